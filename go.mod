@@ -4,12 +4,13 @@ go 1.20
 
 require (
 	github.com/golang/protobuf v1.5.0
+	github.com/google/go-cmp v0.5.5
 	golang.org/x/mod v0.11.0
 	golang.org/x/tools v0.2.0
+	google.golang.org/protobuf v1.30.0
 )
 
 require (
 	github.com/yuin/goldmark v1.4.13 // indirect
 	golang.org/x/sys v0.1.0 // indirect
-	google.golang.org/protobuf v1.30.0 // indirect
 )
@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package analyzer provides static checks of the tests using gomock, to be
+// run by go vet through a vet tool built with the
+// golang.org/x/tools/go/analysis/singlechecker or multichecker packages:
+//
+//	func main() { singlechecker.Main(analyzer.AnyTimes) }
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// AnyTimes reports the mocks of a function on which every expectation is
+// declared with AnyTimes and matches every argument with gomock.Any(), such
+// as:
+//
+//	m.EXPECT().Get(gomock.Any()).Return(1, nil).AnyTimes()
+//	m.EXPECT().Put(gomock.Any(), gomock.Any()).AnyTimes()
+//
+// Such a mock only stubs its methods: the test would pass whether the code
+// under test calls them or not, with whatever arguments. Mocks with fewer
+// expectations than the min_expectations flag, 1 by default, are not
+// reported.
+var AnyTimes = &analysis.Analyzer{
+	Name: "anytimes",
+	Doc:  "report mocks whose expectations all match any call any number of times",
+	Run:  runAnyTimes,
+}
+
+var minExpectations int
+
+func init() {
+	AnyTimes.Flags.IntVar(&minExpectations, "min_expectations", 1,
+		"report mocks with at least this many expectations")
+}
+
+// gomockPaths are the import paths of the gomock package.
+var gomockPaths = map[string]bool{
+	"go.uber.org/mock/gomock":       true,
+	"github.com/golang/mock/gomock": true,
+}
+
+// expectation is a call to a method of the recorder of a mock.
+type expectation struct {
+	call     *ast.CallExpr
+	anyArgs  bool // whether every argument is gomock.Any()
+	anyTimes bool // whether AnyTimes is chained on the call
+}
+
+func runAnyTimes(pass *analysis.Pass) (any, error) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+				checkAnyTimes(pass, fn.Body)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// checkAnyTimes reports the mocks of the function body whose expectations all
+// match any call any number of times.
+func checkAnyTimes(pass *analysis.Pass, body *ast.BlockStmt) {
+	exps := make(map[*ast.CallExpr]*expectation)
+	var mocks []string // in order of first expectation
+	byMock := make(map[string][]*expectation)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if mock, ok := recorderCall(call); ok {
+			e := &expectation{call: call, anyArgs: allAny(pass, call.Args)}
+			exps[call] = e
+			if len(byMock[mock]) == 0 {
+				mocks = append(mocks, mock)
+			}
+			byMock[mock] = append(byMock[mock], e)
+		}
+		return true
+	})
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && isMethodCall(call, "AnyTimes") {
+			if e := exps[chainRoot(call, exps)]; e != nil {
+				e.anyTimes = true
+			}
+		}
+		return true
+	})
+
+	for _, mock := range mocks {
+		es := byMock[mock]
+		if len(es) < minExpectations {
+			continue
+		}
+		vacuous := true
+		for _, e := range es {
+			vacuous = vacuous && e.anyArgs && e.anyTimes
+		}
+		if vacuous {
+			pass.Reportf(es[0].call.Pos(),
+				"every expectation on %s matches any call any number of times, so the test verifies none of its calls", mock)
+		}
+	}
+}
+
+// recorderCall returns the mock of a call to a method of its recorder, such as
+// m in m.EXPECT().Get(key).
+func recorderCall(call *ast.CallExpr) (mock string, ok bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	expect, ok := sel.X.(*ast.CallExpr)
+	if !ok || len(expect.Args) != 0 || !isMethodCall(expect, "EXPECT") {
+		return "", false
+	}
+	return types.ExprString(expect.Fun.(*ast.SelectorExpr).X), true
+}
+
+// chainRoot returns the expectation on which the method call is chained, such
+// as m.EXPECT().Get(key) in m.EXPECT().Get(key).Return(1).AnyTimes().
+func chainRoot(call *ast.CallExpr, exps map[*ast.CallExpr]*expectation) *ast.CallExpr {
+	for {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+		x, ok := astutil.Unparen(sel.X).(*ast.CallExpr)
+		if !ok {
+			return nil
+		}
+		if exps[x] != nil {
+			return x
+		}
+		call = x
+	}
+}
+
+// isMethodCall returns whether call calls a method, or a function of a
+// package, named name.
+func isMethodCall(call *ast.CallExpr, name string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == name
+}
+
+// allAny returns whether every argument is a call to gomock.Any.
+func allAny(pass *analysis.Pass, args []ast.Expr) bool {
+	for _, arg := range args {
+		call, ok := astutil.Unparen(arg).(*ast.CallExpr)
+		if !ok || !isMethodCall(call, "Any") {
+			return false
+		}
+		fn, ok := pass.TypesInfo.Uses[call.Fun.(*ast.SelectorExpr).Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil || !gomockPaths[fn.Pkg().Path()] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go.uber.org/mock/gomock/analyzer"
+)
+
+func TestAnyTimes(t *testing.T) {
+	diags, want := run(t, analyzer.AnyTimes, "a")
+	for line, msg := range diags {
+		if want[line] == nil {
+			t.Errorf("line %d: unexpected diagnostic %q", line, msg)
+		} else if !want[line].MatchString(msg) {
+			t.Errorf("line %d: diagnostic %q does not match %q", line, msg, want[line])
+		}
+	}
+	for line, re := range want {
+		if _, ok := diags[line]; !ok {
+			t.Errorf("line %d: no diagnostic matching %q", line, re)
+		}
+	}
+}
+
+var wantRE = regexp.MustCompile("// want `(.*)`")
+
+// run runs a on the package of testdata/src at path, whose imports are also
+// packages of testdata/src. It returns the diagnostics by line, and the
+// patterns they should match by line, given by comments such as:
+//
+//	m.EXPECT().Get(gomock.Any()) // want `message`
+func run(t *testing.T, a *analysis.Analyzer, path string) (diags map[int]string, want map[int]*regexp.Regexp) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	imp := &srcImporter{fset: fset, pkgs: make(map[string]*types.Package)}
+	files, pkg, info, err := imp.load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags = make(map[int]string)
+	pass := &analysis.Pass{
+		Analyzer:  a,
+		Fset:      fset,
+		Files:     files,
+		Pkg:       pkg,
+		TypesInfo: info,
+		Report: func(d analysis.Diagnostic) {
+			diags[fset.Position(d.Pos).Line] = d.Message
+		},
+	}
+	if _, err := a.Run(pass); err != nil {
+		t.Fatal(err)
+	}
+
+	want = make(map[int]*regexp.Regexp)
+	for _, f := range files {
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				if m := wantRE.FindStringSubmatch(c.Text); m != nil {
+					want[fset.Position(c.Pos()).Line] = regexp.MustCompile(m[1])
+				}
+			}
+		}
+	}
+	return diags, want
+}
+
+// srcImporter type-checks the packages of testdata/src from source, and the
+// standard library from export data.
+type srcImporter struct {
+	fset *token.FileSet
+	pkgs map[string]*types.Package
+}
+
+func (imp *srcImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := imp.pkgs[path]; ok {
+		return pkg, nil
+	}
+	_, pkg, _, err := imp.load(path)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil {
+		return importer.Default().Import(path)
+	}
+	return pkg, nil
+}
+
+// load type-checks the package of testdata/src at path. It returns a nil
+// package if there is none.
+func (imp *srcImporter) load(path string) ([]*ast.File, *types.Package, *types.Info, error) {
+	names, err := filepath.Glob(filepath.Join("testdata", "src", path, "*.go"))
+	if err != nil || len(names) == 0 {
+		return nil, nil, nil, err
+	}
+	var files []*ast.File
+	for _, name := range names {
+		f, err := parser.ParseFile(imp.fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		files = append(files, f)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: imp}
+	pkg, err := conf.Check(path, imp.fset, files, info)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	imp.pkgs[path] = pkg
+	return files, pkg, info, nil
+}
@@ -0,0 +1,37 @@
+package a
+
+import "go.uber.org/mock/gomock"
+
+type MockStore struct{}
+
+func (m *MockStore) EXPECT() *MockStoreRecorder { return nil }
+
+type MockStoreRecorder struct{}
+
+func (mr *MockStoreRecorder) Get(key any) *gomock.Call        { return nil }
+func (mr *MockStoreRecorder) Put(key, value any) *gomock.Call { return nil }
+func (mr *MockStoreRecorder) Close() *gomock.Call             { return nil }
+
+func Vacuous(m *MockStore) {
+	m.EXPECT().Get(gomock.Any()).Return(1, nil).AnyTimes() // want `every expectation on m matches any call any number of times`
+	m.EXPECT().Put(gomock.Any(), (gomock.Any())).AnyTimes()
+	m.EXPECT().Close().AnyTimes()
+}
+
+func SomeArg(m *MockStore) {
+	m.EXPECT().Get(gomock.Any()).AnyTimes()
+	m.EXPECT().Put("a", gomock.Any()).AnyTimes()
+}
+
+func SomeTimes(m *MockStore) {
+	m.EXPECT().Get(gomock.Any()).AnyTimes()
+	m.EXPECT().Put(gomock.Any(), gomock.Any()).Times(2)
+}
+
+func Separate(m, n *MockStore) {
+	m.EXPECT().Get(gomock.Any()).AnyTimes() // want `every expectation on m matches`
+	n.EXPECT().Get(gomock.Any()).AnyTimes()
+	func() {
+		n.EXPECT().Get(gomock.Eq("a")).AnyTimes()
+	}()
+}
@@ -15,10 +15,15 @@
 package gomock
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Call represents an expected call to a mock.
@@ -31,8 +36,36 @@ type Call struct {
 	args       []Matcher    // the args
 	origin     string       // file and line number of call setup
 
+	// ctrl is the Controller the call is registered with, if any. Once set,
+	// its lock guards the fields below, which are changed by the methods
+	// declaring the expectation while the Controller may be matching calls.
+	ctrl *Controller
+
 	preReqs []*Call // prerequisite calls
 
+	tags []string // labels used to select the call in Controller.Verify
+
+	observer bool // whether the call also counts calls matched by other expectations
+
+	ctx context.Context // set by WithContext, returned by CallContext to the actions
+
+	results []any // values given to Return, checked by Controller.SelfCheck
+
+	received [][]any // arguments of the calls matched, see ReceivedArgs
+
+	seqServed int // index of the values of ReturnSeq for the next call
+
+	name string // see Name
+
+	// The time within which the call is expected, and the deadline it sets,
+	// see Within.
+	within   time.Duration
+	deadline time.Time
+
+	onExhausted []func() // see OnExhausted
+
+	notify []func(args []any) // see Notify and NotifyArgs
+
 	// Expectations
 	minCalls, maxCalls int
 
@@ -52,15 +85,7 @@ func newCall(t TestHelper, receiver any, method string, methodType reflect.Type,
 	// TODO: check arity, types.
 	mArgs := make([]Matcher, len(args))
 	for i, arg := range args {
-		if m, ok := arg.(Matcher); ok {
-			mArgs[i] = m
-		} else if arg == nil {
-			// Handle nil specially so that passing a nil interface value
-			// will match the typed nils of concrete args.
-			mArgs[i] = Nil()
-		} else {
-			mArgs[i] = Eq(arg)
-		}
+		mArgs[i] = toMatcher(arg)
 	}
 
 	// callerInfo's skip should be updated if the number of calls between the user's test
@@ -79,8 +104,25 @@ func newCall(t TestHelper, receiver any, method string, methodType reflect.Type,
 		args: mArgs, origin: origin, minCalls: 1, maxCalls: 1, actions: actions}
 }
 
+// toMatcher returns arg if it is a Matcher, and a matcher comparing with arg
+// otherwise.
+func toMatcher(arg any) Matcher {
+	if m, ok := arg.(Matcher); ok {
+		return m
+	}
+	if arg == nil {
+		// Handle nil specially so that passing a nil interface value
+		// will match the typed nils of concrete args.
+		return Nil()
+	}
+	return Eq(arg)
+}
+
 // AnyTimes allows the expectation to be called 0 or more times
 func (c *Call) AnyTimes() *Call {
+	c.lock()
+	defer c.unlock()
+
 	c.minCalls, c.maxCalls = 0, 1e8 // close enough to infinity
 	return c
 }
@@ -88,6 +130,9 @@ func (c *Call) AnyTimes() *Call {
 // MinTimes requires the call to occur at least n times. If AnyTimes or MaxTimes have not been called or if MaxTimes
 // was previously called with 1, MinTimes also sets the maximum number of calls to infinity.
 func (c *Call) MinTimes(n int) *Call {
+	c.lock()
+	defer c.unlock()
+
 	c.minCalls = n
 	if c.maxCalls == 1 {
 		c.maxCalls = 1e8
@@ -98,6 +143,9 @@ func (c *Call) MinTimes(n int) *Call {
 // MaxTimes limits the number of calls to n times. If AnyTimes or MinTimes have not been called or if MinTimes was
 // previously called with 1, MaxTimes also sets the minimum number of calls to 0.
 func (c *Call) MaxTimes(n int) *Call {
+	c.lock()
+	defer c.unlock()
+
 	c.maxCalls = n
 	if c.minCalls == 1 {
 		c.minCalls = 0
@@ -108,24 +156,19 @@ func (c *Call) MaxTimes(n int) *Call {
 // DoAndReturn declares the action to run when the call is matched.
 // The return values from this function are returned by the mocked function.
 // It takes an any argument to support n-arity functions.
-// The anonymous function must match the function signature mocked method.
+// The anonymous function must match the function signature mocked method,
+// which is checked when the expectation is declared.
 func (c *Call) DoAndReturn(f any) *Call {
-	// TODO: Check arity and types here, rather than dying badly elsewhere.
+	c.t.Helper()
+
+	if !c.checkFunc("DoAndReturn", f, true, true) {
+		return c
+	}
 	v := reflect.ValueOf(f)
 
 	c.addAction(func(args []any) []any {
 		c.t.Helper()
 		ft := v.Type()
-		if c.methodType.NumIn() != ft.NumIn() {
-			if ft.IsVariadic() {
-				c.t.Fatalf("wrong number of arguments in DoAndReturn func for %T.%v The function signature must match the mocked method, a variadic function cannot be used.",
-					c.receiver, c.method)
-			} else {
-				c.t.Fatalf("wrong number of arguments in DoAndReturn func for %T.%v: got %d, want %d [%s]",
-					c.receiver, c.method, ft.NumIn(), c.methodType.NumIn(), c.origin)
-			}
-			return nil
-		}
 		vArgs := make([]reflect.Value, len(args))
 		for i := 0; i < len(args); i++ {
 			if args[i] != nil {
@@ -149,24 +192,19 @@ func (c *Call) DoAndReturn(f any) *Call {
 // return values are ignored to retain backward compatibility. To use the
 // return values call DoAndReturn.
 // It takes an any argument to support n-arity functions.
-// The anonymous function must match the function signature mocked method.
+// The anonymous function must match the function signature mocked method,
+// which is checked when the expectation is declared.
 func (c *Call) Do(f any) *Call {
-	// TODO: Check arity and types here, rather than dying badly elsewhere.
+	c.t.Helper()
+
+	if !c.checkFunc("Do", f, true, false) {
+		return c
+	}
 	v := reflect.ValueOf(f)
 
 	c.addAction(func(args []any) []any {
 		c.t.Helper()
 		ft := v.Type()
-		if c.methodType.NumIn() != ft.NumIn() {
-			if ft.IsVariadic() {
-				c.t.Fatalf("wrong number of arguments in Do func for %T.%v The function signature must match the mocked method, a variadic function cannot be used.",
-					c.receiver, c.method)
-			} else {
-				c.t.Fatalf("wrong number of arguments in Do func for %T.%v: got %d, want %d [%s]",
-					c.receiver, c.method, ft.NumIn(), c.methodType.NumIn(), c.origin)
-			}
-			return nil
-		}
 		vArgs := make([]reflect.Value, len(args))
 		for i := 0; i < len(args); i++ {
 			if args[i] != nil {
@@ -182,14 +220,296 @@ func (c *Call) Do(f any) *Call {
 	return c
 }
 
+// DoLoose is like Do, but converts each argument that is not assignable to the
+// corresponding parameter of f, such as the value of an any-typed parameter
+// when f declares a named type, or a wider numeric type, for it. The test
+// fails if an argument cannot be converted, instead of panicking.
+func (c *Call) DoLoose(f any) *Call {
+	c.t.Helper()
+
+	if !c.checkFunc("DoLoose", f, false, false) {
+		return c
+	}
+	v := reflect.ValueOf(f)
+
+	c.addAction(func(args []any) []any {
+		c.t.Helper()
+		ft := v.Type()
+		vArgs := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			t := paramType(ft, i)
+			vArg, ok := convertArg(arg, t)
+			if !ok {
+				c.t.Fatalf("argument %d to DoLoose func for %T.%v: %T is not convertible to %v [%s]",
+					i, c.receiver, c.method, arg, t, c.origin)
+				return nil
+			}
+			vArgs[i] = vArg
+		}
+		v.Call(vArgs)
+		return nil
+	})
+	return c
+}
+
+// checkFunc fails the test unless f, the function given to the action named
+// name, takes as many parameters as the mocked method, of compatible types if
+// params is set, and returns compatible results if results is set. It
+// reports whether f is valid. Checking when the expectation is declared
+// reports a mistake at the line where it is written, even if the call never
+// happens.
+func (c *Call) checkFunc(name string, f any, params, results bool) bool {
+	c.t.Helper()
+
+	ft := reflect.TypeOf(f)
+	if ft == nil || ft.Kind() != reflect.Func {
+		c.t.Fatalf("argument to %s for %T.%v is not a function: %T [%s]",
+			name, c.receiver, c.method, f, c.origin)
+		return false
+	}
+	mt := c.methodType
+	if mt.NumIn() != ft.NumIn() {
+		if ft.IsVariadic() {
+			c.t.Fatalf("wrong number of arguments in %s func for %T.%v The function signature must match the mocked method, a variadic function cannot be used.",
+				name, c.receiver, c.method)
+		} else {
+			c.t.Fatalf("wrong number of arguments in %s func for %T.%v: got %d, want %d [%s]",
+				name, c.receiver, c.method, ft.NumIn(), mt.NumIn(), c.origin)
+		}
+		return false
+	}
+	if mt.IsVariadic() != ft.IsVariadic() {
+		c.t.Fatalf("%s func for %T.%v must be variadic if and only if the mocked method is [%s]",
+			name, c.receiver, c.method, c.origin)
+		return false
+	}
+	if params {
+		for i := 0; i < mt.NumIn(); i++ {
+			in, fin := mt.In(i), ft.In(i)
+			if i == mt.NumIn()-1 && mt.IsVariadic() {
+				// The variadic arguments are passed one by one.
+				in, fin = in.Elem(), fin.Elem()
+			}
+			if !compatibleTypes(in, fin) {
+				c.t.Fatalf("wrong type of argument %d in %s func for %T.%v: got %v, want %v [%s]",
+					i, name, c.receiver, c.method, ft.In(i), mt.In(i), c.origin)
+				return false
+			}
+		}
+	}
+	if results {
+		if mt.NumOut() != ft.NumOut() {
+			c.t.Fatalf("wrong number of results in %s func for %T.%v: got %d, want %d [%s]",
+				name, c.receiver, c.method, ft.NumOut(), mt.NumOut(), c.origin)
+			return false
+		}
+		for i := 0; i < mt.NumOut(); i++ {
+			if !compatibleTypes(mt.Out(i), ft.Out(i)) {
+				c.t.Fatalf("wrong type of result %d in %s func for %T.%v: got %v, want %v [%s]",
+					i, name, c.receiver, c.method, ft.Out(i), mt.Out(i), c.origin)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// compatibleTypes returns whether some values can have both types a and b,
+// such as an int and an any.
+func compatibleTypes(a, b reflect.Type) bool {
+	return a.AssignableTo(b) || b.AssignableTo(a) ||
+		a.Kind() == reflect.Interface && b.Kind() == reflect.Interface
+}
+
 // Return declares the values to be returned by the mocked function call.
 func (c *Call) Return(rets ...any) *Call {
 	c.t.Helper()
 
+	c.checkReturns("Return", rets)
+	c.lock()
+	c.results = rets
+	c.unlock()
+	c.addAction(func([]any) []any {
+		return rets
+	})
+
+	return c
+}
+
+// ReturnSeq declares the values to be returned by the successive calls
+// matching the expectation: the nth call returns valuesPerCall[n-1], and the
+// calls past the end of the sequence return its last values. The number of
+// calls expected is left to Times.
+//
+// Example usage:
+//
+//	m.EXPECT().Get("a").ReturnSeq(
+//	  []any{"", errUnavailable},
+//	  []any{"", errUnavailable},
+//	  []any{"value", nil},
+//	).Times(3)
+func (c *Call) ReturnSeq(valuesPerCall ...[]any) *Call {
+	c.t.Helper()
+
+	if len(valuesPerCall) == 0 {
+		c.t.Fatalf("ReturnSeq called with no values for %T.%v [%s]", c.receiver, c.method, c.origin)
+		return c
+	}
+	for _, rets := range valuesPerCall {
+		c.checkReturns("ReturnSeq", rets)
+	}
+	c.lock()
+	c.results = valuesPerCall[0]
+	c.seqServed = 0
+	c.unlock()
+
+	c.addAction(func([]any) []any {
+		c.lock()
+		defer c.unlock()
+
+		rets := valuesPerCall[c.seqServed]
+		if c.seqServed < len(valuesPerCall)-1 {
+			c.seqServed++
+		}
+		return rets
+	})
+	return c
+}
+
+// ReturnFn declares a function computing the values to be returned by the
+// mocked function call from its arguments, so that a single expectation can
+// answer each call differently without the function having the signature of
+// the method, as DoAndReturn requires. The values are checked against the
+// results of the method when the call is made.
+//
+// Example usage:
+//
+//	prices := map[string]int{"apple": 1, "pear": 2}
+//	m.EXPECT().Price(gomock.Any()).ReturnFn(func(args ...any) []any {
+//	  price, ok := prices[args[0].(string)]
+//	  return []any{price, ok}
+//	}).AnyTimes()
+func (c *Call) ReturnFn(f func(args ...any) []any) *Call {
+	c.t.Helper()
+
+	if f == nil {
+		c.t.Fatalf("nil function given to ReturnFn for %T.%v [%s]", c.receiver, c.method, c.origin)
+		return c
+	}
+	c.addAction(func(args []any) []any {
+		c.t.Helper()
+		rets := f(args...)
+		c.checkReturns("ReturnFn func", rets)
+		return rets
+	})
+	return c
+}
+
+// Panic declares that the mocked function call panics with v, to exercise
+// the recovery of the code under test. The actions declared before Panic run
+// first; those declared after it do not run.
+//
+// Example usage:
+//
+//	m.EXPECT().Handle(gomock.Any()).Panic("handler crashed")
+func (c *Call) Panic(v any) *Call {
+	c.t.Helper()
+
+	c.addAction(func([]any) []any {
+		panic(v)
+	})
+	return c
+}
+
+// ReturnFromJSON declares the values to be returned by the mocked function
+// call, decoded from a JSON array with an element per result, so that large
+// responses can live in fixture files rather than in the test. src is the
+// path of the file, or the JSON itself as a []byte. Results of type error are
+// decoded from their text, or null.
+//
+// Example usage:
+//
+//	m.EXPECT().Get("a").ReturnFromJSON("testdata/get_a.json")
+//	m.EXPECT().Get("b").ReturnFromJSON([]byte(`[{"name": "b"}, "not found"]`))
+func (c *Call) ReturnFromJSON(src any) *Call {
+	c.t.Helper()
+
+	var data []byte
+	switch src := src.(type) {
+	case string:
+		b, err := os.ReadFile(src)
+		if err != nil {
+			c.t.Fatalf("ReturnFromJSON for %T.%v: %v [%s]", c.receiver, c.method, err, c.origin)
+			return c
+		}
+		data = b
+	case []byte:
+		data = src
+	default:
+		c.t.Fatalf("argument to ReturnFromJSON for %T.%v is a %T, not a path or a []byte [%s]",
+			c.receiver, c.method, src, c.origin)
+		return c
+	}
+
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		c.t.Fatalf("ReturnFromJSON for %T.%v: results are not a JSON array: %v [%s]",
+			c.receiver, c.method, err, c.origin)
+		return c
+	}
+	mt := c.methodType
+	if len(elems) != mt.NumOut() {
+		c.t.Fatalf("wrong number of results to ReturnFromJSON for %T.%v: got %d, want %d [%s]",
+			c.receiver, c.method, len(elems), mt.NumOut(), c.origin)
+		return c
+	}
+	rets := make([]any, len(elems))
+	for i, elem := range elems {
+		ret, err := decodeResult(elem, mt.Out(i))
+		if err != nil {
+			c.t.Fatalf("cannot decode result %d of ReturnFromJSON for %T.%v as %v: %v [%s]",
+				i, c.receiver, c.method, mt.Out(i), err, c.origin)
+			return c
+		}
+		rets[i] = ret
+	}
+
+	c.addAction(func([]any) []any {
+		return rets
+	})
+	return c
+}
+
+// decodeResult decodes a result of type t from JSON. Errors are decoded from
+// their text.
+func decodeResult(data json.RawMessage, t reflect.Type) (any, error) {
+	v := reflect.New(t)
+	if t == errorType {
+		var msg *string
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, err
+		}
+		if msg != nil {
+			v.Elem().Set(reflect.ValueOf(errors.New(*msg)))
+		}
+		return v.Elem().Interface(), nil
+	}
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
+
+// checkReturns fails the test unless rets, given to the method named name,
+// are valid results of the mocked method. It converts the values assignable
+// to the result types in place.
+func (c *Call) checkReturns(name string, rets []any) {
+	c.t.Helper()
+
 	mt := c.methodType
 	if len(rets) != mt.NumOut() {
-		c.t.Fatalf("wrong number of arguments to Return for %T.%v: got %d, want %d [%s]",
-			c.receiver, c.method, len(rets), mt.NumOut(), c.origin)
+		c.t.Fatalf("wrong number of arguments to %s for %T.%v: got %d, want %d [%s]",
+			name, c.receiver, c.method, len(rets), mt.NumOut(), c.origin)
 	}
 	for i, ret := range rets {
 		if got, want := reflect.TypeOf(ret), mt.Out(i); got == want {
@@ -200,8 +520,8 @@ func (c *Call) Return(rets ...any) *Call {
 			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
 				// ok
 			default:
-				c.t.Fatalf("argument %d to Return for %T.%v is nil, but %v is not nillable [%s]",
-					i, c.receiver, c.method, want, c.origin)
+				c.t.Fatalf("argument %d to %s for %T.%v is nil, but %v is not nillable [%s]",
+					i, name, c.receiver, c.method, want, c.origin)
 			}
 		} else if got.AssignableTo(want) {
 			// Assignable type relation. Make the assignment now so that the generated code
@@ -210,24 +530,58 @@ func (c *Call) Return(rets ...any) *Call {
 			v.Set(reflect.ValueOf(ret))
 			rets[i] = v.Interface()
 		} else {
-			c.t.Fatalf("wrong type of argument %d to Return for %T.%v: %v is not assignable to %v [%s]",
-				i, c.receiver, c.method, got, want, c.origin)
+			c.t.Fatalf("wrong type of argument %d to %s for %T.%v: %v is not assignable to %v [%s]",
+				i, name, c.receiver, c.method, got, want, c.origin)
 		}
 	}
-
-	c.addAction(func([]any) []any {
-		return rets
-	})
-
-	return c
 }
 
 // Times declares the exact number of times a function call is expected to be executed.
 func (c *Call) Times(n int) *Call {
+	c.lock()
+	defer c.unlock()
+
 	c.minCalls, c.maxCalls = n, n
 	return c
 }
 
+// TimesBetween declares the range of the number of times a function call is
+// expected to be executed, from min to max included. Unlike MinTimes and
+// MaxTimes, it sets both bounds whatever was declared before.
+func (c *Call) TimesBetween(min, max int) *Call {
+	c.t.Helper()
+
+	if min < 0 || max < min {
+		c.t.Fatalf("TimesBetween(%d, %d) called for %T.%v: the range is invalid [%s]",
+			min, max, c.receiver, c.method, c.origin)
+		return c
+	}
+	c.lock()
+	defer c.unlock()
+
+	c.minCalls, c.maxCalls = min, max
+	return c
+}
+
+// OnExhausted registers f to be called when the expectation reaches its
+// maximum number of calls, after the actions of the last call, so that tests
+// coordinating with goroutines can tell when the work of the mock is done.
+// Expectations allowing any number of calls are never exhausted.
+//
+// Example usage:
+//
+//	done := make(chan struct{})
+//	m.EXPECT().Process(gomock.Any()).Times(3).OnExhausted(func() { close(done) })
+//	go worker(m)
+//	<-done
+func (c *Call) OnExhausted(f func()) *Call {
+	c.lock()
+	defer c.unlock()
+
+	c.onExhausted = append(c.onExhausted, f)
+	return c
+}
+
 // SetArg declares an action that will set the nth argument's value,
 // indirected through a pointer. Or, in the case of a slice and map, SetArg
 // will copy value's elements/key-value pairs into the nth argument.
@@ -277,6 +631,84 @@ func (c *Call) SetArg(n int, value any) *Call {
 	return c
 }
 
+// Observer makes the call an observer expectation, which counts the calls it
+// matches without consuming them: each such call is still matched by a
+// regular expectation, which defines its results, and by every other observer
+// it matches. The results of an observer are ignored, while its other
+// actions, such as Do, still run.
+//
+// Observers suit audit-style assertions, such as a metric being emitted for
+// every request, next to the expectations defining the behavior of the mock:
+//
+//	m.EXPECT().Get("a").Return(1, nil)
+//	m.EXPECT().Get(gomock.Any()).Times(1).Observer()
+//
+// An observer stops counting calls once it has been called its maximum number
+// of times.
+func (c *Call) Observer() *Call {
+	c.lock()
+	defer c.unlock()
+
+	c.observer = true
+	return c
+}
+
+// Name names the expectation in the failure messages involving it, which
+// then read, for instance:
+//
+//	missing call(s) to expectation 'refresh token after expiry' (*MockStore.Get(is equal to token (string))) /path/to/store_test.go:42
+//
+// rather than identifying it by its method and origin alone, which tells
+// the dozens of expectations of a test apart better.
+func (c *Call) Name(name string) *Call {
+	c.lock()
+	defer c.unlock()
+
+	c.name = name
+	return c
+}
+
+// Tag labels the call with the given tags, so that it can be selected with
+// WithTag when calling Controller.Verify.
+func (c *Call) Tag(tags ...string) *Call {
+	c.lock()
+	defer c.unlock()
+
+	c.tags = append(c.tags, tags...)
+	return c
+}
+
+func (c *Call) hasTag(tag string) bool {
+	for _, t := range c.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ReceivedArgs returns the arguments of the calls the expectation matched so
+// far, in order, for the test to check them once the code under test has run:
+//
+//	call := m.EXPECT().Send(gomock.Any()).Return(nil)
+//	// Code under test calling m.Send.
+//	req := call.ReceivedArgs()[0][0].(*Request)
+//
+// The arguments of variadic methods are listed one by one. Slices and maps
+// are those passed to the mock, unless the Controller was created with
+// WithArgSnapshots, in which case they are copies taken when the calls were
+// made.
+func (c *Call) ReceivedArgs() [][]any {
+	c.lock()
+	defer c.unlock()
+
+	received := make([][]any, len(c.received))
+	for i, args := range c.received {
+		received[i] = append([]any(nil), args...)
+	}
+	return received
+}
+
 // isPreReq returns true if other is a direct or indirect prerequisite to c.
 func (c *Call) isPreReq(other *Call) bool {
 	for _, preReq := range c.preReqs {
@@ -294,6 +726,10 @@ func (c *Call) After(preReq *Call) *Call {
 	if c == preReq {
 		c.t.Fatalf("A call isn't allowed to be its own prerequisite")
 	}
+
+	c.lock()
+	defer c.unlock()
+
 	if preReq.isPreReq(c) {
 		c.t.Fatalf("Loop in call order: %v is a prerequisite to %v (possibly indirectly).", c, preReq)
 	}
@@ -318,46 +754,68 @@ func (c *Call) String() string {
 		args[i] = arg.String()
 	}
 	arguments := strings.Join(args, ", ")
+	if c.name != "" {
+		return fmt.Sprintf("expectation '%s' (%T.%v(%s)) %s", c.name, c.receiver, c.method, arguments, c.origin)
+	}
 	return fmt.Sprintf("%T.%v(%s) %s", c.receiver, c.method, arguments, c.origin)
 }
 
+// Receiver returns the mock the expectation is declared on.
+func (c *Call) Receiver() any {
+	return c.receiver
+}
+
+// Method returns the name of the method the expectation is declared on.
+func (c *Call) Method() string {
+	return c.method
+}
+
+// location locates the expectation in failure messages, by its name if it
+// has one and by its origin.
+func (c *Call) location() string {
+	if c.name != "" {
+		return fmt.Sprintf("'%s' at %s", c.name, c.origin)
+	}
+	return "at " + c.origin
+}
+
 // Tests if the given call matches the expected call.
 // If yes, returns nil. If no, returns error with message explaining why it does not match.
 func (c *Call) matches(args []any) error {
 	if !c.methodType.IsVariadic() {
 		if len(args) != len(c.args) {
-			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: %d",
-				c.origin, len(args), len(c.args))
+			return fmt.Errorf("expected call %s has the wrong number of arguments. Got: %d, want: %d",
+				c.location(), len(args), len(c.args))
 		}
 
 		for i, m := range c.args {
 			if !m.Matches(args[i]) {
 				return fmt.Errorf(
-					"expected call at %s doesn't match the argument at index %d.\nGot: %v\nWant: %v",
-					c.origin, i, formatGottenArg(m, args[i]), m,
+					"expected call %s doesn't match the argument at index %d.\nGot: %v\nWant: %v%s",
+					c.location(), i, formatGottenArg(m, args[i]), m, formatDiff(m, args[i]),
 				)
 			}
 		}
 	} else {
 		if len(c.args) < c.methodType.NumIn()-1 {
-			return fmt.Errorf("expected call at %s has the wrong number of matchers. Got: %d, want: %d",
-				c.origin, len(c.args), c.methodType.NumIn()-1)
+			return fmt.Errorf("expected call %s has the wrong number of matchers. Got: %d, want: %d",
+				c.location(), len(c.args), c.methodType.NumIn()-1)
 		}
 		if len(c.args) != c.methodType.NumIn() && len(args) != len(c.args) {
-			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: %d",
-				c.origin, len(args), len(c.args))
+			return fmt.Errorf("expected call %s has the wrong number of arguments. Got: %d, want: %d",
+				c.location(), len(args), len(c.args))
 		}
 		if len(args) < len(c.args)-1 {
-			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: greater than or equal to %d",
-				c.origin, len(args), len(c.args)-1)
+			return fmt.Errorf("expected call %s has the wrong number of arguments. Got: %d, want: greater than or equal to %d",
+				c.location(), len(args), len(c.args)-1)
 		}
 
 		for i, m := range c.args {
 			if i < c.methodType.NumIn()-1 {
 				// Non-variadic args
 				if !m.Matches(args[i]) {
-					return fmt.Errorf("expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v",
-						c.origin, strconv.Itoa(i), formatGottenArg(m, args[i]), m)
+					return fmt.Errorf("expected call %s doesn't match the argument at index %s.\nGot: %v\nWant: %v%s",
+						c.location(), strconv.Itoa(i), formatGottenArg(m, args[i]), m, formatDiff(m, args[i]))
 				}
 				continue
 			}
@@ -400,27 +858,42 @@ func (c *Call) matches(args []any) error {
 			// Got Foo(a, b, c, d, e) want Foo(matcherA, matcherB, matcherC, matcherD)
 			// Got Foo(a, b, c) want Foo(matcherA, matcherB)
 
-			return fmt.Errorf("expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v",
-				c.origin, strconv.Itoa(i), formatGottenArg(m, args[i:]), c.args[i])
+			return fmt.Errorf("expected call %s doesn't match the argument at index %s.\nGot: %v\nWant: %v",
+				c.location(), strconv.Itoa(i), formatGottenArg(m, args[i:]), c.args[i])
 		}
 	}
 
 	// Check that all prerequisite calls have been satisfied.
 	for _, preReqCall := range c.preReqs {
 		if !preReqCall.satisfied() {
-			return fmt.Errorf("expected call at %s doesn't have a prerequisite call satisfied:\n%v\nshould be called before:\n%v",
-				c.origin, preReqCall, c)
+			return fmt.Errorf("expected call %s doesn't have a prerequisite call satisfied:\n%v\nshould be called before:\n%v",
+				c.location(), preReqCall, c)
 		}
 	}
 
 	// Check that the call is not exhausted.
 	if c.exhausted() {
-		return fmt.Errorf("expected call at %s has already been called the max number of times", c.origin)
+		return fmt.Errorf("expected call %s has already been called the max number of times", c.location())
 	}
 
 	return nil
 }
 
+// specificity ranks how narrowly the call matches: each argument matched by
+// a matcher other than Any outweighs having a bounded number of calls.
+func (c *Call) specificity() int {
+	var n int
+	for _, m := range c.args {
+		if _, ok := m.(anyMatcher); !ok {
+			n += 2
+		}
+	}
+	if c.maxCalls < 1e8 {
+		n++
+	}
+	return n
+}
+
 // dropPrereqs tells the expected Call to not re-check prerequisite calls any
 // longer, and to return its current set.
 func (c *Call) dropPrereqs() (preReqs []*Call) {
@@ -441,6 +914,40 @@ func InOrder(calls ...*Call) {
 	}
 }
 
+// CallGroup is a group of calls that may occur in any order, declared by
+// AnyOrder.
+type CallGroup struct {
+	calls []*Call
+}
+
+// AnyOrder declares that the given calls may occur in any order, and returns
+// them as a group to be followed by other calls with Then. Along with InOrder,
+// it describes systems that are only partially ordered, such as requests sent
+// concurrently before a final one:
+//
+//	gomock.AnyOrder(
+//	  m.EXPECT().Fetch("a"),
+//	  m.EXPECT().Fetch("b"),
+//	  m.EXPECT().Fetch("c"),
+//	).Then(
+//	  m.EXPECT().Merge(),
+//	)
+func AnyOrder(calls ...*Call) *CallGroup {
+	return &CallGroup{calls: calls}
+}
+
+// Then declares that the given calls may only occur after all the calls of
+// g, and in any order among themselves. It returns them as a group so that
+// further calls can follow them.
+func (g *CallGroup) Then(calls ...*Call) *CallGroup {
+	for _, c := range calls {
+		for _, preReq := range g.calls {
+			c.After(preReq)
+		}
+	}
+	return AnyOrder(calls...)
+}
+
 func setSlice(arg any, v reflect.Value) {
 	va := reflect.ValueOf(arg)
 	for i := 0; i < v.Len(); i++ {
@@ -459,13 +966,79 @@ func setMap(arg any, v reflect.Value) {
 }
 
 func (c *Call) addAction(action func([]any) []any) {
+	c.lock()
+	defer c.unlock()
+
 	c.actions = append(c.actions, action)
 }
 
+// lock locks the Controller the expectation is registered with, if any.
+func (c *Call) lock() {
+	if c.ctrl != nil {
+		c.ctrl.lock()
+	}
+}
+
+func (c *Call) unlock() {
+	if c.ctrl != nil {
+		c.ctrl.unlock()
+	}
+}
+
+// paramType returns the type of the parameter of the function type ft
+// receiving its argument i, the arguments of a variadic parameter being
+// passed one by one.
+func paramType(ft reflect.Type, i int) reflect.Type {
+	if ft.IsVariadic() && i >= ft.NumIn()-1 {
+		return ft.In(ft.NumIn() - 1).Elem()
+	}
+	return ft.In(i)
+}
+
+// convertArg returns arg as a value of type t, converting it if it is not
+// assignable to t. A nil arg is the zero value of t.
+func convertArg(arg any, t reflect.Type) (reflect.Value, bool) {
+	if arg == nil {
+		return reflect.Zero(t), true
+	}
+	v := reflect.ValueOf(arg)
+	switch k := v.Kind(); {
+	case v.Type().AssignableTo(t):
+		return v, true
+	case k >= reflect.Int && k <= reflect.Uintptr && t.Kind() == reflect.String:
+		// Converting an integer to a string yields a rune, not its digits.
+		return reflect.Value{}, false
+	case v.CanConvert(t):
+		return v.Convert(t), true
+	}
+	return reflect.Value{}, false
+}
+
+// formatGottenArg formats arg with the GotFormatter of m if it has one, and
+// with the one registered for the type of arg otherwise.
 func formatGottenArg(m Matcher, arg any) string {
-	got := fmt.Sprintf("%v (%T)", arg, arg)
 	if gs, ok := m.(GotFormatter); ok {
-		got = gs.Got(arg)
+		return gs.Got(arg)
+	}
+	if gs, ok := registeredGotFormatter(arg); ok {
+		return gs.Got(arg)
+	}
+	return fmt.Sprintf("%v (%T)", arg, arg)
+}
+
+// structuralDiffer is implemented by matchers describing how a value differs
+// from the one they expect, such as the one returned by Eq.
+type structuralDiffer interface {
+	structuralDiff(x any) string
+}
+
+// formatDiff returns the differences between arg and the value expected by
+// m, if m describes them, to follow the Got and Want lines of a mismatch.
+func formatDiff(m Matcher, arg any) string {
+	if d, ok := m.(structuralDiffer); ok {
+		if diff := d.structuralDiff(arg); diff != "" {
+			return "\nDiff (-want +got):\n" + strings.TrimSuffix(diff, "\n")
+		}
 	}
-	return got
+	return ""
 }
@@ -15,7 +15,10 @@
 package gomock
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -119,7 +122,9 @@ type testCase struct {
 	doFunc      any
 	callFunc    any
 	args        []any
-	expectPanic bool
+	wantErr     bool // whether the function is rejected when declared
+	// Whether the results of the function are rejected by DoAndReturn.
+	wantResultErr bool
 }
 
 var testCases []testCase = []testCase{
@@ -128,21 +133,21 @@ var testCases []testCase = []testCase{
 		doFunc:      "meow",
 		callFunc:    func(x int, y int) {},
 		args:        []any{0, 1},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "argument to Do is not a function",
 		doFunc:      "meow",
 		callFunc: func(x int, y int) bool {
 			return true
 		},
-		args:        []any{0, 1},
-		expectPanic: true,
+		args:    []any{0, 1},
+		wantErr: true,
 	}, {
 		description: "number of args for Do func don't match Call func",
 		doFunc:      func(x int) {},
 		callFunc:    func(x int, y int) {},
 		args:        []any{0, 1},
-		expectPanic: false,
+		wantErr:     true,
 	}, {
 		description: "number of args for Do func don't match Call func",
 		doFunc: func(x int) bool {
@@ -151,14 +156,14 @@ var testCases []testCase = []testCase{
 		callFunc: func(x int, y int) bool {
 			return true
 		},
-		args:        []any{0, 1},
-		expectPanic: false,
+		args:    []any{0, 1},
+		wantErr: true,
 	}, {
 		description: "arg type for Do func incompatible with Call func",
 		doFunc:      func(x int) {},
 		callFunc:    func(x string) {},
 		args:        []any{"meow"},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "arg type for Do func incompatible with Call func",
 		doFunc: func(x int) bool {
@@ -167,8 +172,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x string) bool {
 			return true
 		},
-		args:        []any{"meow"},
-		expectPanic: true,
+		args:    []any{"meow"},
+		wantErr: true,
 	}, {
 		description: "Do func(int) Call func(int)",
 		doFunc:      func(x int) {},
@@ -202,7 +207,7 @@ var testCases []testCase = []testCase{
 		doFunc:      func(x string) {},
 		callFunc:    func(x []byte) {},
 		args:        []any{[]byte("meow")},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func(string) bool Call func([]byte) bool",
 		doFunc: func(x string) bool {
@@ -211,20 +216,20 @@ var testCases []testCase = []testCase{
 		callFunc: func(x []byte) bool {
 			return true
 		},
-		args:        []any{[]byte("meow")},
-		expectPanic: true,
+		args:    []any{[]byte("meow")},
+		wantErr: true,
 	}, {
 		description: "Do func(map[int]string) Call func(map[any]int)",
 		doFunc:      func(x map[int]string) {},
 		callFunc:    func(x map[any]int) {},
 		args:        []any{map[any]int{"meow": 0}},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func(map[int]string) Call func(map[any]any)",
 		doFunc:      func(x map[int]string) {},
 		callFunc:    func(x map[any]any) {},
 		args:        []any{map[any]any{"meow": "meow"}},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func(map[int]string) bool Call func(map[any]int) bool",
 		doFunc: func(x map[int]string) bool {
@@ -233,8 +238,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x map[any]int) bool {
 			return true
 		},
-		args:        []any{map[any]int{"meow": 0}},
-		expectPanic: true,
+		args:    []any{map[any]int{"meow": 0}},
+		wantErr: true,
 	}, {
 		description: "Do func(map[int]string) bool Call func(map[any]any) bool",
 		doFunc: func(x map[int]string) bool {
@@ -243,20 +248,20 @@ var testCases []testCase = []testCase{
 		callFunc: func(x map[any]any) bool {
 			return true
 		},
-		args:        []any{map[any]any{"meow": "meow"}},
-		expectPanic: true,
+		args:    []any{map[any]any{"meow": "meow"}},
+		wantErr: true,
 	}, {
 		description: "Do func([]string) Call func([]any)",
 		doFunc:      func(x []string) {},
 		callFunc:    func(x []any) {},
 		args:        []any{[]any{0}},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func([]string) Call func([]int)",
 		doFunc:      func(x []string) {},
 		callFunc:    func(x []int) {},
 		args:        []any{[]int{0, 1}},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func([]int) Call func([]int)",
 		doFunc:      func(x []int) {},
@@ -267,19 +272,19 @@ var testCases []testCase = []testCase{
 		doFunc:      func(x []int) {},
 		callFunc:    func(x []any) {},
 		args:        []any{[]any{0}},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func([]int) Call func(...any)",
 		doFunc:      func(x []int) {},
 		callFunc:    func(x ...any) {},
 		args:        []any{0, 1},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func([]int) Call func(...int)",
 		doFunc:      func(x []int) {},
 		callFunc:    func(x ...int) {},
 		args:        []any{0, 1},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func([]string) bool Call func([]any) bool",
 		doFunc: func(x []string) bool {
@@ -288,8 +293,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x []any) bool {
 			return true
 		},
-		args:        []any{[]any{0}},
-		expectPanic: true,
+		args:    []any{[]any{0}},
+		wantErr: true,
 	}, {
 		description: "Do func([]string) bool Call func([]int) bool",
 		doFunc: func(x []string) bool {
@@ -298,8 +303,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x []int) bool {
 			return true
 		},
-		args:        []any{[]int{0, 1}},
-		expectPanic: true,
+		args:    []any{[]int{0, 1}},
+		wantErr: true,
 	}, {
 		description: "Do func([]int) bool Call func([]int) bool",
 		doFunc: func(x []int) bool {
@@ -317,8 +322,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x []any) bool {
 			return true
 		},
-		args:        []any{[]any{0}},
-		expectPanic: true,
+		args:    []any{[]any{0}},
+		wantErr: true,
 	}, {
 		description: "Do func([]int) bool Call func(...any) bool",
 		doFunc: func(x []int) bool {
@@ -327,8 +332,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x ...any) bool {
 			return true
 		},
-		args:        []any{0, 1},
-		expectPanic: true,
+		args:    []any{0, 1},
+		wantErr: true,
 	}, {
 		description: "Do func([]int) bool Call func(...int) bool",
 		doFunc: func(x []int) bool {
@@ -337,20 +342,20 @@ var testCases []testCase = []testCase{
 		callFunc: func(x ...int) bool {
 			return true
 		},
-		args:        []any{0, 1},
-		expectPanic: true,
+		args:    []any{0, 1},
+		wantErr: true,
 	}, {
 		description: "Do func(...int) Call func([]int)",
 		doFunc:      func(x ...int) {},
 		callFunc:    func(x []int) {},
 		args:        []any{[]int{0, 1}},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func(...int) Call func([]any)",
 		doFunc:      func(x ...int) {},
 		callFunc:    func(x []any) {},
 		args:        []any{[]any{0, 1}},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func(...int) Call func(...any)",
 		doFunc:      func(x ...int) {},
@@ -373,8 +378,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x []int) bool {
 			return true
 		},
-		args:        []any{[]int{0, 1}},
-		expectPanic: true,
+		args:    []any{[]int{0, 1}},
+		wantErr: true,
 	}, {
 		description: "Do func(...int) bool Call func([]any) bool",
 		doFunc: func(x ...int) bool {
@@ -383,8 +388,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x []any) bool {
 			return true
 		},
-		args:        []any{[]any{0, 1}},
-		expectPanic: true,
+		args:    []any{[]any{0, 1}},
+		wantErr: true,
 	}, {
 		description: "Do func(...int) bool Call func(...any) bool",
 		doFunc: func(x ...int) bool {
@@ -409,13 +414,13 @@ var testCases []testCase = []testCase{
 		doFunc:      func(x b) {},
 		callFunc:    func(x fmt.Stringer) {},
 		args:        []any{foo{}},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func(b) Call func(a); a and b are not aliases",
 		doFunc:      func(x b) {},
 		callFunc:    func(x a) {},
 		args:        []any{a{}},
-		expectPanic: true,
+		wantErr:     true,
 	}, {
 		description: "Do func(foo) bool; foo implements interface X Call func(interface X) bool",
 		doFunc: func(x foo) bool {
@@ -433,8 +438,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x fmt.Stringer) bool {
 			return true
 		},
-		args:        []any{foo{}},
-		expectPanic: true,
+		args:    []any{foo{}},
+		wantErr: true,
 	}, {
 		description: "Do func(b) bool Call func(a) bool; a and b are not aliases",
 		doFunc: func(x b) bool {
@@ -443,8 +448,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x a) bool {
 			return true
 		},
-		args:        []any{a{}},
-		expectPanic: true,
+		args:    []any{a{}},
+		wantErr: true,
 	}, {
 		description: "Do func(bool) b Call func(bool) a; a and b are not aliases",
 		doFunc: func(x bool) b {
@@ -453,7 +458,8 @@ var testCases []testCase = []testCase{
 		callFunc: func(x bool) a {
 			return a{}
 		},
-		args: []any{true},
+		args:          []any{true},
+		wantResultErr: true,
 	},
 }
 
@@ -462,21 +468,20 @@ func TestCall_Do(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			c := prepareDoCall(tc.doFunc, tc.callFunc)
 
-			if len(c.actions) != 1 {
-				t.Errorf("expected %d actions but got %d", 1, len(c.actions))
+			if tc.wantErr {
+				if tr := c.t.(*mockTestReporter); tr.fatalCalls != 1 {
+					t.Errorf("expected Do to fail")
+				}
+				if len(c.actions) != 0 {
+					t.Errorf("expected %d actions but got %d", 0, len(c.actions))
+				}
+				return
 			}
 
-			action := c.actions[0]
-
-			if tc.expectPanic {
-				defer func() {
-					if r := recover(); r == nil {
-						t.Error("expected Do to panic")
-					}
-				}()
+			if len(c.actions) != 1 {
+				t.Fatalf("expected %d actions but got %d", 1, len(c.actions))
 			}
-
-			action(tc.args)
+			c.actions[0](tc.args)
 		})
 	}
 }
@@ -526,13 +531,126 @@ func TestCall_Do_NumArgValidation(t *testing.T) {
 				methodType: tt.methodType,
 			}
 			call.Do(tt.doFn)
+			if tt.wantErr {
+				if tr.fatalCalls != 1 {
+					t.Fatalf("expected declaration to fail")
+				}
+				return
+			}
 			call.actions[0](tt.args)
-			if tt.wantErr && tr.fatalCalls != 1 {
-				t.Fatalf("expected call to fail")
+			if tr.fatalCalls != 0 {
+				t.Fatalf("expected call to pass")
 			}
-			if !tt.wantErr && tr.fatalCalls != 0 {
+		})
+	}
+}
+
+func TestCall_DoLoose(t *testing.T) {
+	type name string
+	var got any
+	tests := []struct {
+		name       string
+		methodType reflect.Type
+		doFn       any
+		args       []any
+		want       any
+		wantErr    bool
+	}{
+		{
+			name:       "assignable",
+			methodType: reflect.TypeOf(func(any) {}),
+			doFn:       func(s string) { got = s },
+			args:       []any{"a"},
+			want:       "a",
+		},
+		{
+			name:       "named type",
+			methodType: reflect.TypeOf(func(any) {}),
+			doFn:       func(n name) { got = n },
+			args:       []any{"a"},
+			want:       name("a"),
+		},
+		{
+			name:       "wider numeric type",
+			methodType: reflect.TypeOf(func(any) {}),
+			doFn:       func(n int64) { got = n },
+			args:       []any{1},
+			want:       int64(1),
+		},
+		{
+			name:       "nil",
+			methodType: reflect.TypeOf(func(any) {}),
+			doFn:       func(p *a) { got = p },
+			args:       []any{nil},
+			want:       (*a)(nil),
+		},
+		{
+			name:       "integer to string",
+			methodType: reflect.TypeOf(func(any) {}),
+			doFn:       func(s string) { got = s },
+			args:       []any{65},
+			wantErr:    true,
+		},
+		{
+			name:       "not convertible",
+			methodType: reflect.TypeOf(func(any) {}),
+			doFn:       func(n int) { got = n },
+			args:       []any{"a"},
+			wantErr:    true,
+		},
+		{
+			name:       "too many",
+			methodType: reflect.TypeOf(func(any) {}),
+			doFn:       func(x, y int) { got = x },
+			args:       []any{1},
+			wantErr:    true,
+		},
+		{
+			name:       "variadic",
+			methodType: reflect.TypeOf(func(int, ...any) {}),
+			doFn:       func(n int, names ...name) { got = fmt.Sprint(n, names) },
+			args:       []any{1, "a", "b", "c"},
+			want:       "1 [a b c]",
+		},
+		{
+			name:       "variadic without arguments",
+			methodType: reflect.TypeOf(func(int, ...any) {}),
+			doFn:       func(n int, names ...name) { got = fmt.Sprint(n, names) },
+			args:       []any{1},
+			want:       "1 []",
+		},
+		{
+			name:       "variadic not convertible",
+			methodType: reflect.TypeOf(func(int, ...any) {}),
+			doFn:       func(n int, names ...name) { got = fmt.Sprint(n, names) },
+			args:       []any{1, "a", 2.5},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &mockTestReporter{}
+			call := &Call{
+				t:          tr,
+				methodType: tt.methodType,
+			}
+			got = nil
+			call.DoLoose(tt.doFn)
+			if len(call.actions) != 0 {
+				call.actions[0](tt.args)
+			}
+			if tt.wantErr {
+				if tr.fatalCalls != 1 {
+					t.Fatalf("expected call to fail")
+				}
+				return
+			}
+			if tr.fatalCalls != 0 {
 				t.Fatalf("expected call to pass")
 			}
+			if got != tt.want {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
 		})
 	}
 }
@@ -582,11 +700,14 @@ func TestCall_DoAndReturn_NumArgValidation(t *testing.T) {
 				methodType: tt.methodType,
 			}
 			call.DoAndReturn(tt.doFn)
-			call.actions[0](tt.args)
-			if tt.wantErr && tr.fatalCalls != 1 {
-				t.Fatalf("expected call to fail")
+			if tt.wantErr {
+				if tr.fatalCalls != 1 {
+					t.Fatalf("expected declaration to fail")
+				}
+				return
 			}
-			if !tt.wantErr && tr.fatalCalls != 0 {
+			call.actions[0](tt.args)
+			if tr.fatalCalls != 0 {
 				t.Fatalf("expected call to pass")
 			}
 		})
@@ -598,21 +719,173 @@ func TestCall_DoAndReturn(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			c := prepareDoAndReturnCall(tc.doFunc, tc.callFunc)
 
+			if tc.wantErr || tc.wantResultErr {
+				if tr := c.t.(*mockTestReporter); tr.fatalCalls != 1 {
+					t.Errorf("expected DoAndReturn to fail")
+				}
+				if len(c.actions) != 0 {
+					t.Errorf("expected %d actions but got %d", 0, len(c.actions))
+				}
+				return
+			}
+
 			if len(c.actions) != 1 {
-				t.Errorf("expected %d actions but got %d", 1, len(c.actions))
+				t.Fatalf("expected %d actions but got %d", 1, len(c.actions))
 			}
+			c.actions[0](tc.args)
+		})
+	}
+}
 
-			action := c.actions[0]
+func TestCall_ReturnSeq(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+	tr := &mockTestReporter{}
+	c := &Call{t: tr, methodType: reflect.TypeOf(func(string) (string, error) { return "", nil })}
+	c.ReturnSeq(
+		[]any{"", errUnavailable},
+		[]any{"value", nil},
+	)
+
+	if tr.fatalCalls != 0 {
+		t.Fatalf("ReturnSeq failed %d times", tr.fatalCalls)
+	}
+	if len(c.actions) != 1 {
+		t.Fatalf("expected %d actions but got %d", 1, len(c.actions))
+	}
+	for i, want := range [][]any{
+		{"", errUnavailable},
+		{"value", nil},
+		{"value", nil},
+	} {
+		if got := c.actions[0](nil); !reflect.DeepEqual(got, want) {
+			t.Errorf("call %d returned %#v, want %#v", i+1, got, want)
+		}
+	}
+}
+
+func TestCall_ReturnSeq_Invalid(t *testing.T) {
+	methodType := reflect.TypeOf(func(string) (string, error) { return "", nil })
+	for _, tc := range []struct {
+		description   string
+		valuesPerCall [][]any
+	}{
+		{"no values", nil},
+		{"wrong number", [][]any{{"value", nil}, {"value"}}},
+		{"wrong type", [][]any{{1, nil}}},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			tr := &mockTestReporter{}
+			c := &Call{t: tr, methodType: methodType}
+			c.ReturnSeq(tc.valuesPerCall...)
 
-			if tc.expectPanic {
-				defer func() {
-					if r := recover(); r == nil {
-						t.Error("expected DoAndReturn to panic")
-					}
-				}()
+			if tr.fatalCalls == 0 {
+				t.Error("expected ReturnSeq to fail")
 			}
+		})
+	}
+}
+
+func TestCall_ReturnFn(t *testing.T) {
+	type count int
+	prices := map[string]count{"apple": 1, "pear": 2}
+	tr := &mockTestReporter{}
+	c := &Call{t: tr, methodType: reflect.TypeOf(func(string) (count, error) { return 0, nil })}
+	c.ReturnFn(func(args ...any) []any {
+		price, ok := prices[args[0].(string)]
+		if !ok {
+			return []any{count(0), errors.New("unknown fruit")}
+		}
+		return []any{price, nil}
+	})
 
-			action(tc.args)
+	if len(c.actions) != 1 {
+		t.Fatalf("expected %d actions but got %d", 1, len(c.actions))
+	}
+	for _, tc := range []struct {
+		fruit string
+		want  []any
+	}{
+		{"pear", []any{count(2), nil}},
+		{"apple", []any{count(1), nil}},
+		{"kiwi", []any{count(0), errors.New("unknown fruit")}},
+	} {
+		if got := c.actions[0]([]any{tc.fruit}); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %#v, want %#v", tc.fruit, got, tc.want)
+		}
+	}
+	if tr.fatalCalls != 0 {
+		t.Errorf("ReturnFn failed %d times", tr.fatalCalls)
+	}
+
+	c.actions = nil
+	c.ReturnFn(func(args ...any) []any { return []any{"1", nil} })
+	c.actions[0]([]any{"pear"})
+	if tr.fatalCalls != 1 {
+		t.Errorf("expected ReturnFn to fail on results of the wrong type")
+	}
+}
+
+func TestCall_ReturnFromJSON(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	methodType := reflect.TypeOf(func(string) (*user, []string, error) { return nil, nil, nil })
+	path := filepath.Join(t.TempDir(), "get.json")
+	if err := os.WriteFile(path, []byte(`[{"name": "gopher", "age": 13}, ["a"], null]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		description string
+		src         any
+		want        []any
+	}{
+		{"path", path, []any{&user{Name: "gopher", Age: 13}, []string{"a"}, nil}},
+		{"bytes", []byte(`[null, [], "not found"]`), []any{(*user)(nil), []string{}, errors.New("not found")}},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			tr := &mockTestReporter{}
+			c := &Call{t: tr, methodType: methodType}
+			c.ReturnFromJSON(tc.src)
+
+			if tr.fatalCalls != 0 {
+				t.Fatalf("ReturnFromJSON failed %d times", tr.fatalCalls)
+			}
+			if len(c.actions) != 1 {
+				t.Fatalf("expected %d actions but got %d", 1, len(c.actions))
+			}
+			if got := c.actions[0](nil); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got results %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCall_ReturnFromJSON_Invalid(t *testing.T) {
+	methodType := reflect.TypeOf(func(string) (int, error) { return 0, nil })
+	for _, tc := range []struct {
+		description string
+		src         any
+	}{
+		{"missing file", filepath.Join(t.TempDir(), "missing.json")},
+		{"not bytes", 42},
+		{"not an array", []byte(`{"a": 1}`)},
+		{"wrong number of results", []byte(`[1]`)},
+		{"wrong type", []byte(`["a", null]`)},
+		{"error not a string", []byte(`[1, 2]`)},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			tr := &mockTestReporter{}
+			c := &Call{t: tr, methodType: methodType}
+			c.ReturnFromJSON(tc.src)
+
+			if tr.fatalCalls != 1 {
+				t.Errorf("number of fatal calls == %v, want 1", tr.fatalCalls)
+			}
+			if len(c.actions) != 0 {
+				t.Errorf("expected %d actions but got %d", 0, len(c.actions))
+			}
 		})
 	}
 }
@@ -18,102 +18,100 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"sync"
 )
 
 // callSet represents a set of expected calls, indexed by receiver and method
-// name.
+// name, kept in an ExpectationStore.
 type callSet struct {
-	// Calls that are still expected.
-	expected   map[callSetKey][]*Call
-	expectedMu *sync.Mutex
-	// Calls that have been exhausted.
-	exhausted map[callSetKey][]*Call
+	store ExpectationStore
 	// when set to true, existing call expectations are overridden when new call expectations are made
 	allowOverride bool
+	// when set to true, the most specific of the matching calls is matched
+	// rather than the first one
+	preferSpecific bool
 }
 
-// callSetKey is the key in the maps in callSet
+// callSetKey is the key of the calls of a method of a mock.
 type callSetKey struct {
 	receiver any
 	fname    string
 }
 
 func newCallSet() *callSet {
-	return &callSet{
-		expected:   make(map[callSetKey][]*Call),
-		expectedMu: &sync.Mutex{},
-		exhausted:  make(map[callSetKey][]*Call),
-	}
+	return &callSet{store: newMemoryStore()}
 }
 
 func newOverridableCallSet() *callSet {
-	return &callSet{
-		expected:      make(map[callSetKey][]*Call),
-		expectedMu:    &sync.Mutex{},
-		exhausted:     make(map[callSetKey][]*Call),
-		allowOverride: true,
-	}
+	return &callSet{store: newMemoryStore(), allowOverride: true}
 }
 
 // Add adds a new expected call.
 func (cs callSet) Add(call *Call) {
-	key := callSetKey{call.receiver, call.method}
-
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
-
-	m := cs.expected
-	if call.exhausted() {
-		m = cs.exhausted
-	}
 	if cs.allowOverride {
-		m[key] = make([]*Call, 0)
+		for _, c := range append([]*Call(nil), cs.store.Expected(call.receiver, call.method)...) {
+			cs.store.Drop(c)
+		}
+	}
+	cs.store.Add(call)
+	if call.exhausted() {
+		cs.store.Remove(call)
 	}
-
-	m[key] = append(m[key], call)
 }
 
 // Remove removes an expected call.
 func (cs callSet) Remove(call *Call) {
-	key := callSetKey{call.receiver, call.method}
-
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
-
-	calls := cs.expected[key]
-	for i, c := range calls {
-		if c == call {
-			// maintain order for remaining calls
-			cs.expected[key] = append(calls[:i], calls[i+1:]...)
-			cs.exhausted[key] = append(cs.exhausted[key], call)
-			break
+	cs.store.Remove(call)
+}
+
+// Drop removes a call from the set, whether it is expected or exhausted.
+func (cs callSet) Drop(call *Call) {
+	cs.store.Drop(call)
+}
+
+// Has returns whether a call to the method of receiver, other than an
+// observer, is expected or exhausted.
+func (cs callSet) Has(receiver any, method string) bool {
+	for _, calls := range [][]*Call{cs.store.Expected(receiver, method), cs.store.Exhausted(receiver, method)} {
+		for _, call := range calls {
+			if !call.observer {
+				return true
+			}
 		}
 	}
+	return false
 }
 
 // FindMatch searches for a matching call. Returns error with explanation message if no call matched.
 func (cs callSet) FindMatch(receiver any, method string, args []any) (*Call, error) {
-	key := callSetKey{receiver, method}
-
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
-
 	// Search through the expected calls.
-	expected := cs.expected[key]
+	expected := cs.store.Expected(receiver, method)
 	var callsErrors bytes.Buffer
+	var observers int
+	var best *Call
 	for _, call := range expected {
+		if call.observer {
+			observers++
+			continue
+		}
 		err := call.matches(args)
 		if err != nil {
 			_, _ = fmt.Fprintf(&callsErrors, "\n%v", err)
-		} else {
+			continue
+		}
+		if !cs.preferSpecific {
 			return call, nil
 		}
+		if best == nil || call.specificity() > best.specificity() {
+			best = call
+		}
+	}
+	if best != nil {
+		return best, nil
 	}
 
 	// If we haven't found a match then search through the exhausted calls so we
 	// get useful error messages.
-	exhausted := cs.exhausted[key]
+	exhausted := cs.store.Exhausted(receiver, method)
 	for _, call := range exhausted {
 		if err := call.matches(args); err != nil {
 			_, _ = fmt.Fprintf(&callsErrors, "\n%v", err)
@@ -124,24 +122,31 @@ func (cs callSet) FindMatch(receiver any, method string, args []any) (*Call, err
 		)
 	}
 
-	if len(expected)+len(exhausted) == 0 {
+	if len(expected)-observers+len(exhausted) == 0 {
 		_, _ = fmt.Fprintf(&callsErrors, "there are no expected calls of the method %q for that receiver", method)
 	}
 
 	return nil, errors.New(callsErrors.String())
 }
 
+// FindObservers returns the observer calls matching a call.
+func (cs callSet) FindObservers(receiver any, method string, args []any) []*Call {
+	var observers []*Call
+	for _, call := range cs.store.Expected(receiver, method) {
+		if call.observer && call.matches(args) == nil {
+			observers = append(observers, call)
+		}
+	}
+	return observers
+}
+
 // Failures returns the calls that are not satisfied.
 func (cs callSet) Failures() []*Call {
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
-
-	failures := make([]*Call, 0, len(cs.expected))
-	for _, calls := range cs.expected {
-		for _, call := range calls {
-			if !call.satisfied() {
-				failures = append(failures, call)
-			}
+	expected, _ := cs.store.All()
+	var failures []*Call
+	for _, call := range expected {
+		if !call.satisfied() {
+			failures = append(failures, call)
 		}
 	}
 	return failures
@@ -149,14 +154,10 @@ func (cs callSet) Failures() []*Call {
 
 // Satisfied returns true in case all expected calls in this callSet are satisfied.
 func (cs callSet) Satisfied() bool {
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
-
-	for _, calls := range cs.expected {
-		for _, call := range calls {
-			if !call.satisfied() {
-				return false
-			}
+	expected, _ := cs.store.All()
+	for _, call := range expected {
+		if !call.satisfied() {
+			return false
 		}
 	}
 
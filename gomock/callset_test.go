@@ -48,13 +48,13 @@ func TestCallSetAdd_WhenOverridable_ClearsPreviousExpectedAndExhausted(t *testin
 	cs := newOverridableCallSet()
 
 	cs.Add(newCall(t, receiver, method, reflect.TypeOf(receiverType{}.Func)))
-	numExpectedCalls := len(cs.expected[callSetKey{receiver, method}])
+	numExpectedCalls := len(cs.store.Expected(receiver, method))
 	if numExpectedCalls != 1 {
 		t.Fatalf("Expected 1 expected call in callset, got %d", numExpectedCalls)
 	}
 
 	cs.Add(newCall(t, receiver, method, reflect.TypeOf(receiverType{}.Func)))
-	newNumExpectedCalls := len(cs.expected[callSetKey{receiver, method}])
+	newNumExpectedCalls := len(cs.store.Expected(receiver, method))
 	if newNumExpectedCalls != 1 {
 		t.Fatalf("Expected 1 expected call in callset, got %d", newNumExpectedCalls)
 	}
@@ -88,7 +88,7 @@ func TestCallSetRemove(t *testing.T) {
 	}
 
 	for _, c := range ourCalls {
-		validateOrder(cs.expected[callSetKey{receiver, method}])
+		validateOrder(cs.store.Expected(receiver, method))
 		cs.Remove(c)
 	}
 }
@@ -101,9 +101,8 @@ func TestCallSetFindMatch(t *testing.T) {
 		args := []any{}
 
 		c1 := newCall(t, receiver, method, reflect.TypeOf(receiverType{}.Func))
-		cs.exhausted = map[callSetKey][]*Call{
-			{receiver: receiver, fname: method}: {c1},
-		}
+		cs.store.Add(c1)
+		cs.store.Remove(c1)
 
 		_, err := cs.FindMatch(receiver, method, args)
 		if err == nil {
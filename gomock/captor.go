@@ -0,0 +1,120 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ArgCaptor is a matcher for the values of type T, which captures the
+// arguments of the calls matching the expectations it is an argument of.
+// It is returned by Captor.
+type ArgCaptor[T any] struct {
+	mu     sync.Mutex
+	values []T
+}
+
+// Captor returns a matcher for the values of type T, which captures the
+// argument of every call matching the expectations it is given to as an
+// argument, for the test to check once the calls are made. Values passed to
+// its Matches method directly, or to a matcher it is nested in, are not
+// captured, nor are those of calls matching other expectations.
+//
+// Example usage:
+//
+//	req := gomock.Captor[*Request]()
+//	m.EXPECT().Send(req).Return(nil)
+//	// Code under test calling m.Send.
+//	if req.Value().Retries != 3 { ... }
+func Captor[T any]() *ArgCaptor[T] {
+	return &ArgCaptor[T]{}
+}
+
+// Matches returns whether x is a T.
+func (c *ArgCaptor[T]) Matches(x any) bool {
+	return isOfType[T](x)
+}
+
+func (c *ArgCaptor[T]) String() string {
+	return fmt.Sprintf("is a %v (captured)", typeOf[T]())
+}
+
+// Got reports values of the wrong type.
+func (c *ArgCaptor[T]) Got(x any) string {
+	return formatTypeMismatch[T](x)
+}
+
+// Value returns the value captured first, or the zero value of T if none
+// was.
+func (c *ArgCaptor[T]) Value() T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var v T
+	if len(c.values) > 0 {
+		v = c.values[0]
+	}
+	return v
+}
+
+// Last returns the value captured last, or the zero value of T if none was.
+func (c *ArgCaptor[T]) Last() T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var v T
+	if len(c.values) > 0 {
+		v = c.values[len(c.values)-1]
+	}
+	return v
+}
+
+// Values returns the values captured, in the order of the calls.
+func (c *ArgCaptor[T]) Values() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]T(nil), c.values...)
+}
+
+func (c *ArgCaptor[T]) capture(x any) {
+	v, _ := x.(T)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values = append(c.values, v)
+}
+
+// argCapturer is implemented by the matchers capturing the arguments of the
+// calls they match, such as the ones returned by Captor.
+type argCapturer interface {
+	capture(x any)
+}
+
+// captureArgs gives the arguments of a call matching c to the matchers of c
+// capturing them.
+func (c *Call) captureArgs(args []any) {
+	for i, m := range c.args {
+		if len(c.args) != len(args) && i == len(c.args)-1 {
+			// The last matcher matches the variadic arguments as a whole.
+			break
+		}
+		if ac, ok := m.(argCapturer); ok {
+			ac.capture(args[i])
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCaptor(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	arg := gomock.Captor[TestStruct]()
+	if got := arg.Value(); got != (TestStruct{}) {
+		t.Errorf("Value() before any call = %v, want the zero value", got)
+	}
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", arg, 1).Return(1).Times(2)
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), 2).Return(2)
+
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 1)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 2}, 2)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 3}, 1)
+	ctrl.Finish()
+	reporter.assertPass("captor matches any TestStruct")
+
+	assertEqual(t, TestStruct{Number: 1}, arg.Value())
+	assertEqual(t, TestStruct{Number: 3}, arg.Last())
+	assertEqual(t, []TestStruct{{Number: 1}, {Number: 3}}, arg.Values())
+}
+
+func TestCaptor_Variadic(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	first, second := gomock.Captor[string](), gomock.Captor[string]()
+	ctrl.RecordCall(subject, "VariadicMethod", 1, first, second)
+	ctrl.Call(subject, "VariadicMethod", 1, "a", "b")
+	ctrl.Finish()
+	reporter.assertPass("captors match the variadic arguments")
+
+	assertEqual(t, []string{"a"}, first.Values())
+	assertEqual(t, []string{"b"}, second.Values())
+}
+
+func TestCaptor_Matches(t *testing.T) {
+	arg := gomock.Captor[int]()
+	if !arg.Matches(1) || arg.Matches("1") {
+		t.Errorf("Captor[int]() should match ints only")
+	}
+	if got := arg.Values(); len(got) != 0 {
+		t.Errorf("Values() = %v, want none captured outside calls", got)
+	}
+	if got, want := arg.String(), "is a int (captured)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
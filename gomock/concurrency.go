@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// concurrencyLimit tracks the calls to a mock in flight, see
+// AssertMaxConcurrency.
+type concurrencyLimit struct {
+	limit    int64
+	inFlight atomic.Int64
+	peak     atomic.Int64
+}
+
+// enter notes the start of a call, and returns a function noting its end.
+func (l *concurrencyLimit) enter() func() {
+	n := l.inFlight.Add(1)
+	for {
+		peak := l.peak.Load()
+		if n <= peak || l.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	return func() { l.inFlight.Add(-1) }
+}
+
+// AssertMaxConcurrency makes the Controller fail the test when it finishes if
+// more than n calls to the methods of the given mock were ever in flight at
+// the same time. A call is in flight from the moment it matches an expectation
+// until its actions, such as those of Do and DoAndReturn, return. Actions that
+// block let tests hold calls in flight to check the limits of worker pools and
+// semaphores of the code under test:
+//
+//	ctrl.AssertMaxConcurrency(mockFetcher, 4)
+//	mockFetcher.EXPECT().Fetch(gomock.Any()).DoAndReturn(func(url string) error {
+//	  time.Sleep(10 * time.Millisecond)
+//	  return nil
+//	}).Times(100)
+func (ctrl *Controller) AssertMaxConcurrency(receiver any, n int) {
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	if ctrl.concurrency == nil {
+		ctrl.concurrency = make(map[any]*concurrencyLimit)
+	}
+	ctrl.concurrency[receiver] = &concurrencyLimit{limit: int64(n)}
+}
+
+// checkConcurrency reports the mocks whose calls exceeded their limit of
+// calls in flight. The Controller must be locked.
+func (ctrl *Controller) checkConcurrency() {
+	ctrl.T.Helper()
+
+	for receiver, l := range ctrl.concurrency {
+		if peak := l.peak.Load(); peak > l.limit {
+			ctrl.T.Errorf("%T had %d calls in flight at the same time, more than the maximum of %d", receiver, peak, l.limit)
+		}
+	}
+}
+
+// ConcurrentCall is an expectation satisfied by concurrent calls, declared
+// by ExpectConcurrent, which captures the arguments of each of them.
+type ConcurrentCall struct {
+	// Call is the expectation, to which further actions can be added.
+	*Call
+
+	mu   sync.Mutex
+	args [][]any
+}
+
+// ExpectConcurrent declares that call is expected n times, possibly from
+// n goroutines at once, as with a fan-out through an errgroup.Group, and
+// captures the arguments of each of its calls. Typed mocks pass the
+// *gomock.Call they wrap:
+//
+//	calls := gomock.ExpectConcurrent(len(urls), m.EXPECT().Fetch(gomock.Any()).Return(nil).Call)
+//	fetchAll(ctx, m, urls)
+//	// calls.Args() holds the arguments of the n calls, such as []any{"https://a"}.
+//
+// Mocks may be called concurrently: the Controller matches calls one at a
+// time, and runs the actions of the calls, such as Do and DoAndReturn, as
+// well as the capture of their arguments, concurrently.
+func ExpectConcurrent(n int, call *Call) *ConcurrentCall {
+	c := &ConcurrentCall{Call: call.Times(n)}
+	call.addAction(func(args []any) []any {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.args = append(c.args, append([]any(nil), args...))
+		return nil
+	})
+	return c
+}
+
+// Args returns the arguments of the calls made so far, in the order they ran
+// their actions.
+func (c *ConcurrentCall) Args() [][]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][]any(nil), c.args...)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// callConcurrently runs call in n goroutines, and waits for them to return.
+func callConcurrently(t *testing.T, n int, call func()) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			call()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAssertMaxConcurrency_Exceeded(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	ctrl.AssertMaxConcurrency(subject, 2)
+
+	// Each call blocks until the three calls are in flight.
+	var entered sync.WaitGroup
+	entered.Add(3)
+	ctrl.RecordCall(subject, "FooMethod", "argument").Do(func(string) {
+		entered.Done()
+		entered.Wait()
+	}).Times(3)
+	callConcurrently(t, 3, func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	})
+
+	ctrl.Finish()
+	reporter.assertFail("Expected the calls in flight to exceed the maximum")
+	if len(reporter.log) != 1 || !strings.Contains(reporter.log[0], "had 3 calls in flight at the same time, more than the maximum of 2") {
+		t.Errorf("unexpected errors: %q", reporter.log)
+	}
+}
+
+func TestAssertMaxConcurrency_Respected(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	ctrl.AssertMaxConcurrency(subject, 2)
+	ctrl.RecordCall(subject, "FooMethod", "argument").Times(10)
+
+	// The code under test limits its calls with a semaphore.
+	sem := make(chan struct{}, 2)
+	callConcurrently(t, 10, func() {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+		ctrl.Call(subject, "FooMethod", "argument")
+	})
+
+	ctrl.Finish()
+	reporter.assertPass("Expected the calls in flight to stay within the maximum")
+}
+
+func TestExpectConcurrent(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	calls := gomock.ExpectConcurrent(10, ctrl.RecordCall(subject, "BarMethod", gomock.Any()).Return(7))
+	var mu sync.Mutex
+	var results []any
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rets := ctrl.Call(subject, "BarMethod", strconv.Itoa(i))
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, rets[0])
+		}(i)
+	}
+	wg.Wait()
+
+	ctrl.Finish()
+	reporter.assertPass("Expected the concurrent calls to satisfy the expectation")
+	var got []string
+	for _, args := range calls.Args() {
+		got = append(got, args[0].(string))
+	}
+	sort.Strings(got)
+	if want := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Args() = %v, want %v", got, want)
+	}
+	for _, r := range results {
+		if r != 7 {
+			t.Errorf("call returned %v, want 7", r)
+		}
+	}
+}
+
+func TestExpectConcurrent_Missing(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	calls := gomock.ExpectConcurrent(3, ctrl.RecordCall(subject, "FooMethod", "argument"))
+	callConcurrently(t, 2, func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	})
+
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+	if n := len(calls.Args()); n != 2 {
+		t.Errorf("%d calls captured, want 2", n)
+	}
+}
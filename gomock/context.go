@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"context"
+	"sync"
+)
+
+// callContexts maps the ID of a goroutine running the actions of a call to
+// the context given to the call by Call.WithContext.
+var callContexts sync.Map
+
+// WithContext makes ctx available to the actions of the call, such as Do and
+// DoAndReturn, through CallContext, even if the mocked method has no context
+// parameter:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	m.EXPECT().Fetch("key").WithContext(ctx).DoAndReturn(func(key string) (string, error) {
+//	  select {
+//	  case v := <-values:
+//	    return v, nil
+//	  case <-gomock.CallContext().Done():
+//	    return "", gomock.CallContext().Err()
+//	  }
+//	})
+func (c *Call) WithContext(ctx context.Context) *Call {
+	c.lock()
+	defer c.unlock()
+
+	c.ctx = ctx
+	return c
+}
+
+// CallContext returns the context of the call whose actions are running: the
+// context given by Call.WithContext, or else the first context.Context among
+// args, or else context.Background. args are the arguments of the call, which
+// may be omitted when the method has no context parameter; passing them lets
+// stubs get a context uniformly, whether it comes from the test or from the
+// code under test.
+func CallContext(args ...any) context.Context {
+	if ctx, ok := callContexts.Load(goroutineID()); ok {
+		return ctx.(context.Context)
+	}
+	for _, arg := range args {
+		if ctx, ok := arg.(context.Context); ok && ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
+// withCallContext returns an action running action with ctx as the context
+// returned by CallContext.
+func withCallContext(ctx context.Context, action func([]any) []any) func([]any) []any {
+	return func(args []any) []any {
+		id := goroutineID()
+		prev, nested := callContexts.Load(id)
+		callContexts.Store(id, ctx)
+		defer func() {
+			if nested {
+				callContexts.Store(id, prev)
+			} else {
+				callContexts.Delete(id)
+			}
+		}()
+		return action(args)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCallContext(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "test")
+	var got []context.Context
+	record := func(string) int {
+		got = append(got, gomock.CallContext())
+		return 0
+	}
+	ctrl.RecordCall(subject, "FooMethod", "with").WithContext(ctx).DoAndReturn(record)
+	ctrl.RecordCall(subject, "FooMethod", "without").DoAndReturn(record)
+	ctrl.Call(subject, "FooMethod", "with")
+	ctrl.Call(subject, "FooMethod", "without")
+
+	if len(got) != 2 {
+		t.Fatalf("got %d contexts, want 2", len(got))
+	}
+	if got[0].Value(ctxKey{}) != "test" {
+		t.Errorf("CallContext() in call with context == %v, want %v", got[0], ctx)
+	}
+	if got[1] != context.Background() {
+		t.Errorf("CallContext() in call without context == %v, want context.Background()", got[1])
+	}
+	if gomock.CallContext() != context.Background() {
+		t.Errorf("CallContext() outside of a call == %v, want context.Background()", gomock.CallContext())
+	}
+	if c := gomock.CallContext("arg", ctx); c != ctx {
+		t.Errorf("CallContext(\"arg\", ctx) == %v, want %v", c, ctx)
+	}
+}
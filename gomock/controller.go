@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"runtime"
 	"sync"
+	"sync/atomic"
 )
 
 // A TestReporter is something that can be used to report test failures.  It
@@ -79,8 +80,34 @@ type Controller struct {
 	mu            sync.Mutex
 	expectedCalls *callSet
 	finished      bool
+	leaks         *leakChecker // nil unless WithLeakCheck is used
+	defaultTimes  TimesPolicy
+	goroutines    map[any]goroutineRestriction // set by AllowCallsFrom
+	// Arguments of the latest calls to each method, to report the closest
+	// one when an expected call is missing.
+	actualCalls map[callSetKey][][]any
+	replay      *replayLog   // nil unless WithReplay is used
+	history     *callHistory // nil unless WithArgSnapshots is used
+	scopes      []*Scope     // see ForSubtest
+	// Limits of the calls in flight of the mocks, see AssertMaxConcurrency.
+	concurrency map[any]*concurrencyLimit
+	rand        *randSource            // see Rand
+	messages    messageTemplatesOption // set by WithMessageTemplates
+	// ID of the goroutine holding mu, during which the Controller may format
+	// the arguments of calls, see Call.
+	lockedBy atomic.Uint64
+	// Number of the attempts at matching a call, for memoized matchers, see
+	// beginMatch.
+	matchAttempts uint64
+	// The method and origin of the call closing each closed mock, see
+	// MarkClosed.
+	closed map[any]string
 }
 
+// maxActualCalls is the number of calls to each method kept in
+// Controller.actualCalls.
+const maxActualCalls = 100
+
 // NewController returns a new Controller. It is the preferred way to create a
 // Controller.
 //
@@ -122,7 +149,53 @@ func WithOverridableExpectations() overridableExpectationsOption {
 }
 
 func (o overridableExpectationsOption) apply(ctrl *Controller) {
-	ctrl.expectedCalls = newOverridableCallSet()
+	ctrl.expectedCalls.allowOverride = true
+}
+
+type specificExpectationsFirstOption struct{}
+
+// WithSpecificExpectationsFirst makes a call match the most specific of the
+// expectations it matches, rather than the earliest registered one. An
+// expectation is more specific when more of its arguments are matched by
+// matchers other than Any; among equally specific expectations, those with a
+// bounded number of calls, unlike AnyTimes, come first, then the earliest
+// registered.
+//
+// It lets fixtures declare broad defaults that the expectations of each test
+// override regardless of the order they are registered in:
+//
+//	m.EXPECT().Get(gomock.Any()).Return("default").AnyTimes() // fixture
+//	m.EXPECT().Get("a").Return("a")                            // test
+func WithSpecificExpectationsFirst() specificExpectationsFirstOption {
+	return specificExpectationsFirstOption{}
+}
+
+func (o specificExpectationsFirstOption) apply(ctrl *Controller) {
+	ctrl.expectedCalls.preferSpecific = true
+}
+
+// TimesPolicy is the number of calls expected by default, that is by the
+// expectations on which none of Times, MinTimes, MaxTimes and AnyTimes is
+// called.
+type TimesPolicy int
+
+const (
+	// ExactlyOnce expects exactly one call. It is the default policy.
+	ExactlyOnce TimesPolicy = iota
+	// AnyTimes expects any number of calls, as Call.AnyTimes does.
+	AnyTimes
+)
+
+type defaultTimesOption TimesPolicy
+
+// WithDefaultTimes sets the number of calls expected by default by the
+// expectations of the Controller.
+func WithDefaultTimes(policy TimesPolicy) defaultTimesOption {
+	return defaultTimesOption(policy)
+}
+
+func (o defaultTimesOption) apply(ctrl *Controller) {
+	ctrl.defaultTimes = TimesPolicy(o)
 }
 
 type cancelReporter struct {
@@ -182,52 +255,112 @@ func (ctrl *Controller) RecordCall(receiver any, method string, args ...any) *Ca
 }
 
 // RecordCallWithMethodType is called by a mock. It should not be called by user code.
+//
+// It is safe to call concurrently with other registrations and with calls to
+// the mocks of the Controller, so parallel subtests may set up expectations
+// on a shared Controller. The returned Call is matched as soon as it is
+// registered, so it should be fully declared before the mock may receive the
+// calls it expects.
 func (ctrl *Controller) RecordCallWithMethodType(receiver any, method string, methodType reflect.Type, args ...any) *Call {
 	ctrl.T.Helper()
 
 	call := newCall(ctrl.T, receiver, method, methodType, args...)
+	call.ctrl = ctrl
+	if s := expectationStats.Load(); s != nil {
+		s.record(ctrl, receiver)
+	}
+	if ctrl.defaultTimes == AnyTimes {
+		call.minCalls, call.maxCalls = 0, 1e8
+	}
 
-	ctrl.mu.Lock()
-	defer ctrl.mu.Unlock()
+	ctrl.lock()
+	defer ctrl.unlock()
 	ctrl.expectedCalls.Add(call)
+	if len(ctrl.scopes) > 0 {
+		ctrl.addToScope(call)
+	}
 
 	return call
 }
 
 // Call is called by a mock. It should not be called by user code.
+//
+// A mocked String, Error or GoString method called by the goroutine holding
+// the lock of the Controller, as it does when it formats the arguments of a
+// call or a failure message, returns the type of the mock for its string
+// results and zero values for the others, instead of going through its
+// expectations, which would deadlock. Calls from other goroutines wait for the
+// lock as usual.
 func (ctrl *Controller) Call(receiver any, method string, args ...any) []any {
 	ctrl.T.Helper()
 
+	if len(args) == 0 && isFormatMethod(method) {
+		if id := ctrl.lockedBy.Load(); id != 0 && id == goroutineID() {
+			return formatResults(receiver, method)
+		}
+	}
+
+	// The end of the call, if its calls in flight are limited.
+	var exit func()
+
 	// Nest this code so we can use defer to make sure the lock is released.
 	actions := func() []func([]any) []any {
 		ctrl.T.Helper()
-		ctrl.mu.Lock()
-		defer ctrl.mu.Unlock()
+		ctrl.lock()
+		defer ctrl.unlock()
+		endMatch := ctrl.beginMatch()
+		defer endMatch()
+
+		if ctrl.leaks != nil {
+			ctrl.leaks.record()
+		}
+		ctrl.recordActualCall(receiver, method, args)
+		if ctrl.replay != nil {
+			ctrl.replay.record(receiver, method, args)
+		}
+		if ctrl.history != nil {
+			ctrl.history.record(receiver, method, args)
+		}
 
 		expected, err := ctrl.expectedCalls.FindMatch(receiver, method, args)
+		if err == nil {
+			err = ctrl.checkGoroutine(receiver)
+		}
 		if err != nil {
 			// callerInfo's skip should be updated if the number of calls between the user's test
 			// and this line changes, i.e. this code is wrapped in another anonymous function.
 			// 0 is us, 1 is controller.Call(), 2 is the generated mock, and 3 is the user's test.
 			origin := callerInfo(3)
-			ctrl.T.Fatalf("Unexpected call to %T.%v(%v) at %s because: %s", receiver, method, args, origin, err)
+			ctrl.logReplay()
+			ctrl.T.Fatalf("%v", ctrl.failure(&ExpectationError{
+				Kind:     UnexpectedCall,
+				Receiver: receiver,
+				Method:   method,
+				Args:     args,
+				Origin:   origin,
+				Err:      err,
+			}))
 		}
 
-		// Two things happen here:
-		// * the matching call no longer needs to check prerequite calls,
-		// * and the prerequite calls are no longer expected, so remove them.
-		preReqCalls := expected.dropPrereqs()
-		for _, preReqCall := range preReqCalls {
-			ctrl.expectedCalls.Remove(preReqCall)
+		// Observers count the call too, but do not define its results.
+		observers := ctrl.expectedCalls.FindObservers(receiver, method, args)
+
+		if l := ctrl.concurrency[receiver]; l != nil {
+			exit = l.enter()
 		}
 
-		actions := expected.call()
-		if expected.exhausted() {
-			ctrl.expectedCalls.Remove(expected)
+		actions := ctrl.consume(expected, args)
+		for _, observer := range observers {
+			for _, action := range ctrl.consume(observer, args) {
+				actions = append(actions, ignoreResults(action))
+			}
 		}
 		return actions
 	}()
 
+	if exit != nil {
+		defer exit()
+	}
 	var rets []any
 	for _, action := range actions {
 		if r := action(args); r != nil {
@@ -238,6 +371,117 @@ func (ctrl *Controller) Call(receiver any, method string, args ...any) []any {
 	return rets
 }
 
+// isFormatMethod returns whether method is called by fmt to format a value.
+func isFormatMethod(method string) bool {
+	return method == "String" || method == "Error" || method == "GoString"
+}
+
+// formatResults returns the results of the mocked format method of receiver
+// called while the Controller holds its lock: the type of the mock for string
+// results, and zero values for the others.
+func formatResults(receiver any, method string) []any {
+	name := fmt.Sprintf("%T", receiver)
+	m, ok := reflect.TypeOf(receiver).MethodByName(method)
+	if !ok {
+		return []any{name}
+	}
+	rets := make([]any, m.Type.NumOut())
+	for i := range rets {
+		t := m.Type.Out(i)
+		v := reflect.Zero(t)
+		if t.Kind() == reflect.String {
+			v = reflect.ValueOf(name).Convert(t)
+		}
+		rets[i] = v.Interface()
+	}
+	return rets
+}
+
+// lock locks the Controller, noting the goroutine holding it so that the
+// mocked String, Error and GoString methods it may call meanwhile do not wait
+// for it. Every acquisition of mu goes through lock.
+func (ctrl *Controller) lock() {
+	ctrl.mu.Lock()
+	ctrl.lockedBy.Store(goroutineID())
+}
+
+func (ctrl *Controller) unlock() {
+	ctrl.lockedBy.Store(0)
+	ctrl.mu.Unlock()
+}
+
+// recordActualCall records the arguments of a call to a mock.
+func (ctrl *Controller) recordActualCall(receiver any, method string, args []any) {
+	if ctrl.actualCalls == nil {
+		ctrl.actualCalls = make(map[callSetKey][][]any)
+	}
+	key := callSetKey{receiver, method}
+	calls := append(ctrl.actualCalls[key], args)
+	if len(calls) > maxActualCalls {
+		calls = calls[1:]
+	}
+	ctrl.actualCalls[key] = calls
+}
+
+// consume records a call with args matching expected, and returns the
+// actions to run.
+func (ctrl *Controller) consume(expected *Call, args []any) []func([]any) []any {
+	// Two things happen here:
+	// * the matching call no longer needs to check prerequite calls,
+	// * and the prerequite calls are no longer expected, so remove them.
+	preReqCalls := expected.dropPrereqs()
+	for _, preReqCall := range preReqCalls {
+		ctrl.expectedCalls.Remove(preReqCall)
+	}
+
+	expected.captureArgs(args)
+	expected.received = append(expected.received, ctrl.receivedArgs(args))
+	actions := expected.call()
+	actions = append([]func([]any) []any(nil), actions...)
+	for _, f := range expected.notify {
+		actions = append(actions, func(args []any) []any {
+			f(args)
+			return nil
+		})
+	}
+	if expected.exhausted() {
+		ctrl.expectedCalls.Remove(expected)
+		for _, f := range expected.onExhausted {
+			actions = append(actions, func([]any) []any {
+				f()
+				return nil
+			})
+		}
+	}
+	if expected.ctx != nil {
+		for i, action := range actions {
+			actions[i] = withCallContext(expected.ctx, action)
+		}
+	}
+	return actions
+}
+
+// receivedArgs returns the arguments of a call to keep for Call.ReceivedArgs,
+// with copies of the slices and maps with WithArgSnapshots.
+func (ctrl *Controller) receivedArgs(args []any) []any {
+	received := make([]any, len(args))
+	for i, arg := range args {
+		if ctrl.history != nil {
+			arg = snapshotArg(arg).Value
+		}
+		received[i] = arg
+	}
+	return received
+}
+
+// ignoreResults returns an action running action and discarding its results.
+func ignoreResults(action func([]any) []any) func([]any) []any {
+	return func(args []any) []any {
+		action(args)
+		return nil
+	}
+}
+
 // Finish checks to see if all the methods that were expected to be called
 // were called. It should be invoked for each Controller. It is not idempotent
 // and therefore can only be invoked once.
@@ -254,14 +498,97 @@ func (ctrl *Controller) Finish() {
 // Satisfied returns whether all expected calls bound to this Controller have been satisfied.
 // Calling Finish is then guaranteed to not fail due to missing calls.
 func (ctrl *Controller) Satisfied() bool {
+	ctrl.lock()
+	defer ctrl.unlock()
+
 	return ctrl.expectedCalls.Satisfied()
 }
 
+// Expects returns whether a call to the method of the mock was expected,
+// whether or not its expectations are exhausted, observers aside. It is
+// called by the mocks generated with the -nil_error_defaults flag of mockgen,
+// to return nil from the methods returning only an error that the test
+// declared no expectations on. It is not intended to be used in user code.
+func (ctrl *Controller) Expects(receiver any, method string) bool {
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	return ctrl.expectedCalls.Has(receiver, method)
+}
+
+// VerifyOption selects the expected calls checked by Controller.Verify.
+type VerifyOption interface {
+	apply(*verifyOptions)
+}
+
+type verifyOptions struct {
+	tags []string
+}
+
+// selects returns whether call is checked by Verify.
+func (o *verifyOptions) selects(call *Call) bool {
+	if len(o.tags) == 0 {
+		return true
+	}
+	for _, tag := range o.tags {
+		if call.hasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+type tagOption string
+
+// WithTag restricts Controller.Verify to the expected calls labelled with tag
+// by Call.Tag. When given several times, calls carrying any of the tags are
+// checked.
+func WithTag(tag string) VerifyOption {
+	return tagOption(tag)
+}
+
+func (o tagOption) apply(opts *verifyOptions) {
+	opts.tags = append(opts.tags, string(o))
+}
+
+// Verify checks that the expected calls selected by opts have been satisfied
+// so far, and fails the test if not. Unlike Finish it can be called any number
+// of times, and leaves the remaining expectations to be checked by Finish.
+// Without options, every expected call is checked.
+func (ctrl *Controller) Verify(opts ...VerifyOption) {
+	ctrl.T.Helper()
+
+	var o verifyOptions
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	var failures []*Call
+	for _, call := range ctrl.expectedCalls.Failures() {
+		if o.selects(call) {
+			failures = append(failures, call)
+		}
+	}
+	if summary := groupFailures(failures); summary != "" {
+		ctrl.T.Errorf("%s", summary)
+	}
+	for _, call := range failures {
+		ctrl.T.Errorf("%v", ctrl.failure(newMissingCallError(call, ctrl.actualCalls[callSetKey{call.receiver, call.method}])))
+	}
+	if len(failures) != 0 {
+		ctrl.logReplay()
+		ctrl.T.Fatalf("aborting test due to missing call(s)")
+	}
+}
+
 func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 	ctrl.T.Helper()
 
-	ctrl.mu.Lock()
-	defer ctrl.mu.Unlock()
+	ctrl.lock()
+	defer ctrl.unlock()
 
 	if ctrl.finished {
 		if _, ok := isCleanuper(ctrl.T); !ok {
@@ -270,18 +597,36 @@ func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 		return
 	}
 	ctrl.finished = true
+	defer ctrl.logRandSeed()
 
 	// Short-circuit, pass through the panic.
 	if panicErr != nil {
 		panic(panicErr)
 	}
 
+	// Wait for the goroutines that called the mocks without holding the lock,
+	// as they may still be calling them on their way out.
+	if ctrl.leaks != nil {
+		ctrl.unlock()
+		leaked := ctrl.leaks.wait()
+		ctrl.lock()
+		for _, stack := range leaked {
+			ctrl.T.Errorf("leaked goroutine that called a mock is still running:\n%s", stack)
+		}
+	}
+
+	ctrl.checkConcurrency()
+
 	// Check that all remaining expected calls are satisfied.
 	failures := ctrl.expectedCalls.Failures()
+	if summary := groupFailures(failures); summary != "" {
+		ctrl.T.Errorf("%s", summary)
+	}
 	for _, call := range failures {
-		ctrl.T.Errorf("missing call(s) to %v", call)
+		ctrl.T.Errorf("%v", ctrl.failure(newMissingCallError(call, ctrl.actualCalls[callSetKey{call.receiver, call.method}])))
 	}
 	if len(failures) != 0 {
+		ctrl.logReplay()
 		if !cleanup {
 			ctrl.T.Fatalf("aborting test due to missing call(s)")
 			return
@@ -317,6 +662,8 @@ func unwrapTestReporter(t TestReporter) TestReporter {
 		}
 	case *nopTestHelper:
 		tr = nt.t
+	case *fuzzReporter:
+		tr = unwrapTestReporter(nt.t)
 	default:
 		// not wrapped
 	}
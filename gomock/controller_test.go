@@ -15,9 +15,14 @@
 package gomock_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"strings"
 
@@ -229,6 +234,229 @@ func TestUnexpectedMethodCall(t *testing.T) {
 	ctrl.Finish()
 }
 
+type argsReporter struct {
+	*ErrorReporter
+	args [][]any
+}
+
+func (r *argsReporter) Errorf(format string, args ...any) {
+	r.args = append(r.args, args)
+	r.ErrorReporter.Errorf(format, args...)
+}
+
+func (r *argsReporter) Fatalf(format string, args ...any) {
+	r.args = append(r.args, args)
+	r.ErrorReporter.Fatalf(format, args...)
+}
+
+func TestExpectationError(t *testing.T) {
+	reporter := &argsReporter{ErrorReporter: NewErrorReporter(t)}
+	ctrl := gomock.NewController(reporter)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}, "Unexpected call to", "there are no expected calls of the method \"FooMethod\" for that receiver")
+
+	err, ok := gomock.ExpectationErrorFrom(reporter.args[0])
+	if !ok {
+		t.Fatalf("no *gomock.ExpectationError in %v", reporter.args[0])
+	}
+	if err.Kind != gomock.UnexpectedCall {
+		t.Errorf("Kind == %v, want %v", err.Kind, gomock.UnexpectedCall)
+	}
+	if err.Receiver != subject || err.Method != "FooMethod" {
+		t.Errorf("got %T.%s, want %T.FooMethod", err.Receiver, err.Method, subject)
+	}
+	assertEqual(t, []any{"argument"}, err.Args)
+	if err.Err == nil || errors.Unwrap(err) != err.Err {
+		t.Errorf("Unwrap() == %v, want %v", errors.Unwrap(err), err.Err)
+	}
+
+	reporter.args = nil
+	call := ctrl.RecordCall(subject, "BarMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
+
+	err, ok = gomock.ExpectationErrorFrom(reporter.args[0])
+	if !ok {
+		t.Fatalf("no *gomock.ExpectationError in %v", reporter.args[0])
+	}
+	if err.Kind != gomock.MissingCall {
+		t.Errorf("Kind == %v, want %v", err.Kind, gomock.MissingCall)
+	}
+	if err.Call != call || err.Method != "BarMethod" {
+		t.Errorf("Call == %v, want %v", err.Call, call)
+	}
+	if !strings.Contains(err.Error(), "missing call(s) to *gomock_test.Subject.BarMethod(is equal to argument (string))") {
+		t.Errorf("unexpected message: %v", err)
+	}
+}
+
+func TestMissingCallClosest(t *testing.T) {
+	reporter := &argsReporter{ErrorReporter: NewErrorReporter(t)}
+	ctrl := gomock.NewController(reporter)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 1, Message: "hello"}, 15).AnyTimes()
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 2, Message: "bye"}, 15)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1, Message: "hello"}, 15)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1, Message: "hello"}, 15)
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
+
+	err, ok := gomock.ExpectationErrorFrom(reporter.args[0])
+	if !ok {
+		t.Fatalf("no *gomock.ExpectationError in %v", reporter.args[0])
+	}
+	want := []any{TestStruct{Number: 1, Message: "hello"}, 15}
+	if !reflect.DeepEqual(err.Closest, want) {
+		t.Errorf("Closest == %v, want %v", err.Closest, want)
+	}
+	for _, s := range []string{
+		"closest call: *gomock_test.Subject.ActOnTestStructMethod(",
+		"argument 0: got",
+		`field Number: got 1, want 2`,
+		`field Message: got "hello", want "bye"`,
+	} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("message %q does not contain %q", err, s)
+		}
+	}
+	if strings.Contains(err.Error(), "argument 1:") {
+		t.Errorf("message %q reports matching argument 1", err)
+	}
+}
+
+func TestMissingCallClosestNilArgument(t *testing.T) {
+	reporter := &argsReporter{ErrorReporter: NewErrorReporter(t)}
+	ctrl := gomock.NewController(reporter)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "SetArgMethodInterface", nil, nil, nil).AnyTimes()
+	ctrl.RecordCall(subject, "SetArgMethodInterface", TestStruct{Number: 1, Message: "hello"}, nil, nil)
+	ctrl.Call(subject, "SetArgMethodInterface", nil, nil, nil)
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
+
+	err, ok := gomock.ExpectationErrorFrom(reporter.args[0])
+	if !ok {
+		t.Fatalf("no *gomock.ExpectationError in %v", reporter.args[0])
+	}
+	if s := "argument 0: got"; !strings.Contains(err.Error(), s) {
+		t.Errorf("message %q does not contain %q", err, s)
+	}
+	if strings.Contains(err.Error(), "field ") {
+		t.Errorf("message %q reports fields of a nil argument", err)
+	}
+}
+
+// StringerSubject is a mock of fmt.Stringer.
+type StringerSubject struct {
+	ctrl *gomock.Controller
+	name string
+}
+
+func (s *StringerSubject) String() string {
+	ret := s.ctrl.Call(s, "String")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+func TestStringerArgument(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	stringer := &StringerSubject{ctrl: ctrl, name: "expected"}
+
+	ctrl.RecordCall(stringer, "String").Return("stringer").AnyTimes()
+	ctrl.RecordCall(subject, "SetArgMethodInterface", stringer, nil, nil)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SetArgMethodInterface", &StringerSubject{ctrl: ctrl, name: "actual"}, nil, nil)
+	}, "Unexpected call to", "*gomock_test.StringerSubject")
+
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+
+	// Outside of the Controller, String is an ordinary call.
+	if got := stringer.String(); got != "stringer" {
+		t.Errorf("String() == %q, want %q", got, "stringer")
+	}
+}
+
+func TestStringerArgument_OtherGoroutine(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+	stringer := &StringerSubject{ctrl: ctrl}
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	blocking := gomock.MatcherFunc(func(any) bool {
+		close(entered)
+		<-release
+		return true
+	}, "blocks")
+	ctrl.RecordCall(subject, "FooMethod", blocking)
+	ctrl.RecordCall(stringer, "String").Return("stringer")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctrl.Call(subject, "FooMethod", "argument")
+	}()
+	<-entered
+
+	// The Controller is locked by the other goroutine while it matches, so
+	// this call waits for it instead of being short-circuited.
+	got := make(chan string)
+	go func() { got <- stringer.String() }()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	<-done
+
+	if s := <-got; s != "stringer" {
+		t.Errorf("String() == %q, want %q", s, "stringer")
+	}
+	ctrl.Finish()
+	reporter.assertPass("String called on another goroutine")
+}
+
+// PairSubject has a String method which is not a fmt.Stringer.
+type PairSubject struct {
+	ctrl *gomock.Controller
+}
+
+func (s *PairSubject) String() (string, bool) {
+	ret := s.ctrl.Call(s, "String")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+func TestFormatMethodResults(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+	pair := &PairSubject{ctrl: ctrl}
+
+	var name string
+	var ok bool
+	ctrl.RecordCall(subject, "FooMethod", gomock.MatcherFunc(func(any) bool {
+		name, ok = pair.String()
+		return true
+	}, "calls String"))
+	ctrl.Call(subject, "FooMethod", "argument")
+
+	if name != "*gomock_test.PairSubject" || ok {
+		t.Errorf("String() == %q, %v, want %q, false", name, ok, "*gomock_test.PairSubject")
+	}
+	ctrl.Finish()
+	reporter.assertPass("String called while matching")
+}
+
 func TestRepeatedCall(t *testing.T) {
 	reporter, ctrl := createFixtures(t)
 	subject := new(Subject)
@@ -277,6 +505,36 @@ func TestExpectedMethodCall_CustomStruct(t *testing.T) {
 	reporter.assertPass("Expected method call made.")
 }
 
+// token is a type with a GotFormatter registered in TestRegisterGotFormatter.
+type token struct {
+	secret string
+}
+
+func TestRegisterGotFormatter(t *testing.T) {
+	gomock.RegisterGotFormatter(reflect.TypeOf(token{}), gomock.GotFormatterFunc(func(got any) string {
+		return fmt.Sprintf("token of %d bytes", len(got.(token).secret))
+	}))
+	defer gomock.RegisterGotFormatter(reflect.TypeOf(token{}), nil)
+	gomock.RegisterGotFormatter(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), gomock.GotFormatterFunc(func(got any) string {
+		return "stringer " + got.(fmt.Stringer).String()
+	}))
+	defer gomock.RegisterGotFormatter(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), nil)
+
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "SetArgMethodInterface", gomock.Nil(), gomock.Nil(), gomock.Nil())
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SetArgMethodInterface", token{"hunter2"}, nil, nil)
+	}, "Unexpected call to", "Got: token of 7 bytes\nWant: is nil")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SetArgMethodInterface", nil, testStringer("a"), nil)
+	}, "Unexpected call to", "Got: stringer a\nWant: is nil")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SetArgMethodInterface", nil, nil, 1)
+	}, "Unexpected call to", "Got: 1 (int)\nWant: is nil")
+}
+
 func TestUnexpectedArgValue_FirstArg(t *testing.T) {
 	reporter, ctrl := createFixtures(t)
 	defer reporter.recoverUnexpectedFatal()
@@ -289,7 +547,8 @@ func TestUnexpectedArgValue_FirstArg(t *testing.T) {
 		// the method argument (of TestStruct type) has 1 unexpected value (for the Message field)
 		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 123, Message: "no message"}, 15)
 	}, "Unexpected call to", "doesn't match the argument at index 0",
-		"Got: {123 no message} (gomock_test.TestStruct)\nWant: is equal to {123 hello %s} (gomock_test.TestStruct)")
+		"Got: {123 no message} (gomock_test.TestStruct)\nWant: is equal to {123 hello %s} (gomock_test.TestStruct)\n"+
+			"Diff (-want +got):\n gomock_test.TestStruct{\n \tNumber: 123,\n-\tMessage: \"hello %s\",\n+\tMessage: \"no message\",\n }")
 
 	reporter.assertFatal(func() {
 		// the method argument (of TestStruct type) has 2 unexpected values (for both fields)
@@ -303,6 +562,32 @@ func TestUnexpectedArgValue_FirstArg(t *testing.T) {
 	})
 }
 
+func TestUnexpectedArgValue_NestedDiff(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	want := map[string]*Kennel{"a": {Name: "home", Dog: &Dog{Name: "Fido"}}}
+	ctrl.RecordCall(subject, "SetArgMethodInterface", want, gomock.Any(), gomock.Any())
+
+	reporter.assertFatal(func() {
+		got := map[string]*Kennel{"a": {Name: "home", Dog: &Dog{Name: "Rex"}}}
+		ctrl.Call(subject, "SetArgMethodInterface", got, nil, nil)
+	}, "Diff (-want +got):\n"+
+		" map[string]*gomock_test.Kennel{\n"+
+		" \t\"a\": &gomock_test.Kennel{\n"+
+		" \t\tName: \"home\",\n"+
+		" \t\tDog: &gomock_test.Dog{\n"+
+		" \t\t\tBreed: \"\",\n"+
+		"-\t\t\tName: \"Fido\",\n"+
+		"+\t\t\tName: \"Rex\",\n"+
+		" \t\t},\n")
+
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
+}
+
 func TestUnexpectedArgValue_SecondArg(t *testing.T) {
 	reporter, ctrl := createFixtures(t)
 	defer reporter.recoverUnexpectedFatal()
@@ -322,6 +607,23 @@ func TestUnexpectedArgValue_SecondArg(t *testing.T) {
 	})
 }
 
+func TestUnexpectedArgValue_AnyContext(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "SetArgMethodInterface", gomock.AnyContext(), "key", nil)
+
+	reporter.assertFatal(func() {
+		// The arguments are swapped, so the context is not where it is expected.
+		ctrl.Call(subject, "SetArgMethodInterface", "key", context.Background(), nil)
+	}, "Unexpected call to", "doesn't match the argument at index 0",
+		"Got: key (string)\nWant: is a context.Context")
+
+	ctrl.Call(subject, "SetArgMethodInterface", context.Background(), "key", nil)
+	ctrl.Finish()
+}
+
 func TestUnexpectedArgValue_WantFormatter(t *testing.T) {
 	reporter, ctrl := createFixtures(t)
 	defer reporter.recoverUnexpectedFatal()
@@ -384,7 +686,7 @@ func TestAnyTimes(t *testing.T) {
 	subject := new(Subject)
 
 	ctrl.RecordCall(subject, "FooMethod", "argument").AnyTimes()
-	for i := 0; i < 100; i++ {
+	for i := 0; i < 10; i++ {
 		ctrl.Call(subject, "FooMethod", "argument")
 	}
 	reporter.assertPass("After 100 method calls.")
@@ -411,7 +713,7 @@ func TestMinTimes1(t *testing.T) {
 	_, ctrl = createFixtures(t)
 	subject = new(Subject)
 	ctrl.RecordCall(subject, "FooMethod", "argument").MinTimes(1)
-	for i := 0; i < 100; i++ {
+	for i := 0; i < 10; i++ {
 		ctrl.Call(subject, "FooMethod", "argument")
 	}
 	ctrl.Finish()
@@ -484,12 +786,216 @@ func TestMinMaxTimes(t *testing.T) {
 	_, ctrl = createFixtures(t)
 	subject = new(Subject)
 	ctrl.RecordCall(subject, "FooMethod", "argument").MaxTimes(1).MinTimes(2)
-	for i := 0; i < 100; i++ {
+	for i := 0; i < 10; i++ {
 		ctrl.Call(subject, "FooMethod", "argument")
 	}
 	ctrl.Finish()
 }
 
+func TestTimesBetween(t *testing.T) {
+	// It fails if there are less calls than specified
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").TimesBetween(2, 3)
+	ctrl.Call(subject, "FooMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
+
+	// It fails if there are more calls than specified
+	reporter, ctrl = createFixtures(t)
+	subject = new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").TimesBetween(2, 3)
+	for i := 0; i < 3; i++ {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	})
+
+	// It succeeds with any number of calls in the range, whatever was
+	// declared before
+	for _, n := range []int{1, 2} {
+		_, ctrl = createFixtures(t)
+		subject = new(Subject)
+		ctrl.RecordCall(subject, "FooMethod", "argument").MaxTimes(1).TimesBetween(1, 2)
+		for i := 0; i < n; i++ {
+			ctrl.Call(subject, "FooMethod", "argument")
+		}
+		ctrl.Finish()
+	}
+
+	reporter, ctrl = createFixtures(t)
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").TimesBetween(3, 2)
+	}, "TimesBetween(3, 2) called for *gomock_test.Subject.FooMethod: the range is invalid")
+}
+
+func TestVerifyWithTag(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").Tag("critical")
+	ctrl.RecordCall(subject, "BarMethod", "2").Tag("noise")
+	ctrl.RecordCall(subject, "BarMethod", "3")
+
+	reporter.assertFatal(func() {
+		ctrl.Verify(gomock.WithTag("critical"))
+	}, "aborting test due to missing call(s)")
+	if !strings.Contains(strings.Join(reporter.log, "\n"), "FooMethod") {
+		t.Errorf("expected missing FooMethod to be reported, got: %v", reporter.log)
+	}
+
+	reporter, ctrl = createFixtures(t)
+	ctrl.RecordCall(subject, "FooMethod", "1").Tag("critical")
+	ctrl.RecordCall(subject, "BarMethod", "2").Tag("noise")
+
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Verify(gomock.WithTag("critical"))
+	reporter.assertPass("tagged expectations are satisfied")
+
+	reporter.assertFatal(func() {
+		ctrl.Verify()
+	}, "aborting test due to missing call(s)")
+}
+
+func TestConcurrentRecordCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	subject := new(Subject)
+
+	// Check the expectations while they are being declared and called.
+	start, stop, done := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				ctrl.Satisfied()
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			arg := strconv.Itoa(i)
+			ctrl.RecordCall(subject, "FooMethod", arg).Return(i).Times(2).Tag(arg)
+			for j := 0; j < 2; j++ {
+				if rets := ctrl.Call(subject, "FooMethod", arg); rets[0] != i {
+					t.Errorf("FooMethod(%q) = %v, want %d", arg, rets[0], i)
+				}
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(stop)
+	<-done
+
+	if !ctrl.Satisfied() {
+		t.Error("expected all calls to be satisfied")
+	}
+}
+
+func TestObserver(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(1)
+	ctrl.RecordCall(subject, "FooMethod", "2").Return(2)
+	var observed []string
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Times(2).Observer().Return(-1).Do(func(arg string) {
+		observed = append(observed, arg)
+	})
+	ctrl.RecordCall(subject, "FooMethod", "2").Observer()
+
+	if rets := ctrl.Call(subject, "FooMethod", "1"); rets[0] != 1 {
+		t.Errorf("FooMethod(1) = %v, want 1", rets[0])
+	}
+	if ctrl.Satisfied() {
+		t.Error("expected the observers not to be satisfied")
+	}
+	if rets := ctrl.Call(subject, "FooMethod", "2"); rets[0] != 2 {
+		t.Errorf("FooMethod(2) = %v, want 2", rets[0])
+	}
+	assertEqual(t, []string{"1", "2"}, observed)
+	ctrl.Finish()
+	reporter.assertPass("Expected the observers to be satisfied")
+}
+
+func TestObserver_NoRegularExpectation(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Observer()
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "1")
+	}, "Unexpected call to", "there are no expected calls")
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+}
+
+func TestWithSpecificExpectationsFirst(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithSpecificExpectationsFirst())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), gomock.Any()).Return(0).AnyTimes()
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), 1).Return(1).AnyTimes()
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), 1).Return(2)
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 1).Return(3)
+
+	for _, tt := range []struct {
+		arg  TestStruct
+		arg1 int
+		want int
+	}{
+		{TestStruct{Number: 1}, 1, 3},
+		{TestStruct{Number: 2}, 1, 2},
+		{TestStruct{Number: 1}, 1, 1},
+		{TestStruct{Number: 1}, 2, 0},
+	} {
+		if rets := ctrl.Call(subject, "ActOnTestStructMethod", tt.arg, tt.arg1); rets[0] != tt.want {
+			t.Errorf("ActOnTestStructMethod(%v, %d) = %v, want %d", tt.arg, tt.arg1, rets[0], tt.want)
+		}
+	}
+	ctrl.Finish()
+	reporter.assertPass("Expected all calls to be satisfied")
+}
+
+func TestWithDefaultTimes(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithDefaultTimes(gomock.AnyTimes))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "any")
+	ctrl.RecordCall(subject, "FooMethod", "min").MinTimes(2)
+	ctrl.RecordCall(subject, "FooMethod", "max").MaxTimes(1)
+	ctrl.RecordCall(subject, "FooMethod", "once").Times(1)
+
+	for i := 0; i < 3; i++ {
+		ctrl.Call(subject, "FooMethod", "any")
+		ctrl.Call(subject, "FooMethod", "min")
+	}
+	ctrl.Call(subject, "FooMethod", "once")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "once")
+	}, "has already been called the max number of times")
+
+	ctrl.Finish()
+	reporter.assertFail("Expected only the extra call to fail")
+	if len(reporter.log) != 1 {
+		t.Errorf("got %d errors, want 1: %q", len(reporter.log), reporter.log)
+	}
+}
+
 func TestDo(t *testing.T) {
 	_, ctrl := createFixtures(t)
 	subject := new(Subject)
@@ -694,6 +1200,38 @@ func TestOrderedCallsCorrect(t *testing.T) {
 	reporter.assertPass("After finish")
 }
 
+func TestAnyOrderThen(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	gomock.AnyOrder(
+		ctrl.RecordCall(subject, "FooMethod", "1"),
+		ctrl.RecordCall(subject, "FooMethod", "2"),
+	).Then(
+		ctrl.RecordCall(subject, "BarMethod", "3"),
+		ctrl.RecordCall(subject, "BarMethod", "4"),
+	).Then(
+		ctrl.RecordCall(subject, "FooMethod", "5"),
+	)
+
+	ctrl.Call(subject, "FooMethod", "2")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "BarMethod", "3")
+	}, "Unexpected call to", "doesn't have a prerequisite call satisfied")
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "BarMethod", "4")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "5")
+	}, "Unexpected call to", "doesn't have a prerequisite call satisfied")
+	ctrl.Call(subject, "BarMethod", "3")
+	ctrl.Call(subject, "FooMethod", "5")
+
+	if !ctrl.Satisfied() {
+		t.Error("expected all calls to be satisfied")
+	}
+	ctrl.Finish()
+}
+
 func TestPanicOverridesExpectationChecks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	reporter := NewErrorReporter(t)
@@ -844,6 +1382,10 @@ func TestWithHelper(t *testing.T) {
 	}
 }
 
+func (e *ErrorReporter) Failed() bool {
+	return e.failed
+}
+
 func (e *ErrorReporter) Cleanup(f func()) {
 	e.t.Helper()
 	e.t.Cleanup(f)
@@ -952,3 +1494,158 @@ func TestCallAfterLoopPanic(t *testing.T) {
 	})
 	ctrl = gomock.NewController(reporter)
 }
+
+// NamedSubject is a Subject of which instances are distinct.
+type NamedSubject struct {
+	name string
+}
+
+func (s *NamedSubject) FooMethod(arg string) int { return 0 }
+func (s *NamedSubject) BarMethod(arg string) int { return 0 }
+
+func TestMissingCallsSummary(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	first, second, foo := &NamedSubject{"first"}, &NamedSubject{"second"}, new(FooSubject)
+
+	ctrl.RecordCall(foo, "FooMethod", "a")
+	ctrl.RecordCall(second, "FooMethod", "b")
+	ctrl.RecordCall(first, "FooMethod", "c")
+	ctrl.RecordCall(first, "FooMethod", "d")
+	ctrl.RecordCall(first, "BarMethod", "e")
+	ctrl.RecordCall(second, "BarMethod", "f").AnyTimes()
+
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+
+	want := "missing call(s) to 5 expectation(s) of 3 mocks:\n" +
+		"\t*gomock_test.FooSubject: FooMethod (1)\n" +
+		"\t*gomock_test.NamedSubject #1: FooMethod (1)\n" +
+		"\t*gomock_test.NamedSubject #2: BarMethod (1), FooMethod (2)"
+	if len(reporter.log) != 7 || reporter.log[0] != want {
+		t.Fatalf("log[0] = %q, want %q", reporter.log, want)
+	}
+	for i, arg := range []string{"a", "b", "e", "c", "d"} {
+		if entry := reporter.log[i+1]; !strings.Contains(entry, "(is equal to "+arg+" (string))") {
+			t.Errorf("log[%d] = %q, want the missing call with %s", i+1, entry, arg)
+		}
+	}
+}
+
+func TestCall_Panic(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var ran bool
+	ctrl.RecordCall(subject, "FooMethod", "argument").Do(func(string) { ran = true }).Panic("crashed")
+
+	func() {
+		defer func() {
+			if r := recover(); r != "crashed" {
+				t.Errorf("recovered %v, want %q", r, "crashed")
+			}
+		}()
+		ctrl.Call(subject, "FooMethod", "argument")
+		t.Error("expected the call to panic")
+	}()
+	if !ran {
+		t.Error("expected the actions declared before Panic to run")
+	}
+	ctrl.Finish()
+	reporter.assertPass("Expected the panicking call")
+}
+
+func TestCall_OnExhausted(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var events []string
+	ctrl.RecordCall(subject, "FooMethod", "argument").Times(2).
+		Do(func(string) { events = append(events, "call") }).
+		OnExhausted(func() { events = append(events, "exhausted") })
+
+	done := make(chan struct{})
+	ctrl.RecordCall(subject, "BarMethod", "argument").OnExhausted(func() { close(done) })
+	go ctrl.Call(subject, "BarMethod", "argument")
+	<-done
+
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Finish()
+	reporter.assertPass("Expected all calls")
+	assertEqual(t, []string{"call", "call", "exhausted"}, events)
+}
+
+func TestCall_Notify(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var called bool
+	notified := make(chan struct{})
+	ctrl.RecordCall(subject, "FooMethod", "argument").Notify(notified).
+		DoAndReturn(func(string) int {
+			called = true
+			return 1
+		})
+	args := make(chan []any, 1)
+	ctrl.RecordCall(subject, "BarMethod", gomock.Any()).NotifyArgs(args)
+
+	go ctrl.Call(subject, "FooMethod", "argument")
+	<-notified
+	assertEqual(t, true, called)
+
+	go ctrl.Call(subject, "BarMethod", "value")
+	assertEqual(t, []any{"value"}, <-args)
+
+	ctrl.Finish()
+	reporter.assertPass("Expected all calls")
+}
+
+func TestCall_Notify_Nil(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").Notify(nil)
+	}, "Notify called for *gomock_test.Subject.FooMethod: the channel is nil")
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").NotifyArgs(nil)
+	}, "NotifyArgs called for *gomock_test.Subject.FooMethod: the channel is nil")
+}
+
+func TestExpects(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	assertEqual(t, false, ctrl.Expects(subject, "FooMethod"))
+	ctrl.RecordCall(subject, "BarMethod", "argument").Observer()
+	assertEqual(t, false, ctrl.Expects(subject, "BarMethod"))
+	ctrl.RecordCall(subject, "FooMethod", "argument")
+	assertEqual(t, true, ctrl.Expects(subject, "FooMethod"))
+	ctrl.Call(subject, "FooMethod", "argument")
+	assertEqual(t, true, ctrl.Expects(subject, "FooMethod"))
+}
+
+func TestCall_Name(t *testing.T) {
+	reporter := &argsReporter{ErrorReporter: NewErrorReporter(t)}
+	ctrl := gomock.NewController(reporter)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").Name("refresh token after expiry")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "other")
+	}, "expected call 'refresh token after expiry' at", "doesn't match the argument at index 0")
+
+	reporter.args = nil
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
+	err, ok := gomock.ExpectationErrorFrom(reporter.args[0])
+	if !ok {
+		t.Fatalf("no *gomock.ExpectationError in %v", reporter.args[0])
+	}
+	assertEqual(t, "refresh token after expiry", err.Name)
+	if !strings.Contains(err.Error(), "missing call(s) to expectation 'refresh token after expiry' (*gomock_test.Subject.FooMethod(is equal to argument (string)))") {
+		t.Errorf("unexpected message: %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Delay makes the calls matching the expectation sleep for d before running
+// their actions, such as returning, to exercise the timeouts and the
+// parallelism of the code under test. The sleep relies on the time package
+// only, so in a testing/synctest bubble it follows the fake clock: it takes
+// no real time and the code under test observes exactly d.
+//
+// Example usage:
+//
+//	m.EXPECT().Fetch(gomock.Any()).Return(data, nil).Delay(2 * time.Second)
+func (c *Call) Delay(d time.Duration) *Call {
+	c.t.Helper()
+
+	if d < 0 {
+		c.t.Fatalf("Delay(%v) called for %T.%v: the delay is negative [%s]", d, c.receiver, c.method, c.origin)
+		return c
+	}
+	c.addDelay(func() time.Duration { return d })
+	return c
+}
+
+// DelayJitter is like Delay with a delay between min and max, drawn anew for
+// each call from the random numbers of the Controller (see Controller.Rand),
+// so that WithRandSeed reproduces the delays of a failed test.
+func (c *Call) DelayJitter(min, max time.Duration) *Call {
+	c.t.Helper()
+
+	if min < 0 || max < min {
+		c.t.Fatalf("DelayJitter(%v, %v) called for %T.%v: the range is invalid [%s]",
+			min, max, c.receiver, c.method, c.origin)
+		return c
+	}
+	c.addDelay(func() time.Duration {
+		return min + time.Duration(c.rand().Int63n(int64(max-min)+1))
+	})
+	return c
+}
+
+// BlockUntil makes the calls matching the expectation block until ch is
+// closed before running their actions, such as returning, so that a test can
+// hold the code under test in the middle of a call while it checks or does
+// something else. Closing ch releases the calls blocked and lets the later
+// ones through.
+//
+// Example usage:
+//
+//	release := make(chan struct{})
+//	m.EXPECT().Fetch(gomock.Any()).Return(data, nil).BlockUntil(release)
+//	go cache.Refresh()
+//	// ... check that reads are served while the fetch is in flight ...
+//	close(release)
+func (c *Call) BlockUntil(ch <-chan struct{}) *Call {
+	c.t.Helper()
+
+	if ch == nil {
+		c.t.Fatalf("BlockUntil called for %T.%v: the channel is nil, so calls would block forever [%s]", c.receiver, c.method, c.origin)
+		return c
+	}
+	c.addWait(func() { <-ch })
+	return c
+}
+
+// addDelay adds an action sleeping for the duration returned by delay, which
+// runs before the other actions of the call.
+func (c *Call) addDelay(delay func() time.Duration) {
+	c.addWait(func() { time.Sleep(delay()) })
+}
+
+// addWait adds an action calling wait, which runs before the other actions
+// of the call.
+func (c *Call) addWait(wait func()) {
+	c.lock()
+	defer c.unlock()
+
+	action := func([]any) []any {
+		wait()
+		return nil
+	}
+	c.actions = append([]func([]any) []any{action}, c.actions...)
+}
+
+// rand returns the random number generator of the Controller of the call, or
+// one seeded with the current time if it is not recorded by a Controller.
+func (c *Call) rand() *rand.Rand {
+	if c.ctrl != nil {
+		return c.ctrl.Rand()
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
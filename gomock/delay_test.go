@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestDelay(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var ran time.Time
+	ctrl.RecordCall(subject, "FooMethod", "argument").Do(func(string) { ran = time.Now() }).Return(1).Delay(20 * time.Millisecond)
+
+	start := time.Now()
+	if got := ctrl.Call(subject, "FooMethod", "argument")[0]; got != 1 {
+		t.Errorf("call returned %v, want 1", got)
+	}
+	if elapsed := ran.Sub(start); elapsed < 20*time.Millisecond {
+		t.Errorf("actions ran after %v, want at least 20ms", elapsed)
+	}
+	ctrl.Finish()
+	reporter.assertPass("Expected the delayed call")
+}
+
+func TestDelayJitter(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithRandSeed(1))
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").DelayJitter(5*time.Millisecond, 10*time.Millisecond).Times(3)
+
+	for i := 0; i < 3; i++ {
+		start := time.Now()
+		ctrl.Call(subject, "FooMethod", "argument")
+		if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+			t.Errorf("call returned after %v, want at least 5ms", elapsed)
+		}
+	}
+	ctrl.Finish()
+	reporter.assertPass("Expected the delayed calls")
+}
+
+func TestBlockUntil(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	release := make(chan struct{})
+	ctrl.RecordCall(subject, "FooMethod", "argument").Return(1).BlockUntil(release).Times(2)
+
+	returned := make(chan any)
+	go func() { returned <- ctrl.Call(subject, "FooMethod", "argument")[0] }()
+	select {
+	case <-returned:
+		t.Fatal("call returned before the channel was closed")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	assertEqual(t, 1, <-returned)
+
+	// Calls after the channel is closed do not block.
+	assertEqual(t, 1, ctrl.Call(subject, "FooMethod", "argument")[0])
+	ctrl.Finish()
+	reporter.assertPass("Expected the blocked calls")
+}
+
+func TestDelay_Invalid(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").Delay(-time.Second)
+	}, "Delay(-1s) called for *gomock_test.Subject.FooMethod: the delay is negative")
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").DelayJitter(time.Second, time.Millisecond)
+	}, "DelayJitter(1s, 1ms) called for *gomock_test.Subject.FooMethod: the range is invalid")
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").BlockUntil(nil)
+	}, "BlockUntil called for *gomock_test.Subject.FooMethod: the channel is nil")
+}
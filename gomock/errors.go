@@ -0,0 +1,275 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrorKind classifies the failures reported by a Controller.
+type ErrorKind int
+
+const (
+	// UnexpectedCall is reported when a mock is called and no expectation
+	// matches the call.
+	UnexpectedCall ErrorKind = iota + 1
+	// MissingCall is reported when a controller finishes while an
+	// expectation still has not been called its minimum number of times.
+	MissingCall
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case UnexpectedCall:
+		return "unexpected call"
+	case MissingCall:
+		return "missing call"
+	default:
+		return fmt.Sprintf("ErrorKind(%d)", int(k))
+	}
+}
+
+// ExpectationError describes a failure detected by a Controller.
+//
+// The Controller passes an *ExpectationError as an argument to the Errorf
+// and Fatalf methods of its TestReporter, so custom reporters can inspect
+// failures without parsing the formatted message:
+//
+//	func (r *myReporter) Fatalf(format string, args ...any) {
+//	  if err, ok := gomock.ExpectationErrorFrom(args); ok && err.Kind == gomock.UnexpectedCall {
+//	    // ...
+//	  }
+//	}
+type ExpectationError struct {
+	// Kind is the kind of failure.
+	Kind ErrorKind
+	// Receiver is the mock the failure relates to.
+	Receiver any
+	// Method is the name of the mocked method.
+	Method string
+	// Args are the arguments of the actual call for UnexpectedCall, and the
+	// argument matchers of the expectation for MissingCall.
+	Args []any
+	// Origin is the file and line of the actual call for UnexpectedCall, and
+	// of the expectation setup for MissingCall.
+	Origin string
+	// Call is the expectation the failure relates to. It is nil for
+	// UnexpectedCall.
+	Call *Call
+	// Name is the name given to the expectation with Call.Name, if any, for
+	// MissingCall.
+	Name string
+	// Err is the underlying cause, if any.
+	Err error
+	// Closest are the arguments of the actual call to the method that came
+	// closest to matching the expectation, for MissingCall. It is nil if the
+	// method was not called.
+	Closest []any
+
+	// closestDiff describes the arguments of Closest that fail to match, as
+	// computed when the error is built.
+	closestDiff string
+}
+
+func (e *ExpectationError) Error() string {
+	switch e.Kind {
+	case UnexpectedCall:
+		return fmt.Sprintf("Unexpected call to %T.%v(%v) at %s because: %v", e.Receiver, e.Method, e.Args, e.Origin, e.Err)
+	case MissingCall:
+		msg := fmt.Sprintf("missing call(s) to %v", e.Call)
+		if e.Closest != nil {
+			msg += fmt.Sprintf("\n\tclosest call: %T.%v(%v)", e.Receiver, e.Method, e.Closest) + e.closestDiff
+		}
+		return msg
+	default:
+		return fmt.Sprintf("%v to %T.%v at %s: %v", e.Kind, e.Receiver, e.Method, e.Origin, e.Err)
+	}
+}
+
+// Unwrap returns the underlying cause of the failure.
+func (e *ExpectationError) Unwrap() error {
+	return e.Err
+}
+
+// ExpectationErrorFrom returns the first *ExpectationError found, by way of
+// errors.As, among the arguments passed to a TestReporter's Errorf or Fatalf.
+func ExpectationErrorFrom(args []any) (*ExpectationError, bool) {
+	for _, arg := range args {
+		err, ok := arg.(error)
+		if !ok {
+			continue
+		}
+		var expErr *ExpectationError
+		if errors.As(err, &expErr) {
+			return expErr, true
+		}
+	}
+	return nil, false
+}
+
+func newMissingCallError(call *Call, actual [][]any) *ExpectationError {
+	args := make([]any, len(call.args))
+	for i, m := range call.args {
+		args[i] = m
+	}
+	closest := closestCall(call, actual)
+	var diff string
+	for i, m := range call.args {
+		if i < len(closest) && !m.Matches(closest[i]) {
+			diff += fmt.Sprintf("\n\targument %d: %s", i, argDiff(m, closest[i]))
+		}
+	}
+	return &ExpectationError{
+		Kind:        MissingCall,
+		Receiver:    call.receiver,
+		Method:      call.method,
+		Args:        args,
+		Origin:      call.origin,
+		Call:        call,
+		Name:        call.name,
+		Closest:     closest,
+		closestDiff: diff,
+	}
+}
+
+// groupFailures sorts the expectations missing calls by mock, method and
+// origin. If they are expectations of several mocks, it returns a summary
+// listing the methods of each mock with the number of their expectations
+// missing calls, to be reported before the details.
+func groupFailures(failures []*Call) string {
+	type mock struct {
+		name   string // type of the mock, numbered if other mocks share it
+		origin string // earliest origin of its expectations missing calls
+		counts map[string]int
+	}
+	mocks := make(map[any]*mock)
+	var order []any
+	for _, c := range failures {
+		m := mocks[c.receiver]
+		if m == nil {
+			m = &mock{name: fmt.Sprintf("%T", c.receiver), origin: c.origin, counts: make(map[string]int)}
+			mocks[c.receiver] = m
+			order = append(order, c.receiver)
+		}
+		if c.origin < m.origin {
+			m.origin = c.origin
+		}
+		m.counts[c.method]++
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := mocks[order[i]], mocks[order[j]]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		return a.origin < b.origin
+	})
+	index := make(map[any]int, len(order))
+	for i, r := range order {
+		index[r] = i
+	}
+	sort.SliceStable(failures, func(i, j int) bool {
+		a, b := failures[i], failures[j]
+		if index[a.receiver] != index[b.receiver] {
+			return index[a.receiver] < index[b.receiver]
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.origin < b.origin
+	})
+	if len(order) < 2 {
+		return ""
+	}
+
+	types := make(map[string]int)
+	for _, r := range order {
+		types[mocks[r].name]++
+	}
+	seen := make(map[string]int)
+	var b strings.Builder
+	fmt.Fprintf(&b, "missing call(s) to %d expectation(s) of %d mocks:", len(failures), len(order))
+	for _, r := range order {
+		m := mocks[r]
+		name := m.name
+		if types[name] > 1 {
+			seen[name]++
+			name += fmt.Sprintf(" #%d", seen[name])
+		}
+		methods := make([]string, 0, len(m.counts))
+		for method := range m.counts {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for i, method := range methods {
+			methods[i] = fmt.Sprintf("%s (%d)", method, m.counts[method])
+		}
+		fmt.Fprintf(&b, "\n\t%s: %s", name, strings.Join(methods, ", "))
+	}
+	return b.String()
+}
+
+// closestCall returns the arguments among actual that match the most argument
+// matchers of call, or nil if actual is empty.
+func closestCall(call *Call, actual [][]any) []any {
+	var closest []any
+	best := -1
+	for _, args := range actual {
+		var n int
+		for i, m := range call.args {
+			if i < len(args) && m.Matches(args[i]) {
+				n++
+			}
+		}
+		if n > best {
+			closest, best = args, n
+		}
+	}
+	return closest
+}
+
+// argDiff describes how got fails to match m. When m is an Eq matcher of a
+// struct, it lists the exported fields that differ.
+func argDiff(m Matcher, got any) string {
+	msg := fmt.Sprintf("got %s, want %v", formatGottenArg(m, got), m)
+	em, ok := m.(eqMatcher)
+	if !ok {
+		return msg
+	}
+	want, gotV := reflect.ValueOf(em.x), reflect.ValueOf(got)
+	for want.Kind() == reflect.Ptr && gotV.Kind() == reflect.Ptr && !want.IsNil() && !gotV.IsNil() {
+		want, gotV = want.Elem(), gotV.Elem()
+	}
+	if !gotV.IsValid() {
+		return msg
+	}
+	if want.Kind() != reflect.Struct || want.Type() != gotV.Type() {
+		return msg
+	}
+	for i := 0; i < want.NumField(); i++ {
+		f := want.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		wf, gf := want.Field(i).Interface(), gotV.Field(i).Interface()
+		if !reflect.DeepEqual(wf, gf) {
+			msg += fmt.Sprintf("\n\t\tfield %s: got %#v, want %#v", f.Name, gf, wf)
+		}
+	}
+	return msg
+}
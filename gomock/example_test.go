@@ -66,3 +66,13 @@ func ExampleCall_DoAndReturn_withOverridableExpectations() {
 	fmt.Printf("%s %s", r, s)
 	// Output: I'm sleepy foo
 }
+
+func ExampleExplain() {
+	m := gomock.All(gomock.Len(2), gomock.Not(gomock.Nil()))
+	fmt.Print(gomock.Explain(m, []int{1}))
+	// Output:
+	// FAIL has length 2; not(is nil): got [1] ([]int)
+	//   FAIL has length 2: got [1] ([]int) of length 1
+	//   PASS not(is nil)
+	//     FAIL is nil: got [1] ([]int)
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package examplecheck checks that generated mocks behave as mocks of their
+// interfaces, by making a round trip through the Controller for every method:
+// an expectation is declared through the recorder of the mock, the method is
+// called through the interface, and the values given to Return must come
+// back. It catches regressions of the generator for exotic signatures, such
+// as variadic, generic or channel-typed methods, without writing a test for
+// each method.
+//
+// A package of mocks typically checks all of its mocks in a single test:
+//
+//	func TestMocks(t *testing.T) {
+//	  examplecheck.Check[store.Store](t, NewMockStore)
+//	  examplecheck.Check[store.Cache[string]](t, NewMockCache[string])
+//	}
+package examplecheck
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	ctrlType   = reflect.TypeOf((*gomock.Controller)(nil))
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+	stringType = reflect.TypeOf("")
+)
+
+// Check checks the mock of the interface I created by newMock, a mock
+// constructor such as NewMockStore. It fails t if the mock does not implement
+// I, or if a call to an exported method of I is not matched by the
+// expectation declared for it or does not return the values it was given.
+func Check[I any](t testing.TB, newMock any) {
+	t.Helper()
+
+	it := reflect.TypeOf((*I)(nil)).Elem()
+	if it.Kind() != reflect.Interface {
+		t.Fatalf("examplecheck: %v is not an interface", it)
+		return
+	}
+	nm := reflect.ValueOf(newMock)
+	if nm.Kind() != reflect.Func || nm.Type().NumIn() != 1 || nm.Type().In(0) != ctrlType || nm.Type().NumOut() != 1 {
+		t.Fatalf("examplecheck: %T is not a mock constructor", newMock)
+		return
+	}
+
+	ctrl := gomock.NewController(t)
+	mock := nm.Call([]reflect.Value{reflect.ValueOf(ctrl)})[0]
+	if !mock.Type().Implements(it) {
+		t.Fatalf("examplecheck: %v does not implement %v", mock.Type(), it)
+		return
+	}
+	expect := mock.MethodByName("EXPECT")
+	if !expect.IsValid() || expect.Type().NumIn() != 0 || expect.Type().NumOut() != 1 {
+		t.Fatalf("examplecheck: %v has no EXPECT method", mock.Type())
+		return
+	}
+	recorder := expect.Call(nil)[0]
+	iface := reflect.New(it).Elem()
+	iface.Set(mock)
+
+	for i := 0; i < it.NumMethod(); i++ {
+		if m := it.Method(i); m.IsExported() {
+			checkMethod(t, iface, recorder, m)
+		}
+	}
+	ctrl.Finish()
+}
+
+// checkMethod declares an expectation for the method m of iface through
+// recorder, then calls it and checks its results.
+func checkMethod(t testing.TB, iface, recorder reflect.Value, m reflect.Method) {
+	t.Helper()
+
+	rm := recorder.MethodByName(m.Name)
+	if !rm.IsValid() {
+		t.Errorf("examplecheck: %v has no method %s", recorder.Type(), m.Name)
+		return
+	}
+	mt := m.Type
+	nIn := mt.NumIn()
+	if mt.IsVariadic() {
+		nIn--
+	}
+	if rm.Type().NumIn() < nIn || rm.Type().NumOut() != 1 {
+		t.Errorf("examplecheck: %v.%s does not record %s", recorder.Type(), m.Name, mt)
+		return
+	}
+
+	matchers := make([]reflect.Value, nIn)
+	for i := range matchers {
+		matchers[i] = reflect.ValueOf(gomock.Any())
+	}
+	call := rm.Call(matchers)[0]
+
+	want := make([]reflect.Value, mt.NumOut())
+	for i := range want {
+		want[i] = sample(mt.Out(i), i)
+	}
+	if len(want) > 0 {
+		ret := call.MethodByName("Return")
+		if !ret.IsValid() {
+			t.Errorf("examplecheck: %v has no Return method", call.Type())
+			return
+		}
+		ret.Call(want)
+	}
+
+	args := make([]reflect.Value, nIn)
+	for i := range args {
+		args[i] = reflect.Zero(mt.In(i))
+	}
+	got := iface.MethodByName(m.Name).Call(args)
+	for i := range want {
+		if want[i].Kind() == reflect.Func {
+			// Functions cannot be compared.
+			continue
+		}
+		if !reflect.DeepEqual(got[i].Interface(), want[i].Interface()) {
+			t.Errorf("examplecheck: result %d of %v.%s is %#v, want %#v", i, iface.Type(), m.Name, got[i], want[i])
+		}
+	}
+}
+
+// sample returns a value of type t, non-zero where possible, and different
+// for each seed so that results returned in the wrong order are caught.
+func sample(t reflect.Type, seed int) reflect.Value {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(seed + 1))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(seed + 1))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(seed) + 0.5)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(float64(seed), 1))
+	case reflect.String:
+		v.SetString(fmt.Sprintf("result %d", seed))
+	case reflect.Ptr:
+		v = reflect.New(t.Elem())
+	case reflect.Slice:
+		v = reflect.MakeSlice(t, 1, 1)
+	case reflect.Map:
+		v = reflect.MakeMap(t)
+	case reflect.Chan:
+		v = reflect.MakeChan(reflect.ChanOf(reflect.BothDir, t.Elem()), 0).Convert(t)
+	case reflect.Interface:
+		if t == errorType {
+			v.Set(reflect.ValueOf(errors.New(fmt.Sprintf("error %d", seed))))
+		} else if stringType.Implements(t) {
+			v.Set(reflect.ValueOf(fmt.Sprintf("result %d", seed)))
+		}
+	}
+	return v
+}
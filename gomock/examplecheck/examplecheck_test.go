@@ -0,0 +1,146 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package examplecheck_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/mock/gomock/examplecheck"
+	"go.uber.org/mock/gomock/internal/mock_gomock"
+)
+
+func TestCheck(t *testing.T) {
+	examplecheck.Check[gomock.Matcher](t, mock_gomock.NewMockMatcher)
+	examplecheck.Check[Store](t, NewMockStore)
+}
+
+func TestCheck_Broken(t *testing.T) {
+	r := &recordingTB{TB: t}
+	examplecheck.Check[Store](r, NewBrokenMockStore)
+
+	want := "examplecheck: result 0 of examplecheck_test.Store.Get is"
+	if len(r.errors) != 1 || !strings.HasPrefix(r.errors[0], want) {
+		t.Errorf("got errors %q, want one starting with %q", r.errors, want)
+	}
+}
+
+// recordingTB records the failures of a test instead of reporting them.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// Store is an interface with a variety of signatures.
+type Store interface {
+	Get(key string) (string, error)
+	Put(key string, values ...[]byte) error
+	Watch(keys map[string]bool) <-chan string
+	Close()
+}
+
+// MockStore is a mock of Store as generated by mockgen.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	// Whether Get returns the wrong result, as a broken generator would.
+	broken bool
+}
+
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+func NewBrokenMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := NewMockStore(ctrl)
+	mock.broken = true
+	return mock
+}
+
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+func (m *MockStore) Get(key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	if m.broken {
+		return "", ret1
+	}
+	return ret0, ret1
+}
+
+func (mr *MockStoreMockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), key)
+}
+
+func (m *MockStore) Put(key string, values ...[]byte) error {
+	m.ctrl.T.Helper()
+	varargs := []any{key}
+	for _, a := range values {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Put", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockStoreMockRecorder) Put(key any, values ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{key}, values...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), varargs...)
+}
+
+func (m *MockStore) Watch(keys map[string]bool) <-chan string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", keys)
+	ret0, _ := ret[0].(<-chan string)
+	return ret0
+}
+
+func (mr *MockStoreMockRecorder) Watch(keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockStore)(nil).Watch), keys)
+}
+
+func (m *MockStore) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+func (mr *MockStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockStore)(nil).Close))
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// Explain returns an explanation of whether x matches m, to find out why a
+// composite matcher rejects a value without running the test declaring it.
+// It has one line for m and one for each matcher nested in it, indented
+// under the matcher they are nested in, telling whether it matches its
+// argument and, if not, what it got:
+//
+//	fmt.Print(gomock.Explain(gomock.All(gomock.Len(2), gomock.Not(gomock.Nil())), []int{1}))
+//	// FAIL has length 2; not(is nil): got [1] ([]int)
+//	//   FAIL has length 2: got [1] ([]int) of length 1
+//	//   PASS not(is nil)
+//	//     FAIL is nil: got [1] ([]int)
+//
+// The matchers nested in All, Not, Xor, NoneOf, AtLeast, PointsTo, Lazy,
+// Memoize and ContextChain are explained.
+func Explain(m Matcher, x any) string {
+	var b strings.Builder
+	explainMatcher(&b, m, x, 0)
+	return b.String()
+}
+
+func explainMatcher(b *strings.Builder, m Matcher, x any, depth int) {
+	if mm, ok := m.(*memoMatcher); ok {
+		explainMatcher(b, mm.m, x, depth)
+		return
+	}
+
+	match := m.Matches(x)
+	writeExplanation(b, depth, match, m.String(), func() string { return formatGottenArg(m, x) })
+
+	switch m := m.(type) {
+	case allMatcher:
+		explainMatchers(b, m.matchers, x, depth+1)
+	case noneOfMatcher:
+		explainMatchers(b, m.matchers, x, depth+1)
+	case atLeastMatcher:
+		explainMatchers(b, m.matchers, x, depth+1)
+	case notMatcher:
+		explainMatcher(b, m.m, x, depth+1)
+	case xorMatcher:
+		explainMatchers(b, []Matcher{m.m1, m.m2}, x, depth+1)
+	case pointsToMatcher:
+		if v := reflect.ValueOf(x); v.Kind() == reflect.Ptr && !v.IsNil() {
+			explainMatcher(b, m.m, v.Elem().Interface(), depth+1)
+		}
+	case lazyMatcher:
+		if inner := m.f(); inner != nil {
+			explainMatcher(b, inner, x, depth+1)
+		}
+	case ContextMatcher:
+		if ctx, ok := x.(context.Context); ok {
+			for _, c := range m.conds {
+				writeExplanation(b, depth+1, c.matches(ctx), c.desc, func() string { return c.got(ctx) })
+			}
+		}
+	}
+}
+
+func explainMatchers(b *strings.Builder, ms []Matcher, x any, depth int) {
+	for _, m := range ms {
+		explainMatcher(b, m, x, depth)
+	}
+}
+
+// writeExplanation writes the line explaining a matcher described by desc,
+// with what it got if it does not match.
+func writeExplanation(b *strings.Builder, depth int, match bool, desc string, got func() string) {
+	b.WriteString(strings.Repeat("  ", depth))
+	if match {
+		b.WriteString("PASS ")
+		b.WriteString(desc)
+	} else {
+		b.WriteString("FAIL ")
+		b.WriteString(desc)
+		b.WriteString(": got ")
+		b.WriteString(got())
+	}
+	b.WriteByte('\n')
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestExplain(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "user", "bob")
+	n := 3
+
+	tests := []struct {
+		name string
+		m    gomock.Matcher
+		x    any
+		want string
+	}{
+		{
+			name: "leaf",
+			m:    gomock.Eq(2),
+			x:    2,
+			want: "PASS is equal to 2 (int)\n",
+		},
+		{
+			name: "nested",
+			m:    gomock.NoneOf(gomock.Eq(1), gomock.Xor(gomock.Eq(2), gomock.Nil())),
+			x:    2,
+			want: "FAIL none of: is equal to 1 (int); either is equal to 2 (int) or is nil, but not both: got 2 (int)\n" +
+				"  FAIL is equal to 1 (int): got 2 (int)\n" +
+				"  PASS either is equal to 2 (int) or is nil, but not both\n" +
+				"    PASS is equal to 2 (int)\n" +
+				"    FAIL is nil: got 2 (int)\n",
+		},
+		{
+			name: "pointer",
+			m:    gomock.PointsTo(gomock.Memoize(gomock.Eq(4))),
+			x:    &n,
+			want: "FAIL is a pointer to a value that is equal to 4 (int): got pointer to 3 (int)\n" +
+				"  FAIL is equal to 4 (int): got 3 (int)\n",
+		},
+		{
+			name: "context",
+			m:    gomock.ContextChain().WithValue("user", "alice").NotCancelled(),
+			x:    ctx,
+			want: `FAIL is a context.Context with value for "user": is equal to alice (string), not cancelled: got context.Context with value for "user": bob (string)` + "\n" +
+				`  FAIL with value for "user": is equal to alice (string): got value for "user": bob (string)` + "\n" +
+				"  PASS not cancelled\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, tt.want, gomock.Explain(tt.m, tt.x))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// NewFuzzController returns a Controller for the run of a fuzz target on
+// input, the arguments of the fuzz function, whose failures mark the input as
+// failing and are reported along with the input:
+//
+//	func FuzzParse(f *testing.F) {
+//	  f.Fuzz(func(t *testing.T, data []byte) {
+//	    ctrl := gomock.NewFuzzController(t, []any{data})
+//	    m := NewMockSink(ctrl)
+//	    // ...
+//	  })
+//	}
+//
+// A failure aborting the test from a goroutine other than the one calling
+// NewFuzzController, such as an unexpected call from a worker of the code
+// under test, does not abort the test, which the testing package does not
+// allow, and which crashes the fuzzing process. It fails the test and ends
+// the goroutine instead, running its deferred calls.
+func NewFuzzController(t TestReporter, input []any, opts ...ControllerOption) *Controller {
+	h, ok := t.(TestHelper)
+	if !ok {
+		h = &nopTestHelper{t}
+	}
+	return NewController(&fuzzReporter{t: h, input: formatFuzzInput(input), goroutine: goroutineID()}, opts...)
+}
+
+// fuzzReporter reports the failures of a Controller for an input of a fuzz
+// target.
+type fuzzReporter struct {
+	t         TestHelper
+	input     string
+	goroutine uint64 // ID of the test goroutine
+}
+
+func (r *fuzzReporter) Errorf(format string, args ...any) {
+	r.t.Helper()
+	r.t.Errorf(format+"\nfuzz input: %s", append(args, r.input)...)
+}
+
+func (r *fuzzReporter) Fatalf(format string, args ...any) {
+	r.t.Helper()
+	if goroutineID() == r.goroutine {
+		r.t.Fatalf(format+"\nfuzz input: %s", append(args, r.input)...)
+		return
+	}
+	r.t.Errorf(format+"\nfuzz input: %s", append(args, r.input)...)
+	runtime.Goexit()
+}
+
+func (r *fuzzReporter) Helper() {
+	r.t.Helper()
+}
+
+// formatFuzzInput formats the arguments of a fuzz target as Go values.
+func formatFuzzInput(input []any) string {
+	ss := make([]string, len(input))
+	for i, x := range input {
+		switch x := x.(type) {
+		case []byte:
+			ss[i] = fmt.Sprintf("[]byte(%q)", x)
+		default:
+			ss[i] = fmt.Sprintf("%#v", x)
+		}
+	}
+	return "(" + strings.Join(ss, ", ") + ")"
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewFuzzController(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewFuzzController(reporter, []any{[]byte("ab"), 3})
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}, "Unexpected call to", "\nfuzz input: ([]byte(\"ab\"), 3)")
+}
+
+func TestNewFuzzController_OtherGoroutine(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewFuzzController(reporter, []any{"input"})
+	subject := new(Subject)
+
+	var wg sync.WaitGroup
+	var returned bool
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ctrl.Call(subject, "FooMethod", "argument")
+		returned = true
+	}()
+	wg.Wait()
+
+	reporter.assertFail("unexpected call from another goroutine")
+	if returned {
+		t.Error("the unexpected call returned")
+	}
+	if len(reporter.log) != 1 || !strings.HasSuffix(reporter.log[0], "\nfuzz input: (\"input\")") {
+		t.Errorf("log %q does not report the fuzz input", reporter.log)
+	}
+}
+
+func FuzzNewFuzzController(f *testing.F) {
+	f.Add("a", 1)
+	f.Add("b", 2)
+	f.Fuzz(func(t *testing.T, s string, n int) {
+		ctrl := gomock.NewFuzzController(t, []any{s, n})
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FooMethod", s).Return(n)
+		if got := ctrl.Call(subject, "FooMethod", s)[0]; got != n {
+			t.Errorf("FooMethod(%q) = %v, want %d", s, got, n)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import "errors"
+
+// GoroutinePolicy restricts the goroutines allowed to call a mock.
+type GoroutinePolicy int
+
+const (
+	// AnyGoroutine allows calls from any goroutine. It is the default policy.
+	AnyGoroutine GoroutinePolicy = iota
+	// TestGoroutine only allows calls from the test goroutine.
+	TestGoroutine
+	// BackgroundGoroutine only allows calls from goroutines other than the
+	// test goroutine, catching synchronous calls by code expected to defer
+	// its work to a background worker.
+	BackgroundGoroutine
+)
+
+// goroutineRestriction is the policy set by AllowCallsFrom for a mock.
+type goroutineRestriction struct {
+	policy GoroutinePolicy
+	testID uint64 // ID of the test goroutine
+}
+
+// AllowCallsFrom restricts the goroutines allowed to call the given mock.
+// The goroutine calling AllowCallsFrom is the test goroutine. A call from
+// another goroutine fails the test like an unexpected call.
+//
+// Example usage:
+//
+//	ctrl.AllowCallsFrom(mockSink, gomock.BackgroundGoroutine)
+func (ctrl *Controller) AllowCallsFrom(receiver any, policy GoroutinePolicy) {
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	if ctrl.goroutines == nil {
+		ctrl.goroutines = make(map[any]goroutineRestriction)
+	}
+	ctrl.goroutines[receiver] = goroutineRestriction{policy: policy, testID: goroutineID()}
+}
+
+// checkGoroutine returns an error if the current goroutine is not allowed to
+// call receiver.
+func (ctrl *Controller) checkGoroutine(receiver any) error {
+	r, ok := ctrl.goroutines[receiver]
+	if !ok {
+		return nil
+	}
+	onTest := goroutineID() == r.testID
+	switch {
+	case r.policy == TestGoroutine && !onTest:
+		return errors.New("the mock only allows calls from the test goroutine")
+	case r.policy == BackgroundGoroutine && onTest:
+		return errors.New("the mock only allows calls from goroutines other than the test goroutine")
+	}
+	return nil
+}
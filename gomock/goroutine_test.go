@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// inBackground runs fn in another goroutine and waits for it.
+func inBackground(fn func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	<-done
+}
+
+func TestAllowCallsFrom_TestGoroutine(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	ctrl.AllowCallsFrom(subject, gomock.TestGoroutine)
+	ctrl.RecordCall(subject, "FooMethod", "argument").AnyTimes()
+
+	ctrl.Call(subject, "FooMethod", "argument")
+	inBackground(func() {
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FooMethod", "argument")
+		}, "Unexpected call to", "only allows calls from the test goroutine")
+	})
+}
+
+func TestAllowCallsFrom_BackgroundGoroutine(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	ctrl.AllowCallsFrom(subject, gomock.BackgroundGoroutine)
+	ctrl.RecordCall(subject, "FooMethod", "argument").AnyTimes()
+
+	inBackground(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	})
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}, "Unexpected call to", "only allows calls from goroutines other than the test goroutine")
+}
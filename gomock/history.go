@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import "reflect"
+
+type argSnapshotsOption struct{}
+
+// WithArgSnapshots makes the Controller keep the history of the calls its
+// mocks receive, available through ReceivedCalls, with a copy of the slice and
+// map arguments taken when each call is made. Assertions on the arguments
+// after the code under test has run are then not affected by the code reusing
+// or modifying the same slices and maps:
+//
+//	buf := make([]byte, 0, 64)
+//	buf = append(buf, "a"...)
+//	m.Write(buf)
+//	buf = append(buf[:0], "b"...) // The history still holds "a".
+//
+// The copies are shallow: the elements of the slices and maps are not copied.
+func WithArgSnapshots() argSnapshotsOption {
+	return argSnapshotsOption{}
+}
+
+func (argSnapshotsOption) apply(ctrl *Controller) {
+	ctrl.history = new(callHistory)
+}
+
+// ReceivedCall is a call received by a mock, as recorded by a Controller
+// created with WithArgSnapshots.
+type ReceivedCall struct {
+	Receiver any
+	Method   string
+	Args     []ArgSnapshot
+}
+
+// ArgSnapshot is an argument of a call as it was when the call was made.
+type ArgSnapshot struct {
+	// Value is the argument, or a shallow copy of it for slices and maps.
+	Value any
+	// Len is the length of a slice or map argument, and Cap the capacity of
+	// a slice argument. They are -1 when they do not apply.
+	Len, Cap int
+}
+
+// callHistory records the calls received by the mocks of a Controller.
+type callHistory struct {
+	calls []ReceivedCall
+}
+
+func (h *callHistory) record(receiver any, method string, args []any) {
+	snapshots := make([]ArgSnapshot, len(args))
+	for i, arg := range args {
+		snapshots[i] = snapshotArg(arg)
+	}
+	h.calls = append(h.calls, ReceivedCall{Receiver: receiver, Method: method, Args: snapshots})
+}
+
+// snapshotArg returns arg with a shallow copy of it if it is a slice or a map.
+func snapshotArg(arg any) ArgSnapshot {
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return ArgSnapshot{Value: arg}
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		return ArgSnapshot{Value: c.Interface(), Len: v.Len(), Cap: v.Cap()}
+	case reflect.Map:
+		if v.IsNil() {
+			return ArgSnapshot{Value: arg, Cap: -1}
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return ArgSnapshot{Value: c.Interface(), Len: v.Len(), Cap: -1}
+	}
+	return ArgSnapshot{Value: arg, Len: -1, Cap: -1}
+}
+
+// ReceivedCalls returns the calls received so far by the mocks of the
+// Controller, in order. It fails the test if the Controller was not created
+// with WithArgSnapshots.
+func (ctrl *Controller) ReceivedCalls() []ReceivedCall {
+	ctrl.T.Helper()
+
+	if ctrl.history == nil {
+		ctrl.T.Fatalf("gomock: ReceivedCalls requires a Controller created with WithArgSnapshots")
+		return nil
+	}
+
+	ctrl.lock()
+	defer ctrl.unlock()
+	return append([]ReceivedCall(nil), ctrl.history.calls...)
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithArgSnapshots(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithArgSnapshots())
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "SetArgMethod", gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	ctrl.RecordCall(subject, "FooMethod", "argument")
+
+	buf := make([]byte, 0, 8)
+	buf = append(buf, "ab"...)
+	n := 1
+	m := map[any]any{"a": 1}
+	ctrl.Call(subject, "SetArgMethod", buf, &n, m)
+	// The code under test reuses the slice and the map.
+	buf = append(buf[:0], "xyz"...)
+	m["b"] = 2
+	ctrl.Call(subject, "SetArgMethod", buf, &n, map[any]any(nil))
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Finish()
+	reporter.assertPass("Expected all calls to match")
+
+	calls := ctrl.ReceivedCalls()
+	if len(calls) != 3 {
+		t.Fatalf("got %d calls, want 3", len(calls))
+	}
+	if calls[0].Receiver != subject || calls[0].Method != "SetArgMethod" {
+		t.Errorf("first call is %T.%s", calls[0].Receiver, calls[0].Method)
+	}
+	want := []gomock.ArgSnapshot{
+		{Value: []byte("ab"), Len: 2, Cap: 8},
+		{Value: &n, Len: -1, Cap: -1},
+		{Value: map[any]any{"a": 1}, Len: 1, Cap: -1},
+	}
+	if !reflect.DeepEqual(calls[0].Args, want) {
+		t.Errorf("first call args are %#v, want %#v", calls[0].Args, want)
+	}
+	want = []gomock.ArgSnapshot{
+		{Value: []byte("xyz"), Len: 3, Cap: 8},
+		{Value: &n, Len: -1, Cap: -1},
+		{Value: map[any]any(nil), Cap: -1},
+	}
+	if !reflect.DeepEqual(calls[1].Args, want) {
+		t.Errorf("second call args are %#v, want %#v", calls[1].Args, want)
+	}
+	want = []gomock.ArgSnapshot{{Value: "argument", Len: -1, Cap: -1}}
+	if !reflect.DeepEqual(calls[2].Args, want) {
+		t.Errorf("third call args are %#v, want %#v", calls[2].Args, want)
+	}
+}
+
+func TestReceivedCalls_WithoutArgSnapshots(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	reporter.assertFatal(func() {
+		ctrl.ReceivedCalls()
+	}, "requires a Controller created with WithArgSnapshots")
+}
+
+func TestCall_ReceivedArgs(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	call := ctrl.RecordCall(subject, "VariadicMethod", gomock.Any(), gomock.Any()).Times(2)
+
+	if got := call.ReceivedArgs(); len(got) != 0 {
+		t.Errorf("got %v before any call, want none", got)
+	}
+	ctrl.Call(subject, "VariadicMethod", 0)
+	ctrl.Call(subject, "VariadicMethod", 1, "a", "b")
+	ctrl.Finish()
+	reporter.assertPass("Expected all calls to match")
+
+	want := [][]any{{0}, {1, "a", "b"}}
+	if got := call.ReceivedArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCall_ReceivedArgs_WithArgSnapshots(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithArgSnapshots())
+	subject := new(Subject)
+	call := ctrl.RecordCall(subject, "SetArgMethod", gomock.Any(), gomock.Any(), gomock.Any())
+
+	buf := []byte("ab")
+	n := 1
+	ctrl.Call(subject, "SetArgMethod", buf, &n, map[any]any(nil))
+	// The code under test reuses the slice.
+	copy(buf, "xy")
+	ctrl.Finish()
+	reporter.assertPass("Expected all calls to match")
+
+	want := [][]any{{[]byte("ab"), &n, map[any]any(nil)}}
+	if got := call.ReceivedArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import "reflect"
+
+// Inherit moves the expectations declared on the mock from to the mock
+// receiver, which must have every method they expect with the same type. It
+// lets the fixtures declaring expectations on the mock of an interface apply
+// to the mocks of the interfaces embedding it:
+//
+//	func expectHeader(r *MockReader) { r.EXPECT().Read(gomock.Any()).Return(4, nil) }
+//
+//	reader := NewMockReader(ctrl)
+//	expectHeader(reader)
+//	ctrl.Inherit(readWriter, reader) // or readWriter.InheritFrom(reader) with mockgen -inherit
+//
+// The expectations keep their options, order and prerequisites, and are no
+// longer expected on from. Both mocks must belong to the Controller.
+func (ctrl *Controller) Inherit(receiver, from any) {
+	ctrl.T.Helper()
+
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	store := ctrl.expectedCalls.store
+	expected, exhausted := store.All()
+	recv := reflect.TypeOf(receiver)
+	checked := make(map[string]bool)
+	for _, c := range append(expected, exhausted...) {
+		if c.receiver != from || checked[c.method] {
+			continue
+		}
+		m, ok := recv.MethodByName(c.method)
+		if !ok {
+			ctrl.T.Fatalf("gomock: cannot inherit the expected call %T.%s at %s: %T has no method %s",
+				from, c.method, c.origin, receiver, c.method)
+			return
+		}
+		// The type of the method of a mock, without its receiver.
+		in := make([]reflect.Type, m.Type.NumIn()-1)
+		for i := range in {
+			in[i] = m.Type.In(i + 1)
+		}
+		out := make([]reflect.Type, m.Type.NumOut())
+		for i := range out {
+			out[i] = m.Type.Out(i)
+		}
+		if mt := reflect.FuncOf(in, out, m.Type.IsVariadic()); mt != c.methodType {
+			ctrl.T.Fatalf("gomock: cannot inherit the expected call %T.%s at %s: %T.%s is a %v, not a %v",
+				from, c.method, c.origin, receiver, c.method, mt, c.methodType)
+			return
+		}
+		checked[c.method] = true
+	}
+
+	for _, c := range expected {
+		if c.receiver == from {
+			store.Drop(c)
+			c.receiver = receiver
+			store.Add(c)
+		}
+	}
+	for _, c := range exhausted {
+		if c.receiver == from {
+			store.Drop(c)
+			c.receiver = receiver
+			store.Add(c)
+			store.Remove(c)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+)
+
+// FooSubject has a subset of the methods of Subject.
+type FooSubject struct{}
+
+func (s *FooSubject) FooMethod(arg string) int {
+	return 0
+}
+
+// BarSubject has a method of Subject with another type.
+type BarSubject struct{}
+
+func (s *BarSubject) BarMethod(arg int) int {
+	return 0
+}
+
+func TestInherit(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	foo := new(FooSubject)
+	subject := new(Subject)
+
+	first := ctrl.RecordCall(foo, "FooMethod", "a").Return(1)
+	ctrl.RecordCall(foo, "FooMethod", "b").Return(2).After(first)
+	ctrl.RecordCall(subject, "BarMethod", "c").Return(3)
+	ctrl.Inherit(subject, foo)
+
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "a"))
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "b"))
+	assertEqual(t, []any{3}, ctrl.Call(subject, "BarMethod", "c"))
+	ctrl.Finish()
+	reporter.assertPass("inherited expectations were met")
+}
+
+func TestInherit_Moves(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	foo := new(FooSubject)
+	subject := new(Subject)
+
+	ctrl.RecordCall(foo, "FooMethod", "a").Return(1)
+	ctrl.Inherit(subject, foo)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(foo, "FooMethod", "a")
+	}, "Unexpected call to", "there are no expected calls of the method \"FooMethod\" for that receiver")
+}
+
+func TestInherit_MissingMethod(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "BarMethod", "a")
+	reporter.assertFatal(func() {
+		ctrl.Inherit(new(FooSubject), subject)
+	}, "cannot inherit the expected call *gomock_test.Subject.BarMethod", "*gomock_test.FooSubject has no method BarMethod")
+}
+
+func TestInherit_MethodType(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	bar := new(BarSubject)
+
+	ctrl.RecordCall(bar, "BarMethod", 1)
+	reporter.assertFatal(func() {
+		ctrl.Inherit(new(Subject), bar)
+	}, "cannot inherit the expected call *gomock_test.BarSubject.BarMethod", "*gomock_test.Subject.BarMethod is a func(string) int, not a func(int) int")
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type leakCheckOption struct {
+	timeout time.Duration
+}
+
+// WithLeakCheck makes the Controller check, when it finishes, that every
+// goroutine which called one of its mocks has exited, other than the goroutine
+// finishing the Controller. Goroutines still running after timeout are
+// reported along with their stacks.
+//
+// Unlike a check of all the goroutines of the test binary, it only reports the
+// workers that used the mocks of this Controller.
+//
+// The wait relies on the time package only, so in a testing/synctest bubble it
+// follows the fake clock of the bubble: Finish returns as soon as the workers
+// exit or the timeout elapses in fake time, without sleeping for real.
+func WithLeakCheck(timeout time.Duration) leakCheckOption {
+	return leakCheckOption{timeout: timeout}
+}
+
+func (o leakCheckOption) apply(ctrl *Controller) {
+	ctrl.leaks = &leakChecker{timeout: o.timeout, ids: make(map[uint64]bool)}
+}
+
+// leakChecker tracks the goroutines that called the mocks of a Controller.
+type leakChecker struct {
+	timeout time.Duration
+
+	mu  sync.Mutex
+	ids map[uint64]bool
+}
+
+// record notes that the current goroutine called a mock.
+func (l *leakChecker) record() {
+	id := goroutineID()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ids[id] = true
+}
+
+// wait waits for the recorded goroutines to exit, and returns the stacks of
+// those still running after the timeout.
+func (l *leakChecker) wait() []string {
+	self := goroutineID()
+	deadline := time.Now().Add(l.timeout)
+	for {
+		var leaked []string
+		l.mu.Lock()
+		for _, stack := range goroutineStacks() {
+			if id, ok := stackGoroutineID(stack); ok && id != self && l.ids[id] {
+				leaked = append(leaked, stack)
+			}
+		}
+		l.mu.Unlock()
+
+		if len(leaked) == 0 || !time.Now().Before(deadline) {
+			return leaked
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// goroutineID returns the ID of the current goroutine.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	id, _ := stackGoroutineID(string(buf[:runtime.Stack(buf, false)]))
+	return id
+}
+
+// goroutineStacks returns the stacks of all goroutines.
+func goroutineStacks() []string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	var stacks []string
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		stacks = append(stacks, string(stack))
+	}
+	return stacks
+}
+
+// stackGoroutineID parses the ID of a goroutine from the header of its stack,
+// such as "goroutine 18 [running]:".
+func stackGoroutineID(stack string) (uint64, bool) {
+	header, ok := strings.CutPrefix(stack, "goroutine ")
+	if !ok {
+		return 0, false
+	}
+	id, _, _ := strings.Cut(header, " ")
+	n, err := strconv.ParseUint(id, 10, 64)
+	return n, err == nil
+}
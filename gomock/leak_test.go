@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithLeakCheck(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithLeakCheck(50*time.Millisecond))
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").Times(2)
+
+	// The current goroutine is not reported.
+	ctrl.Call(subject, "FooMethod", "argument")
+
+	release, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		ctrl.Call(subject, "FooMethod", "argument")
+		<-release
+	}()
+	// A goroutine that did not call a mock is not reported.
+	go func() {
+		<-release
+	}()
+
+	for !ctrl.Satisfied() {
+		time.Sleep(time.Millisecond)
+	}
+	ctrl.Finish()
+	close(release)
+	<-done
+
+	reporter.assertFail("Expected the leaked goroutine to be reported")
+	if len(reporter.log) != 1 {
+		t.Fatalf("got %d errors, want 1: %q", len(reporter.log), reporter.log)
+	}
+	if got, want := reporter.log[0], "TestWithLeakCheck.func1"; !strings.Contains(got, want) {
+		t.Errorf("error %q does not contain %q", got, want)
+	}
+}
+
+func TestWithLeakCheck_Exited(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithLeakCheck(time.Second))
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument")
+
+	release := make(chan struct{})
+	go func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+		<-release
+	}()
+
+	for !ctrl.Satisfied() {
+		time.Sleep(time.Millisecond)
+	}
+	// The goroutine exits while Finish waits for it.
+	time.AfterFunc(10*time.Millisecond, func() { close(release) })
+	ctrl.Finish()
+
+	reporter.assertPass("Expected the goroutine to have exited")
+}
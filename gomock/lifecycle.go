@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import "fmt"
+
+// CheckNotClosed is called by the mocks generated with the -close_guard flag
+// of mockgen before each of their calls. It fails the test if a method
+// closing the mock, such as Close or Shutdown, was called before, since
+// nothing should use an object once closed. It is not intended to be used in
+// user code.
+func (ctrl *Controller) CheckNotClosed(receiver any, method string) {
+	ctrl.T.Helper()
+
+	ctrl.lock()
+	closedBy, closed := ctrl.closed[receiver]
+	ctrl.unlock()
+
+	if closed {
+		// 0 is us, 1 is the generated mock, and 2 is the code calling it.
+		origin := callerInfo(2)
+		ctrl.T.Fatalf("Unexpected call to %T.%v at %s because: the mock was closed by %s",
+			receiver, method, origin, closedBy)
+	}
+}
+
+// MarkClosed is called by the mocks generated with the -close_guard flag of
+// mockgen once a method closing the mock returns, so that CheckNotClosed
+// fails the calls that follow. It is not intended to be used in user code.
+func (ctrl *Controller) MarkClosed(receiver any, method string) {
+	// 0 is us, 1 is the generated mock, and 2 is the code calling it.
+	origin := callerInfo(2)
+
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	if ctrl.closed == nil {
+		ctrl.closed = make(map[any]string)
+	}
+	if _, ok := ctrl.closed[receiver]; !ok {
+		ctrl.closed[receiver] = fmt.Sprintf("%s at %s", method, origin)
+	}
+}
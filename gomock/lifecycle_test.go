@@ -0,0 +1,32 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import "testing"
+
+func TestCheckNotClosed(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	other := new(NamedSubject)
+
+	ctrl.CheckNotClosed(subject, "FooMethod")
+	ctrl.MarkClosed(subject, "BarMethod")
+	ctrl.CheckNotClosed(other, "FooMethod")
+	reporter.assertPass("Expected calls before closing the mock to pass")
+
+	reporter.assertFatal(func() {
+		ctrl.CheckNotClosed(subject, "FooMethod")
+	}, "Unexpected call to *gomock_test.Subject.FooMethod", "the mock was closed by BarMethod at")
+}
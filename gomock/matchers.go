@@ -15,11 +15,24 @@
 package gomock
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"net"
+	"net/netip"
 	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/prototext"
+	protov2 "google.golang.org/protobuf/proto"
 )
 
 // A Matcher is a representation of a class of values.
@@ -89,6 +102,74 @@ func GotFormatterAdapter(s GotFormatter, m Matcher) Matcher {
 	}
 }
 
+// gotFormatters are the GotFormatters registered with RegisterGotFormatter.
+var gotFormatters struct {
+	sync.RWMutex
+	byType map[reflect.Type]GotFormatter
+	intfs  []reflect.Type // interface types with a formatter, in order of registration
+}
+
+// RegisterGotFormatter registers f to format the received values of type t
+// in the failure messages of every matcher that does not implement
+// GotFormatter itself, without wrapping each one in GotFormatterAdapter. If t
+// is an interface type, f formats the values of the types implementing it
+// that have no formatter of their own, the first interface registered
+// winning. A nil f removes the formatter of t.
+//
+// Example usage:
+//
+//	gomock.RegisterGotFormatter(reflect.TypeOf(Credentials{}), gomock.GotFormatterFunc(func(got any) string {
+//	  return fmt.Sprintf("credentials of %s", got.(Credentials).User)
+//	}))
+func RegisterGotFormatter(t reflect.Type, f GotFormatter) {
+	gotFormatters.Lock()
+	defer gotFormatters.Unlock()
+
+	if gotFormatters.byType == nil {
+		gotFormatters.byType = make(map[reflect.Type]GotFormatter)
+	}
+	_, registered := gotFormatters.byType[t]
+	if f == nil {
+		delete(gotFormatters.byType, t)
+	} else {
+		gotFormatters.byType[t] = f
+	}
+	if t.Kind() != reflect.Interface || registered == (f != nil) {
+		return
+	}
+	if f != nil {
+		gotFormatters.intfs = append(gotFormatters.intfs, t)
+		return
+	}
+	for i, it := range gotFormatters.intfs {
+		if it == t {
+			gotFormatters.intfs = append(gotFormatters.intfs[:i:i], gotFormatters.intfs[i+1:]...)
+			break
+		}
+	}
+}
+
+// registeredGotFormatter returns the formatter registered for the type of x.
+func registeredGotFormatter(x any) (GotFormatter, bool) {
+	t := reflect.TypeOf(x)
+	if t == nil {
+		return nil, false
+	}
+
+	gotFormatters.RLock()
+	defer gotFormatters.RUnlock()
+
+	if f, ok := gotFormatters.byType[t]; ok {
+		return f, true
+	}
+	for _, it := range gotFormatters.intfs {
+		if t.Implements(it) {
+			return gotFormatters.byType[it], true
+		}
+	}
+	return nil, false
+}
+
 type anyMatcher struct{}
 
 func (anyMatcher) Matches(any) bool {
@@ -99,199 +180,2123 @@ func (anyMatcher) String() string {
 	return "is anything"
 }
 
-type eqMatcher struct {
-	x any
+type anyTMatcher[T any] struct{}
+
+func (anyTMatcher[T]) Matches(x any) bool {
+	return isOfType[T](x)
+}
+
+func (anyTMatcher[T]) String() string {
+	return fmt.Sprintf("is a %v", typeOf[T]())
+}
+
+// Got reports values of the wrong type.
+func (anyTMatcher[T]) Got(x any) string {
+	return formatTypeMismatch[T](x)
+}
+
+type eqTMatcher[T any] struct {
+	want T
+}
+
+func (m eqTMatcher[T]) Matches(x any) bool {
+	return isOfType[T](x) && eqMatcher{m.want}.Matches(x)
+}
+
+func (m eqTMatcher[T]) String() string {
+	return fmt.Sprintf("is equal to %v (%v)", m.want, typeOf[T]())
+}
+
+// Got reports values of the wrong type.
+func (m eqTMatcher[T]) Got(x any) string {
+	return formatTypeMismatch[T](x)
+}
+
+// typeOf returns the type T, which may be an interface type.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// isOfType returns whether x is a T, nil being the zero value of interface
+// types.
+func isOfType[T any](x any) bool {
+	if _, ok := x.(T); ok {
+		return true
+	}
+	return x == nil && typeOf[T]().Kind() == reflect.Interface
+}
+
+// formatTypeMismatch formats x, noting if it is not a T.
+func formatTypeMismatch[T any](x any) string {
+	if isOfType[T](x) {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	return fmt.Sprintf("%v (%T), not of type %v", x, x, typeOf[T]())
+}
+
+type anyContextMatcher struct{}
+
+func (anyContextMatcher) Matches(x any) bool {
+	_, ok := x.(context.Context)
+	return ok
+}
+
+func (anyContextMatcher) String() string {
+	return "is a context.Context"
+}
+
+type contextValueMatcher struct {
+	key any
+	val Matcher
+}
+
+func (m contextValueMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+	return m.val.Matches(ctx.Value(m.key))
+}
+
+func (m contextValueMatcher) String() string {
+	return fmt.Sprintf("is a context.Context with value for %#v: %v", m.key, m.val)
+}
+
+// Got formats the value of the context for the key.
+func (m contextValueMatcher) Got(x any) string {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	return fmt.Sprintf("context.Context with value for %#v: %v", m.key, formatGottenArg(m.val, ctx.Value(m.key)))
+}
+
+type contextDeadlineMatcher struct{}
+
+func (contextDeadlineMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+	_, ok = ctx.Deadline()
+	return ok
+}
+
+func (contextDeadlineMatcher) String() string {
+	return "is a context.Context with a deadline"
+}
+
+type contextNotCancelledMatcher struct{}
+
+func (contextNotCancelledMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	return ok && ctx.Err() == nil
+}
+
+func (contextNotCancelledMatcher) String() string {
+	return "is a context.Context not cancelled"
+}
+
+// Got formats the error of the context, if it is done.
+func (contextNotCancelledMatcher) Got(x any) string {
+	ctx, ok := x.(context.Context)
+	if !ok || ctx.Err() == nil {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	return fmt.Sprintf("context.Context done with %v", ctx.Err())
+}
+
+// ContextMatcher is a matcher for contexts satisfying a chain of conditions,
+// built with ContextChain. Each of its methods returns a matcher with one
+// more condition.
+type ContextMatcher struct {
+	conds []contextCond
+}
+
+type contextCond struct {
+	desc    string
+	matches func(ctx context.Context) bool
+	got     func(ctx context.Context) string // the mismatch, if any
+}
+
+// WithValue adds the condition that the context carries a value for key
+// matching val, which is matched by equality if it is not a Matcher.
+func (m ContextMatcher) WithValue(key, val any) ContextMatcher {
+	vm := toMatcher(val)
+	return m.with(contextCond{
+		desc:    fmt.Sprintf("with value for %#v: %v", key, vm),
+		matches: func(ctx context.Context) bool { return vm.Matches(ctx.Value(key)) },
+		got: func(ctx context.Context) string {
+			return fmt.Sprintf("value for %#v: %v", key, formatGottenArg(vm, ctx.Value(key)))
+		},
+	})
+}
+
+// WithDeadline adds the condition that the context has a deadline.
+func (m ContextMatcher) WithDeadline() ContextMatcher {
+	return m.with(contextCond{
+		desc: "with a deadline",
+		matches: func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		},
+		got: formatDeadline,
+	})
+}
+
+// WithDeadlineWithin adds the condition that the context has a deadline at
+// most d from the time of the call.
+func (m ContextMatcher) WithDeadlineWithin(d time.Duration) ContextMatcher {
+	return m.with(contextCond{
+		desc: fmt.Sprintf("with a deadline within %v", d),
+		matches: func(ctx context.Context) bool {
+			deadline, ok := ctx.Deadline()
+			return ok && time.Until(deadline) <= d
+		},
+		got: formatDeadline,
+	})
+}
+
+// NotCancelled adds the condition that the context is not done when the
+// mock is called, neither cancelled nor past its deadline.
+func (m ContextMatcher) NotCancelled() ContextMatcher {
+	return m.with(contextCond{
+		desc:    "not cancelled",
+		matches: func(ctx context.Context) bool { return ctx.Err() == nil },
+		got: func(ctx context.Context) string {
+			return fmt.Sprintf("done with %v", ctx.Err())
+		},
+	})
+}
+
+func (m ContextMatcher) with(c contextCond) ContextMatcher {
+	conds := make([]contextCond, len(m.conds), len(m.conds)+1)
+	copy(conds, m.conds)
+	return ContextMatcher{conds: append(conds, c)}
+}
+
+func (m ContextMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+	for _, c := range m.conds {
+		if !c.matches(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m ContextMatcher) String() string {
+	descs := make([]string, len(m.conds))
+	for i, c := range m.conds {
+		descs[i] = c.desc
+	}
+	if len(descs) == 0 {
+		return "is a context.Context"
+	}
+	return "is a context.Context " + strings.Join(descs, ", ")
+}
+
+// Got formats the conditions the context does not satisfy.
+func (m ContextMatcher) Got(x any) string {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	var mismatches []string
+	for _, c := range m.conds {
+		if !c.matches(ctx) {
+			mismatches = append(mismatches, c.got(ctx))
+		}
+	}
+	if len(mismatches) == 0 {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	return "context.Context with " + strings.Join(mismatches, ", ")
+}
+
+// formatDeadline formats the deadline of ctx relative to the current time.
+func formatDeadline(ctx context.Context) string {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return "no deadline"
+	}
+	return fmt.Sprintf("a deadline in %v", time.Until(deadline).Round(time.Millisecond))
+}
+
+type funcMatcher struct {
+	f    func(x any) bool
+	desc string
+}
+
+func (m funcMatcher) Matches(x any) bool {
+	return m.f(x)
+}
+
+func (m funcMatcher) String() string {
+	return m.desc
+}
+
+type condMatcher[T any] struct {
+	desc string
+	fn   func(T) bool
+}
+
+func (m condMatcher[T]) Matches(x any) bool {
+	if !isOfType[T](x) {
+		return false
+	}
+	v, _ := x.(T)
+	return m.fn(v)
+}
+
+func (m condMatcher[T]) String() string {
+	return fmt.Sprintf("%s (%v)", m.desc, typeOf[T]())
+}
+
+// Got reports values of the wrong type, and the values the condition
+// rejects.
+func (m condMatcher[T]) Got(x any) string {
+	if isOfType[T](x) && !m.Matches(x) {
+		return fmt.Sprintf("%v (%T), which does not satisfy: %s", x, x, m.desc)
+	}
+	return formatTypeMismatch[T](x)
+}
+
+type tagsMatcher struct {
+	typ    reflect.Type // the type of the expected value
+	fields fieldsMatcher
+}
+
+func (m tagsMatcher) Matches(x any) bool {
+	return reflect.TypeOf(x) == m.typ && m.fields.Matches(x)
+}
+
+func (m tagsMatcher) String() string {
+	return fmt.Sprintf("is a %v that %v", m.typ, m.fields)
+}
+
+// Got formats the fields compared by the matcher, for values of the expected
+// type.
+func (m tagsMatcher) Got(x any) string {
+	if reflect.TypeOf(x) != m.typ {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	return m.fields.Got(x)
+}
+
+// tagMatcher returns the matcher for the field f of value v given by its mock
+// tag, or false if the field is ignored.
+func tagMatcher(f reflect.StructField, v reflect.Value) (Matcher, bool) {
+	tag, ok := f.Tag.Lookup("mock")
+	switch {
+	case !ok || tag == "":
+		return Eq(v.Interface()), true
+	case tag == "ignore":
+		return nil, false
+	case tag == "nonzero":
+		return MatcherFunc(func(x any) bool {
+			v := reflect.ValueOf(x)
+			return v.IsValid() && !v.IsZero()
+		}, "is not zero"), true
+	case strings.HasPrefix(tag, "regex="):
+		return Regex(strings.TrimPrefix(tag, "regex=")), true
+	}
+	panic(fmt.Sprintf("gomock: ByTags: invalid mock tag %q on field %s of %v", tag, f.Name, v.Type()))
+}
+
+type likeMatcher struct {
+	partial  reflect.Value // a struct
+	pointer  bool          // whether matched values are pointers to structs
+	required map[string]bool
+}
+
+func (m likeMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if m.pointer {
+		if v.Kind() != reflect.Ptr || v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Type() != m.partial.Type() {
+		return false
+	}
+	for _, i := range m.fields() {
+		if !reflect.DeepEqual(m.partial.Field(i).Interface(), v.Field(i).Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+// fields returns the indices of the fields compared by the matcher.
+func (m likeMatcher) fields() []int {
+	var fields []int
+	t := m.partial.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.IsExported() && (m.required[f.Name] || !m.partial.Field(i).IsZero()) {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}
+
+func (m likeMatcher) String() string {
+	fields := m.fields()
+	ss := make([]string, len(fields))
+	for i, f := range fields {
+		ss[i] = fmt.Sprintf("%s: %#v", m.partial.Type().Field(f).Name, m.partial.Field(f).Interface())
+	}
+	return fmt.Sprintf("is like %v{%s}", m.partial.Type(), strings.Join(ss, ", "))
+}
+
+type fieldsMatcher struct {
+	paths    []string // sorted
+	matchers map[string]Matcher
+}
+
+func (m fieldsMatcher) Matches(x any) bool {
+	for _, path := range m.paths {
+		v, ok := fieldByPath(x, path)
+		if !ok || !m.matchers[path].Matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m fieldsMatcher) String() string {
+	ss := make([]string, len(m.paths))
+	for i, path := range m.paths {
+		ss[i] = fmt.Sprintf("%s: %v", path, m.matchers[path])
+	}
+	return fmt.Sprintf("has fields {%s}", strings.Join(ss, ", "))
+}
+
+// Got formats the fields compared by the matcher, rather than the whole value.
+func (m fieldsMatcher) Got(x any) string {
+	ss := make([]string, len(m.paths))
+	for i, path := range m.paths {
+		if v, ok := fieldByPath(x, path); ok {
+			ss[i] = fmt.Sprintf("%s: %#v", path, v)
+		} else {
+			ss[i] = fmt.Sprintf("%s: <missing>", path)
+		}
+	}
+	return fmt.Sprintf("%T{%s}", x, strings.Join(ss, ", "))
+}
+
+// fieldByPath returns the value of the exported field of x named by path, a
+// dot-separated list of field names, following pointers to structs. It
+// returns false if a field does not exist or a pointer on the path is nil.
+func fieldByPath(x any, path string) (any, bool) {
+	v := reflect.ValueOf(x)
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, false
+		}
+		f, ok := v.Type().FieldByName(name)
+		if !ok || !f.IsExported() {
+			return nil, false
+		}
+		v, ok = fieldByIndex(v, f.Index)
+		if !ok {
+			return nil, false
+		}
+	}
+	return v.Interface(), true
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but returns false instead
+// of panicking on nil pointers to embedded structs.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+type mapContainingMatcher[K comparable, V any] struct {
+	keys []K // sorted by their formatting
+	want map[K]Matcher
+}
+
+func (m mapContainingMatcher[K, V]) Matches(x any) bool {
+	missing, mismatched, ok := m.compare(x)
+	return ok && len(missing) == 0 && len(mismatched) == 0
+}
+
+func (m mapContainingMatcher[K, V]) String() string {
+	ss := make([]string, len(m.keys))
+	for i, k := range m.keys {
+		ss[i] = fmt.Sprintf("%v: %v", k, m.want[k])
+	}
+	return fmt.Sprintf("contains {%s}", strings.Join(ss, ", "))
+}
+
+// Got formats the map, followed by its missing and mismatched keys.
+func (m mapContainingMatcher[K, V]) Got(x any) string {
+	got := fmt.Sprintf("%v (%T)", x, x)
+	missing, mismatched, ok := m.compare(x)
+	if !ok {
+		return got
+	}
+	if len(missing) > 0 {
+		got += fmt.Sprintf("\nmissing keys: %v", strings.Join(missing, ", "))
+	}
+	if len(mismatched) > 0 {
+		got += fmt.Sprintf("\nmismatched keys: %v", strings.Join(mismatched, ", "))
+	}
+	return got
+}
+
+// compare returns the expected keys missing from x and those whose values do
+// not match, or false if x is not a map.
+func (m mapContainingMatcher[K, V]) compare(x any) (missing, mismatched []string, ok bool) {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Map {
+		return nil, nil, false
+	}
+	keyType := v.Type().Key()
+	for _, k := range m.keys {
+		var got reflect.Value
+		key := reflect.ValueOf(any(k))
+		if !key.IsValid() && keyType.Kind() == reflect.Interface {
+			key = reflect.Zero(keyType)
+		}
+		if key.IsValid() && key.Type().AssignableTo(keyType) {
+			got = v.MapIndex(key)
+		}
+		switch {
+		case !got.IsValid():
+			missing = append(missing, fmt.Sprintf("%v", k))
+		case !m.want[k].Matches(got.Interface()):
+			mismatched = append(mismatched, fmt.Sprintf("%v: got %v, want %v", k, formatGottenArg(m.want[k], got.Interface()), m.want[k]))
+		}
+	}
+	return missing, mismatched, true
+}
+
+type sortedByMatcher[T any] struct {
+	less     func(a, b T) bool
+	expected []T // nil if only the order is checked
+}
+
+func (m sortedByMatcher[T]) Matches(x any) bool {
+	s, ok := x.([]T)
+	if !ok || !sort.SliceIsSorted(s, func(i, j int) bool { return m.less(s[i], s[j]) }) {
+		return false
+	}
+	return m.expected == nil || InAnyOrder(m.expected).Matches(s)
+}
+
+func (m sortedByMatcher[T]) String() string {
+	if m.expected == nil {
+		return "is sorted"
+	}
+	return fmt.Sprintf("is sorted and has the elements of %v in any order", m.expected)
+}
+
+type errorContainsMatcher struct {
+	substr string
+}
+
+func (m errorContainsMatcher) Matches(x any) bool {
+	err, ok := x.(error)
+	return ok && err != nil && strings.Contains(err.Error(), m.substr)
+}
+
+func (m errorContainsMatcher) String() string {
+	return fmt.Sprintf("is an error containing %q", m.substr)
+}
+
+type errorMatchesMatcher struct {
+	re *regexp.Regexp
+}
+
+func (m errorMatchesMatcher) Matches(x any) bool {
+	err, ok := x.(error)
+	return ok && err != nil && m.re.MatchString(err.Error())
+}
+
+func (m errorMatchesMatcher) String() string {
+	return fmt.Sprintf("is an error matching %q", m.re)
+}
+
+type regexMatcher struct {
+	re *regexp.Regexp
+}
+
+func (m regexMatcher) Matches(x any) bool {
+	switch x := x.(type) {
+	case string:
+		return m.re.MatchString(x)
+	case fmt.Stringer:
+		if v := reflect.ValueOf(x); v.Kind() == reflect.Ptr && v.IsNil() {
+			return false
+		}
+		return m.re.MatchString(x.String())
+	default:
+		return false
+	}
+}
+
+func (m regexMatcher) String() string {
+	return fmt.Sprintf("matches regexp %q", m.re)
+}
+
+type stringMatcher struct {
+	desc  string // such as "has prefix"
+	want  string
+	match func(s, want string) bool
+}
+
+func (m stringMatcher) Matches(x any) bool {
+	s, ok := stringOrBytes(x)
+	return ok && m.match(s, m.want)
+}
+
+func (m stringMatcher) String() string {
+	return fmt.Sprintf("%s %q", m.desc, m.want)
+}
+
+// stringOrBytes returns the contents of x if it is a string or a []byte,
+// including of named types.
+func stringOrBytes(x any) (string, bool) {
+	v := reflect.ValueOf(x)
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String(), true
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return string(v.Bytes()), true
+	}
+	return "", false
+}
+
+type approxMatcher struct {
+	want, epsilon float64
+}
+
+func (m approxMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	var f float64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = v.Float()
+	default:
+		return false
+	}
+	return math.Abs(f-m.want) <= m.epsilon
+}
+
+func (m approxMatcher) String() string {
+	return fmt.Sprintf("is within %v of %v", m.epsilon, m.want)
+}
+
+// number is the set of the numeric types.
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+type approxTMatcher[T number] struct {
+	want, epsilon T
+}
+
+func (m approxTMatcher[T]) Matches(x any) bool {
+	v, ok := x.(T)
+	if !ok {
+		return false
+	}
+	// The difference is computed in the order that cannot underflow
+	// unsigned types.
+	if v >= m.want {
+		return v-m.want <= m.epsilon
+	}
+	return m.want-v <= m.epsilon
+}
+
+func (m approxTMatcher[T]) String() string {
+	return fmt.Sprintf("is within %v of %v (%T)", m.epsilon, m.want, m.want)
+}
+
+type cidrMatcher struct {
+	prefix netip.Prefix
+}
+
+func (m cidrMatcher) Matches(x any) bool {
+	var addr netip.Addr
+	switch x := x.(type) {
+	case netip.Addr:
+		addr = x
+	case net.IP:
+		var ok bool
+		if addr, ok = netip.AddrFromSlice(x); !ok {
+			return false
+		}
+	default:
+		return false
+	}
+	return m.prefix.Contains(addr.Unmap())
+}
+
+func (m cidrMatcher) String() string {
+	return fmt.Sprintf("is an IP address in %v", m.prefix)
+}
+
+type timeMatcher struct {
+	t     time.Time
+	after bool // whether matched times are after t, rather than before
+}
+
+func (m timeMatcher) Matches(x any) bool {
+	t, ok := x.(time.Time)
+	if !ok {
+		return false
+	}
+	if m.after {
+		return t.After(m.t)
+	}
+	return t.Before(m.t)
+}
+
+func (m timeMatcher) String() string {
+	if m.after {
+		return fmt.Sprintf("is a time after %v", m.t)
+	}
+	return fmt.Sprintf("is a time before %v", m.t)
+}
+
+type withinDurationMatcher struct {
+	want  time.Time
+	delta time.Duration
+}
+
+func (m withinDurationMatcher) Matches(x any) bool {
+	t, ok := x.(time.Time)
+	if !ok {
+		return false
+	}
+	// Sub uses the monotonic clock readings when both times have one, so
+	// that changes of the wall clock do not matter.
+	d := t.Sub(m.want)
+	return -m.delta <= d && d <= m.delta
+}
+
+func (m withinDurationMatcher) String() string {
+	return fmt.Sprintf("is a time within %v of %v", m.delta, m.want)
+}
+
+// Got formats the time, followed by its offset from the expected time.
+func (m withinDurationMatcher) Got(x any) string {
+	t, ok := x.(time.Time)
+	if !ok {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	return fmt.Sprintf("%v (%v from the expected time)", t, t.Sub(m.want))
+}
+
+type jsonEqMatcher struct {
+	want    any // decoded
+	wantStr string
+}
+
+func (m jsonEqMatcher) Matches(x any) bool {
+	var data []byte
+	switch x := x.(type) {
+	case string:
+		data = []byte(x)
+	case []byte:
+		data = x
+	case json.RawMessage:
+		data = x
+	default:
+		var err error
+		if data, err = json.Marshal(x); err != nil {
+			return false
+		}
+	}
+	var got any
+	if err := json.Unmarshal(data, &got); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(got, m.want)
+}
+
+func (m jsonEqMatcher) String() string {
+	return fmt.Sprintf("is JSON equal to %s", m.wantStr)
+}
+
+type protoEqMatcher struct {
+	want protov2.Message
+}
+
+func (m protoEqMatcher) Matches(x any) bool {
+	got, ok := x.(protov2.Message)
+	return ok && protov2.Equal(m.want, got)
+}
+
+func (m protoEqMatcher) String() string {
+	return "is proto equal to " + protoText(m.want)
+}
+
+// Got formats a message in text format, followed by its differences with
+// the expected message.
+func (m protoEqMatcher) Got(x any) string {
+	got, ok := x.(protov2.Message)
+	if !ok {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	diff := lineDiff(protoLines(m.want), protoLines(got))
+	return fmt.Sprintf("%s\nDiff (-want +got):\n%s", protoText(got), diff)
+}
+
+// protoText formats m in the protobuf text format, on a single line.
+func protoText(m protov2.Message) string {
+	return fmt.Sprintf("%T{%s}", m, strings.TrimSpace(prototext.Format(m)))
+}
+
+// protoLines formats m in the protobuf text format, one field per line.
+func protoLines(m protov2.Message) string {
+	return strings.TrimSpace(prototext.MarshalOptions{Multiline: true}.Format(m))
+}
+
+// lineDiff returns the lines of a and b, those only in a prefixed with "-",
+// those only in b with "+", and the others with " ".
+func lineDiff(a, b string) string {
+	al, bl := strings.Split(a, "\n"), strings.Split(b, "\n")
+	// lcs[i][j] is the length of the longest common subsequence of al[i:]
+	// and bl[j:].
+	lcs := make([][]int, len(al)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bl)+1)
+	}
+	for i := len(al) - 1; i >= 0; i-- {
+		for j := len(bl) - 1; j >= 0; j-- {
+			if al[i] == bl[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(al) || j < len(bl) {
+		switch {
+		case i < len(al) && j < len(bl) && al[i] == bl[j]:
+			sb.WriteString(" " + al[i] + "\n")
+			i, j = i+1, j+1
+		case j == len(bl) || i < len(al) && lcs[i+1][j] >= lcs[i][j+1]:
+			sb.WriteString("-" + al[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+" + bl[j] + "\n")
+			j++
+		}
+	}
+	return sb.String()
+}
+
+type diffEqMatcher struct {
+	want any
+	opts []cmp.Option
+}
+
+func (m diffEqMatcher) Matches(x any) bool {
+	diff, err := m.diff(x)
+	return err == nil && diff == ""
+}
+
+func (m diffEqMatcher) String() string {
+	return fmt.Sprintf("is cmp-equal to a %T", m.want)
+}
+
+// Got formats the differences between the expected value and x, rather than
+// x itself.
+func (m diffEqMatcher) Got(x any) string {
+	diff, err := m.diff(x)
+	if err != nil {
+		return fmt.Sprintf("%T, %v", x, err)
+	}
+	return fmt.Sprintf("%T, diff (-want +got):\n%s", x, diff)
+}
+
+// diff returns the differences between the expected value and x, or an error
+// if cmp cannot compare them, such as structs with unexported fields and no
+// option handling them.
+func (m diffEqMatcher) diff(x any) (diff string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot compare: %v", r)
+		}
+	}()
+	return cmp.Diff(m.want, x, m.opts...), nil
+}
+
+type eqMatcher struct {
+	x any
+}
+
+func (e eqMatcher) Matches(x any) bool {
+	// In case, some value is nil
+	if e.x == nil || x == nil {
+		return reflect.DeepEqual(e.x, x)
+	}
+
+	// Check if types assignable and convert them to common type
+	x1Val := reflect.ValueOf(e.x)
+	x2Val := reflect.ValueOf(x)
+
+	if x1Val.Type().AssignableTo(x2Val.Type()) {
+		if _, isProto := x1Val.Interface().(proto.Message); isProto {
+			return proto.Equal(x1Val.Interface().(proto.Message), x2Val.Interface().(proto.Message))
+		}
+		x1ValConverted := x1Val.Convert(x2Val.Type())
+		return reflect.DeepEqual(x1ValConverted.Interface(), x2Val.Interface())
+	}
+
+	return false
+}
+
+func (e eqMatcher) String() string {
+	return fmt.Sprintf("is equal to %v (%T)", e.x, e.x)
+}
+
+type eqExportedMatcher struct {
+	x any
+}
+
+func (e eqExportedMatcher) Matches(x any) bool {
+	if e.x == nil || x == nil {
+		return e.x == nil && x == nil
+	}
+	v1, v2 := reflect.ValueOf(e.x), reflect.ValueOf(x)
+	if !v1.Type().AssignableTo(v2.Type()) {
+		return false
+	}
+	return exportedEqual(v1.Convert(v2.Type()), v2, make(map[visit]bool))
+}
+
+func (e eqExportedMatcher) String() string {
+	return fmt.Sprintf("is equal in exported fields to %v (%T)", e.x, e.x)
+}
+
+// visit is a pair of pointers of type typ compared by exportedEqual, to stop
+// at cycles.
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+// exportedEqual is like reflect.DeepEqual for values of the same type, but
+// ignores the unexported fields of structs. Structs without exported fields,
+// such as time.Time, are compared as a whole with opaqueEqual.
+func exportedEqual(a, b reflect.Value, visited map[visit]bool) bool {
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		v := visit{a.Pointer(), b.Pointer(), a.Type()}
+		if visited[v] {
+			return true
+		}
+		visited[v] = true
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		exported := false
+		for i := 0; i < a.NumField(); i++ {
+			if !a.Type().Field(i).IsExported() {
+				continue
+			}
+			exported = true
+			if !exportedEqual(a.Field(i), b.Field(i), visited) {
+				return false
+			}
+		}
+		if !exported {
+			return opaqueEqual(a, b)
+		}
+		return true
+	case reflect.Ptr:
+		return exportedEqual(a.Elem(), b.Elem(), visited)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Elem().Type() != b.Elem().Type() {
+			return false
+		}
+		return exportedEqual(a.Elem(), b.Elem(), visited)
+	case reflect.Array, reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !exportedEqual(a.Index(i), b.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() || !exportedEqual(iter.Value(), bv, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	}
+	return false
+}
+
+// opaqueEqual compares structs of the same type as a whole, with their
+// Equal method if they have one, and with reflect.DeepEqual otherwise.
+func opaqueEqual(a, b reflect.Value) bool {
+	if !a.CanInterface() || !b.CanInterface() {
+		return true
+	}
+	if eq := a.MethodByName("Equal"); eq.IsValid() {
+		t := eq.Type()
+		if t.NumIn() == 1 && t.In(0) == a.Type() && t.NumOut() == 1 && t.Out(0).Kind() == reflect.Bool {
+			return eq.Call([]reflect.Value{b})[0].Bool()
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// structuralDiff returns the differences between the expected value and x,
+// one field or element per line, if both are structs, maps, slices or arrays,
+// or pointers to them.
+func (e eqMatcher) structuralDiff(x any) string {
+	if e.x == nil || x == nil {
+		return ""
+	}
+	want, got := reflect.ValueOf(e.x), reflect.ValueOf(x)
+	if !want.Type().AssignableTo(got.Type()) || !isComposite(got.Type()) {
+		return ""
+	}
+	if _, isProto := x.(proto.Message); isProto {
+		return ""
+	}
+	return lineDiff(valueLines(want.Convert(got.Type())), valueLines(got))
+}
+
+// isComposite returns whether t, or the type t points to, is a struct, map,
+// slice or array type.
+func isComposite(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// valueLines formats v with a field or element per line, for lineDiff.
+func valueLines(v reflect.Value) string {
+	p := linePrinter{seen: make(map[uintptr]bool)}
+	p.print("", "", "", v)
+	return strings.Join(p.lines, "\n")
+}
+
+type linePrinter struct {
+	lines []string
+	seen  map[uintptr]bool // pointers being printed, to stop at cycles
+}
+
+// print prints v on lines starting with indent, the first one with prefix
+// after the indent and the last one ending with suffix.
+func (p *linePrinter) print(indent, prefix, suffix string, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			p.lines = append(p.lines, indent+prefix+"nil"+suffix)
+			return
+		}
+		if p.seen[v.Pointer()] {
+			p.lines = append(p.lines, fmt.Sprintf("%s%s<cycle to %v>%s", indent, prefix, v.Type(), suffix))
+			return
+		}
+		p.seen[v.Pointer()] = true
+		defer delete(p.seen, v.Pointer())
+		p.print(indent, prefix+"&", suffix, v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			p.lines = append(p.lines, indent+prefix+"nil"+suffix)
+			return
+		}
+		p.print(indent, prefix, suffix, v.Elem())
+	case reflect.Struct:
+		p.lines = append(p.lines, indent+prefix+v.Type().String()+"{")
+		for i := 0; i < v.NumField(); i++ {
+			p.print(indent+"\t", v.Type().Field(i).Name+": ", ",", v.Field(i))
+		}
+		p.lines = append(p.lines, indent+"}"+suffix)
+	case reflect.Map:
+		if v.IsNil() {
+			p.lines = append(p.lines, indent+prefix+"nil"+suffix)
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return leafString(keys[i]) < leafString(keys[j]) })
+		p.lines = append(p.lines, indent+prefix+v.Type().String()+"{")
+		for _, k := range keys {
+			p.print(indent+"\t", leafString(k)+": ", ",", v.MapIndex(k))
+		}
+		p.lines = append(p.lines, indent+"}"+suffix)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			p.lines = append(p.lines, indent+prefix+"nil"+suffix)
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			p.lines = append(p.lines, indent+prefix+leafString(v)+suffix)
+			return
+		}
+		p.lines = append(p.lines, indent+prefix+v.Type().String()+"{")
+		for i := 0; i < v.Len(); i++ {
+			p.print(indent+"\t", "", ",", v.Index(i))
+		}
+		p.lines = append(p.lines, indent+"}"+suffix)
+	default:
+		p.lines = append(p.lines, indent+prefix+leafString(v)+suffix)
+	}
+}
+
+// leafString formats a value printed on a single line by linePrinter.
+func leafString(v reflect.Value) string {
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+type nilMatcher struct{}
+
+func (nilMatcher) Matches(x any) bool {
+	if x == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+		reflect.Ptr, reflect.Slice:
+		return v.IsNil()
+	}
+
+	return false
+}
+
+func (nilMatcher) String() string {
+	return "is nil"
+}
+
+type notMatcher struct {
+	m Matcher
+}
+
+func (n notMatcher) Matches(x any) bool {
+	return !n.m.Matches(x)
+}
+
+func (n notMatcher) String() string {
+	return "not(" + n.m.String() + ")"
+}
+
+type lazyMatcher struct {
+	f func() Matcher
+}
+
+func (m lazyMatcher) Matches(x any) bool {
+	inner := m.f()
+	return inner != nil && inner.Matches(x)
+}
+
+func (m lazyMatcher) String() string {
+	if inner := m.f(); inner != nil {
+		return inner.String()
+	}
+	return "is matched by a lazy matcher not resolved yet"
+}
+
+// Got formats x as the matcher it resolves to does.
+func (m lazyMatcher) Got(x any) string {
+	if inner := m.f(); inner != nil {
+		return formatGottenArg(inner, x)
+	}
+	return fmt.Sprintf("%v (%T)", x, x)
+}
+
+type pointsToMatcher struct {
+	m Matcher
+}
+
+func (p pointsToMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return false
+	}
+	return p.m.Matches(v.Elem().Interface())
+}
+
+func (p pointsToMatcher) String() string {
+	return "is a pointer to a value that " + p.m.String()
+}
+
+// Got formats the value pointed to.
+func (p pointsToMatcher) Got(x any) string {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	return "pointer to " + formatGottenArg(p.m, v.Elem().Interface())
+}
+
+type sameMatcher struct {
+	x any
+}
+
+func (s sameMatcher) Matches(x any) bool {
+	return sameArg(s.x, x)
+}
+
+func (s sameMatcher) String() string {
+	return fmt.Sprintf("is the same as %s (%T)", formatIdentity(s.x), s.x)
+}
+
+// Got formats the address of references.
+func (s sameMatcher) Got(x any) string {
+	return fmt.Sprintf("%s (%T)", formatIdentity(x), x)
+}
+
+// formatIdentity formats x with its address if x is a non-nil reference.
+func formatIdentity(x any) string {
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Slice, reflect.UnsafePointer:
+		if !v.IsNil() {
+			return fmt.Sprintf("%v at %#x", x, v.Pointer())
+		}
+	}
+	return fmt.Sprintf("%v", x)
+}
+
+type assignableToTypeOfMatcher struct {
+	targetType reflect.Type
+}
+
+func (m assignableToTypeOfMatcher) Matches(x any) bool {
+	return reflect.TypeOf(x).AssignableTo(m.targetType)
+}
+
+func (m assignableToTypeOfMatcher) String() string {
+	return "is assignable to " + m.targetType.Name()
+}
+
+type xorMatcher struct {
+	m1, m2 Matcher
+}
+
+func (m xorMatcher) Matches(x any) bool {
+	return m.m1.Matches(x) != m.m2.Matches(x)
+}
+
+func (m xorMatcher) String() string {
+	return "either " + m.m1.String() + " or " + m.m2.String() + ", but not both"
+}
+
+type noneOfMatcher struct {
+	matchers []Matcher
+}
+
+func (m noneOfMatcher) Matches(x any) bool {
+	for _, matcher := range m.matchers {
+		if matcher.Matches(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m noneOfMatcher) String() string {
+	ss := make([]string, 0, len(m.matchers))
+	for _, matcher := range m.matchers {
+		ss = append(ss, matcher.String())
+	}
+	return "none of: " + strings.Join(ss, "; ")
+}
+
+type atLeastMatcher struct {
+	n        int
+	matchers []Matcher
+}
+
+func (m atLeastMatcher) Matches(x any) bool {
+	var n int
+	for _, matcher := range m.matchers {
+		if n >= m.n {
+			break
+		}
+		if matcher.Matches(x) {
+			n++
+		}
+	}
+	return n >= m.n
+}
+
+func (m atLeastMatcher) String() string {
+	ss := make([]string, 0, len(m.matchers))
+	for _, matcher := range m.matchers {
+		ss = append(ss, matcher.String())
+	}
+	return fmt.Sprintf("at least %d of: %s", m.n, strings.Join(ss, "; "))
+}
+
+type implementsMatcher[I any] struct{}
+
+func (implementsMatcher[I]) Matches(x any) bool {
+	_, ok := x.(I)
+	return ok
+}
+
+func (implementsMatcher[I]) String() string {
+	return fmt.Sprintf("implements %v", typeOf[I]())
+}
+
+type allMatcher struct {
+	matchers []Matcher
+}
+
+func (am allMatcher) Matches(x any) bool {
+	for _, m := range am.matchers {
+		if !m.Matches(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func (am allMatcher) String() string {
+	ss := make([]string, 0, len(am.matchers))
+	for _, matcher := range am.matchers {
+		ss = append(ss, matcher.String())
+	}
+	return strings.Join(ss, "; ")
+}
+
+type lenMatcher struct {
+	i int
+}
+
+func (m lenMatcher) Matches(x any) bool {
+	n, ok := lengthOf(x)
+	return ok && n == m.i
+}
+
+func (m lenMatcher) String() string {
+	return fmt.Sprintf("has length %d", m.i)
+}
+
+// Got reports the length of x.
+func (m lenMatcher) Got(x any) string {
+	return formatLen(x)
+}
+
+type lenRangeMatcher struct {
+	min, max int // max is negative if unbounded
+}
+
+func (m lenRangeMatcher) Matches(x any) bool {
+	n, ok := lengthOf(x)
+	return ok && n >= m.min && (m.max < 0 || n <= m.max)
+}
+
+func (m lenRangeMatcher) String() string {
+	switch {
+	case m.min == 0 && m.max == 0:
+		return "is empty"
+	case m.min == 1 && m.max < 0:
+		return "is not empty"
+	}
+	return fmt.Sprintf("has length between %d and %d", m.min, m.max)
+}
+
+// Got reports the length of x.
+func (m lenRangeMatcher) Got(x any) string {
+	return formatLen(x)
+}
+
+type zeroMatcher struct{}
+
+func (zeroMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	return !v.IsValid() || v.IsZero()
+}
+
+func (zeroMatcher) String() string {
+	return "is the zero value of its type"
+}
+
+// Got lists the fields that are not zero of structs.
+func (zeroMatcher) Got(x any) string {
+	got := fmt.Sprintf("%v (%T)", x, x)
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Struct {
+		return got
+	}
+	var fields []string
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsZero() {
+			fields = append(fields, v.Type().Field(i).Name)
+		}
+	}
+	return fmt.Sprintf("%s, with non-zero fields %s", got, strings.Join(fields, ", "))
+}
+
+// lengthOf returns the length of x, if x is an array, chan, map, slice, or
+// string.
+func lengthOf(x any) (int, bool) {
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+// formatLen formats x along with its length.
+func formatLen(x any) string {
+	if n, ok := lengthOf(x); ok {
+		return fmt.Sprintf("%v (%T) of length %d", x, x, n)
+	}
+	return fmt.Sprintf("%v (%T), which has no length", x, x)
+}
+
+type inAnyOrderMatcher struct {
+	x any
+}
+
+func (m inAnyOrderMatcher) Matches(x any) bool {
+	given, ok := m.prepareValue(x)
+	if !ok {
+		return false
+	}
+	wanted, ok := m.prepareValue(m.x)
+	if !ok {
+		return false
+	}
+
+	missing, extra := matchElements(eqMatchers(wanted), given)
+	return len(missing) == 0 && len(extra) == 0
+}
+
+// Got formats the collection, followed by the elements missing from it and
+// those it has in excess.
+func (m inAnyOrderMatcher) Got(x any) string {
+	given, ok := m.prepareValue(x)
+	wanted, wok := m.prepareValue(m.x)
+	if !ok || !wok {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	elems := eqMatchers(wanted)
+	missing, extra := matchElements(elems, given)
+	return formatElementsDiff(x, elems, given, missing, extra)
+}
+
+// eqMatchers returns an Eq matcher for each element of v.
+func eqMatchers(v reflect.Value) []Matcher {
+	ms := make([]Matcher, v.Len())
+	for i := range ms {
+		ms[i] = Eq(v.Index(i).Interface())
+	}
+	return ms
+}
+
+func (m inAnyOrderMatcher) prepareValue(x any) (reflect.Value, bool) {
+	xValue := reflect.ValueOf(x)
+	switch xValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		return xValue, true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+func (m inAnyOrderMatcher) String() string {
+	return fmt.Sprintf("has the same elements as %v", m.x)
+}
+
+type elementsMatchMatcher struct {
+	elems []Matcher
+}
+
+func (m elementsMatchMatcher) Matches(x any) bool {
+	given := reflect.ValueOf(x)
+	if given.Kind() != reflect.Slice && given.Kind() != reflect.Array {
+		return false
+	}
+	missing, extra := matchElements(m.elems, given)
+	return len(missing) == 0 && len(extra) == 0
+}
+
+func (m elementsMatchMatcher) String() string {
+	ss := make([]string, len(m.elems))
+	for i, e := range m.elems {
+		ss[i] = e.String()
+	}
+	return fmt.Sprintf("has elements matching [%s] in any order", strings.Join(ss, ", "))
+}
+
+// Got formats the collection, followed by the elements missing from it and
+// those it has in excess.
+func (m elementsMatchMatcher) Got(x any) string {
+	given := reflect.ValueOf(x)
+	if given.Kind() != reflect.Slice && given.Kind() != reflect.Array {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	missing, extra := matchElements(m.elems, given)
+	return formatElementsDiff(x, m.elems, given, missing, extra)
+}
+
+// matchElements pairs each matcher of wanted with a distinct element of
+// given, a slice or an array, such that as many matchers as possible are
+// paired. It returns the indices of the matchers left without an element and
+// of the elements left without a matcher.
+func matchElements(wanted []Matcher, given reflect.Value) (missing, extra []int) {
+	n := given.Len()
+	matches := make([][]bool, len(wanted))
+	for i, m := range wanted {
+		matches[i] = make([]bool, n)
+		for j := 0; j < n; j++ {
+			matches[i][j] = m.Matches(given.Index(j).Interface())
+		}
+	}
+
+	// pairedWith[j] is the index of the matcher paired with element j, or
+	// -1. Pairs are found along augmenting paths, so that a matcher taking
+	// an element another one needs does not prevent a complete pairing.
+	pairedWith := make([]int, n)
+	for j := range pairedWith {
+		pairedWith[j] = -1
+	}
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for j := 0; j < n; j++ {
+			if !matches[i][j] || seen[j] {
+				continue
+			}
+			seen[j] = true
+			if pairedWith[j] < 0 || augment(pairedWith[j], seen) {
+				pairedWith[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range wanted {
+		if !augment(i, make([]bool, n)) {
+			missing = append(missing, i)
+		}
+	}
+	for j, i := range pairedWith {
+		if i < 0 {
+			extra = append(extra, j)
+		}
+	}
+	return missing, extra
+}
+
+// formatElementsDiff formats x, followed by the matchers of wanted missing an
+// element and the extra elements of given.
+func formatElementsDiff(x any, wanted []Matcher, given reflect.Value, missing, extra []int) string {
+	got := fmt.Sprintf("%v (%T)", x, x)
+	if len(missing) > 0 {
+		ss := make([]string, len(missing))
+		for k, i := range missing {
+			ss[k] = wanted[i].String()
+		}
+		got += fmt.Sprintf("\nmissing: %s", strings.Join(ss, "; "))
+	}
+	if len(extra) > 0 {
+		ss := make([]string, len(extra))
+		for k, j := range extra {
+			ss[k] = fmt.Sprintf("%v", given.Index(j).Interface())
+		}
+		got += fmt.Sprintf("\nextra: %s", strings.Join(ss, ", "))
+	}
+	return got
+}
+
+// Constructors
+
+// All returns a composite Matcher that returns true if and only all of the
+// matchers return true.
+func All(ms ...Matcher) Matcher { return allMatcher{ms} }
+
+// Any returns a matcher that always matches.
+func Any() Matcher { return anyMatcher{} }
+
+// AnyT returns a matcher for any value of type T. Unlike Any, it rejects
+// arguments of other types, such as an int64 given to a recorder for an int
+// parameter, and names the type in the failure message. Values of interface
+// types T match if they implement T, as does nil.
+//
+// Example usage:
+//
+//	AnyT[int]().Matches(4) // returns true
+//	AnyT[int]().Matches(int64(4)) // returns false
+//	AnyT[io.Reader]().Matches(os.Stdin) // returns true
+func AnyT[T any]() Matcher { return anyTMatcher[T]{} }
+
+// AnyContext returns a matcher that matches any context.Context. Unlike Any,
+// it does not match values of other types, such as an argument passed in the
+// wrong position, nor a nil context.
+//
+// Example usage:
+//
+//	AnyContext().Matches(context.Background()) // returns true
+//	AnyContext().Matches("ctx") // returns false
+func AnyContext() Matcher { return anyContextMatcher{} }
+
+// ContextWithValue returns a matcher for contexts carrying a value for key
+// matching val, which is matched by equality if it is not a Matcher. It
+// checks that values set by the caller are propagated to the mock.
+//
+// Example usage:
+//
+//	ctx := context.WithValue(context.Background(), requestIDKey{}, "42")
+//	ContextWithValue(requestIDKey{}, "42").Matches(ctx) // returns true
+//	ContextWithValue(requestIDKey{}, "42").Matches(context.Background()) // returns false
+func ContextWithValue(key, val any) Matcher {
+	return contextValueMatcher{key: key, val: toMatcher(val)}
+}
+
+// ContextWithDeadline returns a matcher for contexts with a deadline, such as
+// the contexts created by context.WithTimeout.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	ContextWithDeadline().Matches(ctx) // returns true
+//	ContextWithDeadline().Matches(context.Background()) // returns false
+func ContextWithDeadline() Matcher { return contextDeadlineMatcher{} }
+
+// ContextNotCancelled returns a matcher for contexts that are not done when
+// the mock is called, neither cancelled nor past their deadline.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	ContextNotCancelled().Matches(ctx) // returns true
+//	cancel()
+//	ContextNotCancelled().Matches(ctx) // returns false
+func ContextNotCancelled() Matcher { return contextNotCancelledMatcher{} }
+
+// ContextChain returns a matcher for contexts, to which conditions are added
+// by chaining its methods, so that the propagation of a context is checked by
+// a single readable matcher rather than by composing the context matchers
+// with All.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), requestIDKey{}, "42"), time.Second)
+//	defer cancel()
+//	ContextChain().WithValue(requestIDKey{}, "42").WithDeadlineWithin(time.Second).Matches(ctx) // returns true
+//	ContextChain().WithValue(requestIDKey{}, "42").Matches(context.Background()) // returns false
+func ContextChain() ContextMatcher { return ContextMatcher{} }
+
+// Eq returns a matcher that matches on equality.
+//
+// Example usage:
+//
+//	Eq(5).Matches(5) // returns true
+//	Eq(5).Matches(4) // returns false
+func Eq(x any) Matcher { return eqMatcher{x} }
+
+// EqT is like Eq for values of type T, the type of want. Unlike Eq, which
+// converts the values it compares to the same type, EqT rejects arguments of
+// other types, and its failure messages name the type expected:
+//
+//	Got: 5 (int64), not of type int
+//
+// Example usage:
+//
+//	EqT(5).Matches(5) // returns true
+//	EqT(5).Matches(int64(5)) // returns false
+//	EqT[time.Duration](5).Matches(time.Duration(5)) // returns true
+func EqT[T any](want T) Matcher { return eqTMatcher[T]{want: want} }
+
+// EqExported is like Eq, but only compares the exported fields of structs,
+// recursively, ignoring unexported ones such as mutexes, caches and
+// sync.Once values, which make values that are otherwise equal differ.
+// Structs without exported fields, such as time.Time, are compared as a whole,
+// with their Equal method if they have one.
+//
+// Example usage:
+//
+//	type Store struct {
+//	  Name  string
+//	  mu    sync.Mutex
+//	  cache map[string]string
+//	}
+//	EqExported(&Store{Name: "a"}).Matches(&Store{Name: "a", cache: c}) // returns true
+//	EqExported(&Store{Name: "a"}).Matches(&Store{Name: "b"}) // returns false
+func EqExported(want any) Matcher { return eqExportedMatcher{want} }
+
+// MatcherFunc returns a matcher that matches the values for which f returns
+// true, and is described by desc in failure messages.
+//
+// Example usage:
+//
+//	MatcherFunc(func(x any) bool { s, ok := x.(string); return ok && s != "" }, "is a non-empty string")
+func MatcherFunc(f func(x any) bool, desc string) Matcher {
+	return funcMatcher{f: f, desc: desc}
+}
+
+// MatcherFuncT is like MatcherFunc for a function of the type of the values
+// to match. It does not match values of other types. A nil value is passed to
+// f as the zero value of T when T is an interface type.
+//
+// Example usage:
+//
+//	MatcherFuncT(func(n int) bool { return n%2 == 0 }, "is even").Matches(4) // returns true
+//	MatcherFuncT(func(n int) bool { return n%2 == 0 }, "is even").Matches("4") // returns false
+func MatcherFuncT[T any](f func(x T) bool, desc string) Matcher {
+	return funcMatcher{f: func(x any) bool {
+		v, ok := x.(T)
+		if !ok {
+			if x != nil || reflect.TypeOf((*T)(nil)).Elem().Kind() != reflect.Interface {
+				return false
+			}
+		}
+		return f(v)
+	}, desc: desc}
+}
+
+// CondT returns a matcher for the values of type T satisfying fn, described
+// by desc. Unlike MatcherFuncT, failure messages name the type and show the
+// value fn rejected along with desc. A nil value is passed to fn as the zero
+// value of T when T is an interface type.
+//
+// Example usage:
+//
+//	isEven := CondT("is even", func(n int) bool { return n%2 == 0 })
+//	isEven.Matches(4) // returns true
+//	isEven.Matches(3) // returns false, reported as "3 (int), which does not satisfy: is even"
+//	isEven.Matches("4") // returns false
+func CondT[T any](desc string, fn func(T) bool) Matcher {
+	return condMatcher[T]{desc: desc, fn: fn}
 }
 
-func (e eqMatcher) Matches(x any) bool {
-	// In case, some value is nil
-	if e.x == nil || x == nil {
-		return reflect.DeepEqual(e.x, x)
+// Like returns a matcher for partial, a struct or a pointer to a struct,
+// matching the values of the same type whose exported fields equal the
+// non-zero fields of partial. Zero fields of partial match anything, except
+// those named in required, which must be zero too. Unexported fields are
+// ignored. Like panics if partial is not a struct or a pointer to a struct,
+// or if required names a field it does not have.
+//
+// Example usage:
+//
+//	Like(User{Name: "gopher"}).Matches(User{ID: 1, Name: "gopher"}) // returns true
+//	Like(User{Name: "gopher"}, "Admin").Matches(User{Name: "gopher", Admin: true}) // returns false
+func Like(partial any, required ...string) Matcher {
+	m := likeMatcher{partial: reflect.ValueOf(partial)}
+	if m.partial.Kind() == reflect.Ptr && !m.partial.IsNil() {
+		m.partial, m.pointer = m.partial.Elem(), true
 	}
-
-	// Check if types assignable and convert them to common type
-	x1Val := reflect.ValueOf(e.x)
-	x2Val := reflect.ValueOf(x)
-
-	if x1Val.Type().AssignableTo(x2Val.Type()) {
-		if _, isProto := x1Val.Interface().(proto.Message); isProto {
-			return proto.Equal(x1Val.Interface().(proto.Message), x2Val.Interface().(proto.Message))
+	if m.partial.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gomock: Like requires a struct or a pointer to a struct, got %T", partial))
+	}
+	m.required = make(map[string]bool, len(required))
+	for _, name := range required {
+		if f, ok := m.partial.Type().FieldByName(name); !ok || !f.IsExported() || len(f.Index) != 1 {
+			panic(fmt.Sprintf("gomock: Like: %T has no exported field %q", partial, name))
 		}
-		x1ValConverted := x1Val.Convert(x2Val.Type())
-		return reflect.DeepEqual(x1ValConverted.Interface(), x2Val.Interface())
+		m.required[name] = true
 	}
-
-	return false
+	return m
 }
 
-func (e eqMatcher) String() string {
-	return fmt.Sprintf("is equal to %v (%T)", e.x, e.x)
+// Fields returns a matcher for structs, or pointers to structs, whose exported
+// fields named by the keys of fields match the values, leaving the other
+// fields unconstrained. The keys are field names or dot-separated paths to the
+// fields of nested structs, following pointers. The values are matchers, or
+// values compared with Eq. Fields panics if a key is empty or has an empty
+// element.
+//
+// Example usage:
+//
+//	Fields(map[string]any{"Name": "gopher", "Address.City": Regex("^Z")}).Matches(User{ID: 1, Name: "gopher", Address: &Address{City: "Zurich"}}) // returns true
+//	Fields(map[string]any{"Name": "gopher"}).Matches(User{Name: "rust"}) // returns false
+func Fields(fields map[string]any) Matcher {
+	m := fieldsMatcher{matchers: make(map[string]Matcher, len(fields))}
+	for path, want := range fields {
+		for _, name := range strings.Split(path, ".") {
+			if name == "" {
+				panic(fmt.Sprintf("gomock: Fields: invalid field path %q", path))
+			}
+		}
+		m.paths = append(m.paths, path)
+		m.matchers[path] = toMatcher(want)
+	}
+	sort.Strings(m.paths)
+	return m
 }
 
-type nilMatcher struct{}
-
-func (nilMatcher) Matches(x any) bool {
-	if x == nil {
-		return true
+// ByTags returns a matcher for values of the type of expected, a struct or a
+// pointer to a struct, whose exported fields match according to the mock tags
+// of the fields of the type:
+//
+//   - no tag: the field equals the field of expected, as with Eq;
+//   - mock:"ignore": the field is not compared;
+//   - mock:"nonzero": the field is not the zero value of its type;
+//   - mock:"regex=<pattern>": the field is a string, or a fmt.Stringer,
+//     matching the pattern, as with Regex.
+//
+// It lets a type declare once how its values are compared in expectations.
+// Unexported fields are ignored. ByTags panics if expected is not a struct or
+// a non-nil pointer to a struct, or if a tag or pattern is invalid.
+//
+// Example usage:
+//
+//	type User struct {
+//	  ID        string `mock:"regex=^u-"`
+//	  Name      string
+//	  CreatedAt time.Time `mock:"ignore"`
+//	}
+//	ByTags(User{Name: "gopher"}).Matches(User{ID: "u-1", Name: "gopher", CreatedAt: time.Now()}) // returns true
+//	ByTags(User{Name: "gopher"}).Matches(User{ID: "1", Name: "gopher"}) // returns false
+func ByTags(expected any) Matcher {
+	v := reflect.ValueOf(expected)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gomock: ByTags requires a struct or a pointer to a struct, got %T", expected))
 	}
+	m := tagsMatcher{typ: reflect.TypeOf(expected), fields: fieldsMatcher{matchers: make(map[string]Matcher)}}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if fm, ok := tagMatcher(f, v.Field(i)); ok {
+			m.fields.paths = append(m.fields.paths, f.Name)
+			m.fields.matchers[f.Name] = fm
+		}
+	}
+	sort.Strings(m.fields.paths)
+	return m
+}
 
-	v := reflect.ValueOf(x)
-	switch v.Kind() {
-	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
-		reflect.Ptr, reflect.Slice:
-		return v.IsNil()
+// MapContaining returns a matcher for maps containing at least the keys of
+// want, with values matching those of want, leaving the other keys
+// unconstrained. The values of want are matchers, or values compared with Eq.
+// Mismatches are reported with the keys missing and those whose values do not
+// match.
+//
+// Example usage:
+//
+//	MapContaining(map[string]any{"Accept": "text/plain"}).Matches(map[string]any{"Accept": "text/plain", "Host": "a"}) // returns true
+//	MapContaining(map[string]string{"Accept": "text/plain"}).Matches(map[string]string{"Host": "a"}) // returns false
+func MapContaining[K comparable, V any](want map[K]V) Matcher {
+	m := mapContainingMatcher[K, V]{want: make(map[K]Matcher, len(want))}
+	for k, v := range want {
+		m.keys = append(m.keys, k)
+		m.want[k] = toMatcher(v)
 	}
+	sort.Slice(m.keys, func(i, j int) bool {
+		return fmt.Sprint(m.keys[i]) < fmt.Sprint(m.keys[j])
+	})
+	return m
+}
 
-	return false
+// SortedBy returns a matcher for slices of type []T that are sorted according
+// to less. Unless expected is nil, the slices must also have the elements of
+// expected, in any order.
+//
+// Example usage:
+//
+//	less := func(a, b int) bool { return a < b }
+//	SortedBy(less, []int{3, 1, 2}).Matches([]int{1, 2, 3}) // returns true
+//	SortedBy(less, []int{3, 1, 2}).Matches([]int{3, 2, 1}) // returns false
+//	SortedBy(less, nil).Matches([]int{4, 5}) // returns true
+func SortedBy[T any](less func(a, b T) bool, expected []T) Matcher {
+	return sortedByMatcher[T]{less: less, expected: expected}
 }
 
-func (nilMatcher) String() string {
-	return "is nil"
+// ErrorContains returns a matcher for non-nil errors whose Error text contains
+// substr, for when the error cannot be compared to a sentinel.
+//
+// Example usage:
+//
+//	ErrorContains("not found").Matches(fmt.Errorf("user 1 not found")) // returns true
+//	ErrorContains("not found").Matches("user 1 not found") // returns false
+func ErrorContains(substr string) Matcher {
+	return errorContainsMatcher{substr: substr}
 }
 
-type notMatcher struct {
-	m Matcher
+// ErrorMatches returns a matcher for non-nil errors whose Error text matches
+// the regular expression expr. It panics if expr does not compile.
+//
+// Example usage:
+//
+//	ErrorMatches(`^user \d+ not found$`).Matches(fmt.Errorf("user 1 not found")) // returns true
+//	ErrorMatches(`^user \d+ not found$`).Matches(nil) // returns false
+func ErrorMatches(expr string) Matcher {
+	return errorMatchesMatcher{re: regexp.MustCompile(expr)}
 }
 
-func (n notMatcher) Matches(x any) bool {
-	return !n.m.Matches(x)
+// Regex returns a matcher for strings, and values implementing fmt.Stringer,
+// matching the regular expression pattern. It panics if pattern does not
+// compile.
+//
+// Example usage:
+//
+//	Regex(`^req-\d+$`).Matches("req-42") // returns true
+//	Regex(`^req-\d+$`).Matches("req-x") // returns false
+//	Regex(`^1s$`).Matches(time.Second) // returns true
+func Regex(pattern string) Matcher {
+	return regexMatcher{re: regexp.MustCompile(pattern)}
 }
 
-func (n notMatcher) String() string {
-	return "not(" + n.m.String() + ")"
+// HasPrefix returns a matcher for strings and byte slices beginning with
+// prefix.
+//
+// Example usage:
+//
+//	HasPrefix("req-").Matches("req-42") // returns true
+//	HasPrefix("req-").Matches([]byte("req-42")) // returns true
+//	HasPrefix("req-").Matches("id-42") // returns false
+func HasPrefix(prefix string) Matcher {
+	return stringMatcher{desc: "has prefix", want: prefix, match: strings.HasPrefix}
 }
 
-type assignableToTypeOfMatcher struct {
-	targetType reflect.Type
+// HasSuffix returns a matcher for strings and byte slices ending with suffix.
+//
+// Example usage:
+//
+//	HasSuffix(".json").Matches("user.json") // returns true
+//	HasSuffix(".json").Matches("user.yaml") // returns false
+func HasSuffix(suffix string) Matcher {
+	return stringMatcher{desc: "has suffix", want: suffix, match: strings.HasSuffix}
 }
 
-func (m assignableToTypeOfMatcher) Matches(x any) bool {
-	return reflect.TypeOf(x).AssignableTo(m.targetType)
+// Contains returns a matcher for strings and byte slices containing substr.
+//
+// Example usage:
+//
+//	Contains("gopher").Matches("hello, gopher!") // returns true
+//	Contains("gopher").Matches("hello, world!") // returns false
+func Contains(substr string) Matcher {
+	return stringMatcher{desc: "contains", want: substr, match: strings.Contains}
 }
 
-func (m assignableToTypeOfMatcher) String() string {
-	return "is assignable to " + m.targetType.Name()
+// EqualFold returns a matcher for strings and byte slices equal to want under
+// Unicode case folding.
+//
+// Example usage:
+//
+//	EqualFold("Gopher").Matches("GOPHER") // returns true
+//	EqualFold("Gopher").Matches("gophers") // returns false
+func EqualFold(want string) Matcher {
+	return stringMatcher{desc: "is equal ignoring case to", want: want, match: strings.EqualFold}
 }
 
-type allMatcher struct {
-	matchers []Matcher
+// Approx returns a matcher for numbers within epsilon of want, such as floats
+// computed by the code under test, which are rarely bit-exact. Values of all
+// the numeric types are compared as float64. NaN matches nothing.
+//
+// Example usage:
+//
+//	Approx(0.3, 1e-9).Matches(0.1 + 0.2) // returns true
+//	Approx(10, 0.5).Matches(int64(11)) // returns false
+func Approx(want, epsilon float64) Matcher {
+	return approxMatcher{want: want, epsilon: epsilon}
 }
 
-func (am allMatcher) Matches(x any) bool {
-	for _, m := range am.matchers {
-		if !m.Matches(x) {
-			return false
-		}
-	}
-	return true
+// ApproxT is like Approx for the values of the numeric type T only, compared
+// without conversion.
+//
+// Example usage:
+//
+//	ApproxT[uint8](10, 2).Matches(uint8(12)) // returns true
+//	ApproxT[float32](1, 0.1).Matches(1.0) // returns false, 1.0 is a float64
+func ApproxT[T number](want, epsilon T) Matcher {
+	return approxTMatcher[T]{want: want, epsilon: epsilon}
 }
 
-func (am allMatcher) String() string {
-	ss := make([]string, 0, len(am.matchers))
-	for _, matcher := range am.matchers {
-		ss = append(ss, matcher.String())
-	}
-	return strings.Join(ss, "; ")
+// MatchesRegexp is like Regex for a compiled regular expression.
+//
+// Example usage:
+//
+//	var id = regexp.MustCompile(`^req-\d+$`)
+//	MatchesRegexp(id).Matches("req-1") // returns true
+func MatchesRegexp(re *regexp.Regexp) Matcher {
+	return regexMatcher{re: re}
 }
 
-type lenMatcher struct {
-	i int
+// Satisfies returns a matcher for the values x of type T for which pred(x,
+// arg) returns true, turning a predicate of the standard library or of the
+// code under test into a matcher. It does not match values of other types.
+//
+// Example usage:
+//
+//	Satisfies(strings.Contains, "ed").Matches("seed") // returns true
+//	Satisfies(strings.HasPrefix, "/api/").Matches("/web/") // returns false
+//	Satisfies(errors.Is, fs.ErrNotExist).Matches(err) // returns true if err wraps fs.ErrNotExist
+func Satisfies[T, A any](pred func(T, A) bool, arg A) Matcher {
+	name := runtime.FuncForPC(reflect.ValueOf(pred).Pointer()).Name()
+	return MatcherFuncT(func(x T) bool {
+		return pred(x, arg)
+	}, fmt.Sprintf("satisfies %s(_, %#v)", name, arg))
 }
 
-func (m lenMatcher) Matches(x any) bool {
-	v := reflect.ValueOf(x)
-	switch v.Kind() {
-	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-		return v.Len() == m.i
-	default:
-		return false
+// InCIDR returns a matcher for IP addresses, of type net.IP or netip.Addr, in
+// the network cidr, such as "10.0.0.0/8" or "2001:db8::/32". IPv4 addresses
+// mapped to IPv6 are matched as IPv4 addresses. InCIDR panics if cidr is not
+// valid.
+//
+// Example usage:
+//
+//	InCIDR("10.0.0.0/8").Matches(net.ParseIP("10.1.2.3")) // returns true
+//	InCIDR("10.0.0.0/8").Matches(netip.MustParseAddr("192.168.0.1")) // returns false
+func InCIDR(cidr string) Matcher {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		panic(fmt.Sprintf("gomock: InCIDR: %v", err))
 	}
+	return cidrMatcher{prefix: prefix.Masked()}
 }
 
-func (m lenMatcher) String() string {
-	return fmt.Sprintf("has length %d", m.i)
+// TimeAfter returns a matcher for time.Time values after t.
+//
+// Example usage:
+//
+//	TimeAfter(start).Matches(time.Now()) // returns true
+func TimeAfter(t time.Time) Matcher {
+	return timeMatcher{t: t, after: true}
 }
 
-type inAnyOrderMatcher struct {
-	x any
+// TimeBefore returns a matcher for time.Time values before t.
+//
+// Example usage:
+//
+//	TimeBefore(deadline).Matches(deadline.Add(time.Second)) // returns false
+func TimeBefore(t time.Time) Matcher {
+	return timeMatcher{t: t}
 }
 
-func (m inAnyOrderMatcher) Matches(x any) bool {
-	given, ok := m.prepareValue(x)
-	if !ok {
-		return false
-	}
-	wanted, ok := m.prepareValue(m.x)
-	if !ok {
-		return false
-	}
-
-	if given.Len() != wanted.Len() {
-		return false
-	}
-
-	usedFromGiven := make([]bool, given.Len())
-	foundFromWanted := make([]bool, wanted.Len())
-	for i := 0; i < wanted.Len(); i++ {
-		wantedMatcher := Eq(wanted.Index(i).Interface())
-		for j := 0; j < given.Len(); j++ {
-			if usedFromGiven[j] {
-				continue
-			}
-			if wantedMatcher.Matches(given.Index(j).Interface()) {
-				foundFromWanted[i] = true
-				usedFromGiven[j] = true
-				break
-			}
-		}
-	}
-
-	missingFromWanted := 0
-	for _, found := range foundFromWanted {
-		if !found {
-			missingFromWanted++
-		}
-	}
-	extraInGiven := 0
-	for _, used := range usedFromGiven {
-		if !used {
-			extraInGiven++
-		}
-	}
-
-	return extraInGiven == 0 && missingFromWanted == 0
+// WithinDuration returns a matcher for time.Time values within delta of want,
+// such as timestamps taken by the code under test with time.Now. Times are
+// compared with their monotonic clock readings when both have one, as
+// time.Time.Sub does.
+//
+// Example usage:
+//
+//	WithinDuration(time.Now(), time.Second).Matches(time.Now()) // returns true
+//	WithinDuration(start, time.Second).Matches(start.Add(-2 * time.Second)) // returns false
+func WithinDuration(want time.Time, delta time.Duration) Matcher {
+	return withinDurationMatcher{want: want, delta: delta}
 }
 
-func (m inAnyOrderMatcher) prepareValue(x any) (reflect.Value, bool) {
-	xValue := reflect.ValueOf(x)
-	switch xValue.Kind() {
-	case reflect.Slice, reflect.Array:
-		return xValue, true
-	default:
-		return reflect.Value{}, false
+// JSONEq returns a matcher for JSON documents semantically equal to want,
+// regardless of the order of object keys and of whitespace. Strings, byte
+// slices and json.RawMessage values are decoded as JSON documents, while
+// other values are encoded to JSON first. JSONEq panics if want is not valid
+// JSON.
+//
+// Example usage:
+//
+//	JSONEq(`{"a": 1, "b": [2]}`).Matches(`{"b":[2],"a":1}`) // returns true
+//	JSONEq(`{"name": "gopher"}`).Matches(User{Name: "gopher"}) // returns true if User encodes to {"name":"gopher"}
+//	JSONEq(`{"a": 1}`).Matches(`{"a": 2}`) // returns false
+func JSONEq(want string) Matcher {
+	m := jsonEqMatcher{wantStr: want}
+	if err := json.Unmarshal([]byte(want), &m.want); err != nil {
+		panic(fmt.Sprintf("gomock: JSONEq: invalid JSON %q: %v", want, err))
 	}
+	return Memoize(m)
 }
 
-func (m inAnyOrderMatcher) String() string {
-	return fmt.Sprintf("has the same elements as %v", m.x)
+// ProtoEq returns a matcher for protocol buffer messages equal to want
+// according to proto.Equal, which unlike Eq ignores the internal state of the
+// messages. Mismatches are reported in the protobuf text format, along with
+// their differences.
+//
+// Example usage:
+//
+//	ProtoEq(&pb.User{Name: "gopher"}).Matches(&pb.User{Name: "gopher"}) // returns true
+//	ProtoEq(&pb.User{Name: "gopher"}).Matches(&pb.User{Name: "rust"}) // returns false
+func ProtoEq(want protov2.Message) Matcher {
+	return Memoize(protoEqMatcher{want: want})
 }
 
-// Constructors
-
-// All returns a composite Matcher that returns true if and only all of the
-// matchers return true.
-func All(ms ...Matcher) Matcher { return allMatcher{ms} }
-
-// Any returns a matcher that always matches.
-func Any() Matcher { return anyMatcher{} }
-
-// Eq returns a matcher that matches on equality.
+// DiffEq returns a matcher for values equal to want according to cmp.Equal
+// with the given options, such as cmpopts.IgnoreFields or
+// cmpopts.EquateApprox. Mismatches are reported as a diff of the values
+// rather than in full.
 //
 // Example usage:
 //
-//	Eq(5).Matches(5) // returns true
-//	Eq(5).Matches(4) // returns false
-func Eq(x any) Matcher { return eqMatcher{x} }
+//	DiffEq(User{Name: "gopher", ID: 1}, cmpopts.IgnoreFields(User{}, "ID")).Matches(User{Name: "gopher", ID: 2}) // returns true
+//	DiffEq(1.0, cmpopts.EquateApprox(0.01, 0)).Matches(1.001) // returns true
+func DiffEq(want any, opts ...cmp.Option) Matcher {
+	return Memoize(diffEqMatcher{want: want, opts: opts})
+}
 
 // Len returns a matcher that matches on length. This matcher returns false if
 // is compared to a type that is not an array, chan, map, slice, or string.
@@ -299,6 +2304,59 @@ func Len(i int) Matcher {
 	return lenMatcher{i}
 }
 
+// Zero returns a matcher for the zero value of any type, such as 0, "", a
+// nil pointer, slice or map, or a struct whose fields are all zero. Unlike
+// Nil, it matches zero structs and arrays; unlike Empty, it does not match
+// empty slices or maps that are not nil. Structs that are not zero are
+// reported with the fields that are not.
+//
+// Example usage:
+//
+//	Zero().Matches(User{}) // returns true
+//	Zero().Matches(User{Name: "gopher"}) // returns false
+//	Zero().Matches([]int{}) // returns false
+func Zero() Matcher {
+	return zeroMatcher{}
+}
+
+// Empty returns a matcher for arrays, chans, maps, slices, and strings of
+// length 0, whether nil or not. Values of other types do not match.
+//
+// Example usage:
+//
+//	Empty().Matches([]int{}) // returns true
+//	Empty().Matches(map[string]int(nil)) // returns true
+//	Empty().Matches("a") // returns false
+func Empty() Matcher {
+	return lenRangeMatcher{min: 0, max: 0}
+}
+
+// LenBetween returns a matcher for arrays, chans, maps, slices, and strings of
+// length between min and max, inclusive. It panics if min is negative or
+// greater than max.
+//
+// Example usage:
+//
+//	LenBetween(1, 3).Matches([]int{1, 2}) // returns true
+//	LenBetween(1, 3).Matches("") // returns false
+func LenBetween(min, max int) Matcher {
+	if min < 0 || min > max {
+		panic(fmt.Sprintf("gomock: LenBetween: invalid range [%d, %d]", min, max))
+	}
+	return lenRangeMatcher{min: min, max: max}
+}
+
+// NotEmpty returns a matcher for arrays, chans, maps, slices, and strings of
+// non-zero length.
+//
+// Example usage:
+//
+//	NotEmpty().Matches(map[string]int{"a": 1}) // returns true
+//	NotEmpty().Matches([]int(nil)) // returns false
+func NotEmpty() Matcher {
+	return lenRangeMatcher{min: 1, max: -1}
+}
+
 // Nil returns a matcher that matches if the received value is nil.
 //
 // Example usage:
@@ -309,6 +2367,21 @@ func Len(i int) Matcher {
 //	Nil().Matches(x) // returns false
 func Nil() Matcher { return nilMatcher{} }
 
+// Lazy returns a matcher matching like the matcher returned by f, which is
+// called every time a value is matched or the matcher is described, rather
+// than once when the expectation is declared. It lets an expectation refer to
+// values only known once earlier calls are made, such as an ID returned by a
+// mocked call. Lazy does not match while f returns nil.
+//
+// Example usage:
+//
+//	var id string
+//	m.EXPECT().Create(gomock.Any()).DoAndReturn(func(name string) string { id = newID(); return id })
+//	m.EXPECT().Get(gomock.Lazy(func() gomock.Matcher { return gomock.Eq(id) }))
+func Lazy(f func() Matcher) Matcher {
+	return lazyMatcher{f: f}
+}
+
 // Not reverses the results of its given child matcher.
 //
 // Example usage:
@@ -322,6 +2395,57 @@ func Not(x any) Matcher {
 	return notMatcher{Eq(x)}
 }
 
+// Xor returns a matcher that matches if exactly one of m1 and m2 matches.
+//
+// Example usage:
+//
+//	Xor(Eq(5), Len(1)).Matches(5) // returns true
+//	Xor(Eq("a"), Len(1)).Matches("a") // returns false
+func Xor(m1, m2 Matcher) Matcher { return xorMatcher{m1, m2} }
+
+// NoneOf returns a matcher that matches if none of the matchers ms match.
+//
+// Example usage:
+//
+//	NoneOf(Eq(4), Eq(5)).Matches(3) // returns true
+//	NoneOf(Eq(4), Eq(5)).Matches(5) // returns false
+func NoneOf(ms ...Matcher) Matcher { return noneOfMatcher{ms} }
+
+// AtLeast returns a matcher that matches if at least n of the matchers ms
+// match.
+//
+// Example usage:
+//
+//	AtLeast(2, Len(1), Eq("a"), Eq("b")).Matches("a") // returns true
+//	AtLeast(2, Len(1), Eq("a"), Eq("b")).Matches("c") // returns false
+func AtLeast(n int, ms ...Matcher) Matcher { return atLeastMatcher{n, ms} }
+
+// PointsTo returns a matcher for non-nil pointers to values matching x,
+// which is matched by equality if it is not a Matcher. It matches pointer
+// arguments without building an identical pointer.
+//
+// Example usage:
+//
+//	PointsTo(Request{ID: 1}).Matches(&Request{ID: 1}) // returns true
+//	PointsTo(Fields(map[string]any{"ID": 1})).Matches(&Request{ID: 1, Body: "a"}) // returns true
+//	PointsTo(Request{ID: 1}).Matches((*Request)(nil)) // returns false
+func PointsTo(x any) Matcher {
+	return pointsToMatcher{toMatcher(x)}
+}
+
+// Same returns a matcher that matches only x itself, not a copy of it: the
+// same pointer, map or channel, a slice sharing the elements of x, or a value
+// of another comparable type equal to x, such as the same context.Context.
+//
+// Example usage:
+//
+//	req := &Request{ID: 1}
+//	Same(req).Matches(req) // returns true
+//	Same(req).Matches(&Request{ID: 1}) // returns false
+func Same(x any) Matcher {
+	return sameMatcher{x}
+}
+
 // AssignableToTypeOf is a Matcher that matches if the parameter to the mock
 // function is assignable to the type of the parameter to this function.
 //
@@ -340,6 +2464,40 @@ func AssignableToTypeOf(x any) Matcher {
 	return assignableToTypeOfMatcher{reflect.TypeOf(x)}
 }
 
+// Implements returns a matcher for values whose dynamic type implements the
+// interface I, without spelling its reflect.Type out for AssignableToTypeOf.
+// A nil value does not match. It panics if I is not an interface type.
+//
+// Example usage:
+//
+//	Implements[fmt.Stringer]().Matches(time.Second) // returns true
+//	Implements[fmt.Stringer]().Matches(99) // returns false
+func Implements[I any]() Matcher {
+	if t := typeOf[I](); t.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("gomock: Implements requires an interface type, got %v", t))
+	}
+	return implementsMatcher[I]{}
+}
+
+// ElementsMatch returns a matcher for slices and arrays whose elements match
+// elems in any order, each element of elems matching a distinct element of
+// the collection: duplicates must appear as many times. The elements of elems
+// are matchers, or values compared with Eq. Mismatches are reported with the
+// elements of elems missing from the collection and its extra elements.
+//
+// Example usage:
+//
+//	ElementsMatch(1, 2, 2).Matches([]int{2, 1, 2}) // returns true
+//	ElementsMatch(1, 2, 2).Matches([]int{1, 2, 1}) // returns false
+//	ElementsMatch(Any(), 1).Matches([]int{1, 5}) // returns true
+func ElementsMatch(elems ...any) Matcher {
+	m := elementsMatchMatcher{elems: make([]Matcher, len(elems))}
+	for i, e := range elems {
+		m.elems[i] = toMatcher(e)
+	}
+	return m
+}
+
 // InAnyOrder is a Matcher that returns true for collections of the same elements ignoring the order.
 //
 // Example usage:
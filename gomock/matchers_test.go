@@ -18,9 +18,22 @@ package gomock_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"net"
+	"net/netip"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"go.uber.org/mock/gomock"
 	"go.uber.org/mock/gomock/internal/mock_gomock"
@@ -30,22 +43,244 @@ type A []string
 
 func TestMatchers(t *testing.T) {
 	type e any
+	type ctxKey struct{}
+	valueCtx := context.WithValue(context.Background(), ctxKey{}, "42")
+	deadlineCtx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
 	tests := []struct {
 		name    string
 		matcher gomock.Matcher
 		yes, no []e
 	}{
 		{"test Any", gomock.Any(), []e{3, nil, "foo"}, nil},
+		{"test AnyContext", gomock.AnyContext(),
+			[]e{context.Background(), context.TODO()},
+			[]e{nil, (context.Context)(nil), "ctx", 0}},
+		{"test ContextWithValue", gomock.ContextWithValue(ctxKey{}, "42"),
+			[]e{valueCtx, context.WithValue(valueCtx, "other", 1)},
+			[]e{context.Background(), context.WithValue(context.Background(), ctxKey{}, "43"), "42", nil}},
+		{"test ContextWithValue matcher", gomock.ContextWithValue(ctxKey{}, gomock.Nil()),
+			[]e{context.Background()},
+			[]e{valueCtx, nil}},
+		{"test ContextWithDeadline", gomock.ContextWithDeadline(),
+			[]e{deadlineCtx},
+			[]e{context.Background(), valueCtx, nil}},
+		{"test ContextNotCancelled", gomock.ContextNotCancelled(),
+			[]e{context.Background(), valueCtx, deadlineCtx},
+			[]e{cancelledCtx, context.WithValue(cancelledCtx, ctxKey{}, "42"), nil}},
+		{"test ContextChain", gomock.ContextChain().WithValue(ctxKey{}, "42").WithDeadlineWithin(2 * time.Hour).NotCancelled(),
+			[]e{context.WithValue(deadlineCtx, ctxKey{}, "42")},
+			[]e{valueCtx, deadlineCtx, context.WithValue(cancelledCtx, ctxKey{}, "42"), "42", nil}},
+		{"test ContextChain deadline", gomock.ContextChain().WithDeadlineWithin(time.Minute),
+			[]e{},
+			[]e{deadlineCtx, context.Background()}},
+		{"test ContextChain empty", gomock.ContextChain(),
+			[]e{context.Background(), valueCtx},
+			[]e{nil, "ctx"}},
+		{"test MatcherFunc", gomock.MatcherFunc(func(x any) bool { return x == "a" || x == 1 }, "is a or 1"),
+			[]e{"a", 1},
+			[]e{"b", 2, nil}},
+		{"test PointsTo", gomock.PointsTo(Dog{Name: "Fido"}),
+			[]e{&Dog{Name: "Fido"}},
+			[]e{Dog{Name: "Fido"}, &Dog{Name: "Rex"}, (*Dog)(nil), nil}},
+		{"test PointsTo matcher", gomock.PointsTo(gomock.Fields(map[string]any{"Name": "Fido"})),
+			[]e{&Dog{Name: "Fido"}, &Dog{Breed: "pug", Name: "Fido"}},
+			[]e{&Dog{Breed: "pug"}, (*Dog)(nil)}},
+		{"test PointsTo pointer", gomock.PointsTo(gomock.PointsTo(3)),
+			[]e{func() **int { n := 3; p := &n; return &p }()},
+			[]e{func() **int { var p *int; return &p }(), new(int)}},
+		{"test AnyT", gomock.AnyT[int](),
+			[]e{0, 4},
+			[]e{int64(4), "4", nil}},
+		{"test AnyT of interface", gomock.AnyT[error](),
+			[]e{nil, (error)(nil), errors.New("err")},
+			[]e{"err", 0}},
+		{"test Implements", gomock.Implements[fmt.Stringer](),
+			[]e{time.Second, net.IPv4(10, 0, 0, 1)},
+			[]e{99, "a", Dog{}, nil, (fmt.Stringer)(nil)}},
+		{"test EqExported", gomock.EqExported(Kennel{Name: "home", Dog: &Dog{Name: "Fido"}, secret: 1}),
+			[]e{Kennel{Name: "home", Dog: &Dog{Name: "Fido"}}, Kennel{Name: "home", Dog: &Dog{Name: "Fido"}, secret: 2}},
+			[]e{Kennel{Name: "home"}, Kennel{Name: "home", Dog: &Dog{Name: "Rex"}}, &Kennel{Name: "home"}, "home", nil}},
+		{"test EqExported nested", gomock.EqExported(map[string][]Kennel{"a": {{Size: 1, secret: 1}}}),
+			[]e{map[string][]Kennel{"a": {{Size: 1, secret: 2}}}},
+			[]e{map[string][]Kennel{"a": {{Size: 2}}}, map[string][]Kennel{"b": {{Size: 1}}}, map[string][]Kennel{}}},
+		{"test EqT", gomock.EqT(5),
+			[]e{5},
+			[]e{int64(5), 4, "5", nil}},
+		{"test EqT of defined type", gomock.EqT(time.Second),
+			[]e{time.Second},
+			[]e{int64(time.Second), 1000000000}},
+		{"test EqT of interface", gomock.EqT[fmt.Stringer](nil),
+			[]e{nil},
+			[]e{testStringer("a"), "a"}},
+		{"test MatcherFuncT", gomock.MatcherFuncT(func(n int) bool { return n%2 == 0 }, "is even"),
+			[]e{0, 2, -4},
+			[]e{1, int64(2), "2", nil}},
+		{"test MatcherFuncT of interface", gomock.MatcherFuncT(func(err error) bool { return err == nil }, "is a nil error"),
+			[]e{nil, (error)(nil)},
+			[]e{errors.New("err"), 0}},
+		{"test CondT", gomock.CondT("is even", func(n int) bool { return n%2 == 0 }),
+			[]e{0, 2, -4},
+			[]e{1, int64(2), "2", nil}},
+		{"test CondT of interface", gomock.CondT("is a nil error", func(err error) bool { return err == nil }),
+			[]e{nil, (error)(nil)},
+			[]e{errors.New("err"), 0}},
+		{"test ByTags", gomock.ByTags(Account{Name: "gopher"}),
+			[]e{Account{ID: "u-1", Name: "gopher", Token: "t", Updated: 5, secret: "s"}},
+			[]e{Account{ID: "1", Name: "gopher", Token: "t"}, Account{ID: "u-1", Name: "rust", Token: "t"},
+				Account{ID: "u-1", Name: "gopher"}, &Account{ID: "u-1", Name: "gopher", Token: "t"}, nil}},
+		{"test ByTags pointer", gomock.ByTags(&Account{Name: "gopher"}),
+			[]e{&Account{ID: "u-1", Name: "gopher", Token: "t"}},
+			[]e{Account{ID: "u-1", Name: "gopher", Token: "t"}, (*Account)(nil)}},
+		{"test Like", gomock.Like(Dog{Name: "Fido"}),
+			[]e{Dog{Name: "Fido"}, Dog{Breed: "pug", Name: "Fido"}},
+			[]e{Dog{Name: "Rex"}, &Dog{Name: "Fido"}, nil}},
+		{"test Like required", gomock.Like(Dog{Name: "Fido"}, "Breed"),
+			[]e{Dog{Name: "Fido"}},
+			[]e{Dog{Breed: "pug", Name: "Fido"}}},
+		{"test Like pointer", gomock.Like(&Dog{Breed: "pug"}),
+			[]e{&Dog{Breed: "pug"}, &Dog{Breed: "pug", Name: "Fido"}},
+			[]e{Dog{Breed: "pug"}, (*Dog)(nil), &Dog{}}},
+		{"test Fields", gomock.Fields(map[string]any{"Name": "home", "Dog.Name": gomock.Regex("^F"), "Dog.Breed": "pug"}),
+			[]e{Kennel{Name: "home", Dog: &Dog{Breed: "pug", Name: "Fido"}, Size: 3},
+				&Kennel{Name: "home", Dog: &Dog{Breed: "pug", Name: "Fifi"}}},
+			[]e{Kennel{Name: "home", Dog: &Dog{Breed: "pug", Name: "Rex"}}, Kennel{Name: "home"},
+				(*Kennel)(nil), Dog{Name: "Fido"}, nil, "home"}},
+		{"test Fields unexported", gomock.Fields(map[string]any{"secret": 0}),
+			nil,
+			[]e{Kennel{}}},
+		{"test MapContaining", gomock.MapContaining(map[string]any{"Accept": "text/plain", "Retries": gomock.Not(0)}),
+			[]e{map[string]any{"Accept": "text/plain", "Retries": 2, "Host": "a"}},
+			[]e{map[string]any{"Accept": "text/plain"}, map[string]any{"Accept": "text/html", "Retries": 2},
+				map[string]any{"Accept": "text/plain", "Retries": 0}, map[int]any{1: "a"}, nil, "Accept"}},
+		{"test MapContaining any keys", gomock.MapContaining(map[any]int{"a": 1, 2: 2}),
+			[]e{map[any]int{"a": 1, 2: 2, nil: 3}, map[any]any{"a": 1, 2: 2}},
+			[]e{map[string]int{"a": 1}, map[any]int{"a": 1}}},
+		{"test ElementsMatch", gomock.ElementsMatch(1, 2, 2),
+			[]e{[]int{2, 1, 2}, [3]int{2, 2, 1}},
+			[]e{[]int{1, 2, 1}, []int{1, 2}, []int{1, 2, 2, 3}, []string{"1", "2", "2"}, nil, 1}},
+		{"test ElementsMatch matchers", gomock.ElementsMatch(gomock.Any(), 1),
+			[]e{[]int{1, 5}, []any{"a", 1}},
+			[]e{[]int{5, 5}, []int{1}}},
+		{"test MatchesRegexp", gomock.MatchesRegexp(regexp.MustCompile(`^req-\d+$`)),
+			[]e{"req-1", testStringer("req-7")},
+			[]e{"req-x", 1, nil}},
+		{"test Satisfies", gomock.Satisfies(strings.Contains, "ed"),
+			[]e{"seed", "ed"},
+			[]e{"see", testStringer("seed"), 1, nil}},
+		{"test Satisfies interface", gomock.Satisfies(errors.Is, fs.ErrNotExist),
+			[]e{fs.ErrNotExist, fmt.Errorf("open: %w", fs.ErrNotExist)},
+			[]e{fs.ErrExist, nil, "file does not exist"}},
+		{"test InCIDR", gomock.InCIDR("10.0.0.0/8"),
+			[]e{net.ParseIP("10.1.2.3"), net.IPv4(10, 0, 0, 1).To4(), netip.MustParseAddr("10.255.0.1"),
+				netip.MustParseAddr("::ffff:10.0.0.1")},
+			[]e{net.ParseIP("192.168.0.1"), netip.MustParseAddr("2001:db8::1"), net.IP(nil), "10.0.0.1", nil}},
+		{"test InCIDR IPv6", gomock.InCIDR("2001:db8::/32"),
+			[]e{net.ParseIP("2001:db8::1"), netip.MustParseAddr("2001:db8:ffff::")},
+			[]e{net.ParseIP("2001:db9::1"), net.ParseIP("10.0.0.1")}},
+		{"test TimeAfter", gomock.TimeAfter(time.Unix(100, 0)),
+			[]e{time.Unix(101, 0), time.Unix(100, 1)},
+			[]e{time.Unix(100, 0), time.Unix(99, 0), int64(101), nil}},
+		{"test TimeBefore", gomock.TimeBefore(time.Unix(100, 0)),
+			[]e{time.Unix(99, 0)},
+			[]e{time.Unix(100, 0), time.Unix(101, 0), nil}},
+		{"test Approx", gomock.Approx(0.3, 1e-9),
+			[]e{0.1 + 0.2, 0.3},
+			[]e{0.31, "0.3", nil}},
+		{"test Approx integers", gomock.Approx(10, 0.5),
+			[]e{10, int64(10), uint8(10), float32(10.4), 10.4},
+			[]e{11, int8(9), 9.4}},
+		{"test Approx NaN", gomock.Approx(math.NaN(), math.Inf(1)),
+			nil,
+			[]e{math.NaN(), 0.0}},
+		{"test ApproxT", gomock.ApproxT[uint8](10, 2),
+			[]e{uint8(8), uint8(10), uint8(12)},
+			[]e{uint8(7), uint8(13), uint8(255), 10, uint16(10)}},
+		{"test ApproxT float", gomock.ApproxT[float32](1, 0.1),
+			[]e{float32(1.05), float32(0.95)},
+			[]e{1.0, float32(1.2)}},
+		{"test WithinDuration", gomock.WithinDuration(time.Unix(100, 0), time.Second),
+			[]e{time.Unix(100, 0), time.Unix(101, 0), time.Unix(99, 0), time.Unix(100, 0).In(time.FixedZone("X", 3600))},
+			[]e{time.Unix(101, 1), time.Unix(98, 0), int64(100), nil}},
+		{"test SortedBy", gomock.SortedBy(func(a, b int) bool { return a < b }, nil),
+			[]e{[]int{}, []int{1, 1, 2}, []int{4, 5}},
+			[]e{[]int{2, 1}, []int64{1, 2}, nil}},
+		{"test SortedBy expected", gomock.SortedBy(func(a, b int) bool { return a < b }, []int{3, 1, 2}),
+			[]e{[]int{1, 2, 3}},
+			[]e{[]int{3, 2, 1}, []int{1, 2}, []int{1, 2, 4}}},
+		{"test ErrorContains", gomock.ErrorContains("not found"),
+			[]e{errors.New("not found"), fmt.Errorf("user 1: %w", errors.New("not found"))},
+			[]e{errors.New("denied"), "not found", nil, (error)(nil)}},
+		{"test ErrorMatches", gomock.ErrorMatches(`^user \d+ not found$`),
+			[]e{errors.New("user 1 not found")},
+			[]e{errors.New("user x not found"), errors.New("get: user 1 not found"), "user 1 not found", nil}},
+		{"test Regex", gomock.Regex(`^req-\d+$`),
+			[]e{"req-1", "req-42", testStringer("req-7")},
+			[]e{"req-x", "a req-1", 1, nil, (*testStringer)(nil)}},
+		{"test JSONEq", gomock.JSONEq(`{"name": "Fido", "tags": ["a", "b"], "age": 3}`),
+			[]e{`{"age":3,"tags":["a","b"],"name":"Fido"}`, []byte(` {"name":"Fido","age":3.0,"tags":["a","b"]}`),
+				json.RawMessage(`{"name":"Fido","tags":["a","b"],"age":3}`),
+				map[string]any{"name": "Fido", "tags": []string{"a", "b"}, "age": 3}},
+			[]e{`{"name":"Fido","tags":["b","a"],"age":3}`, `{"name":"Fido"}`, `not json`, nil, 3}},
+		{"test ProtoEq", gomock.ProtoEq(wrapperspb.String("a")),
+			[]e{wrapperspb.String("a"), proto.Clone(wrapperspb.String("a"))},
+			[]e{wrapperspb.String("b"), wrapperspb.Bytes([]byte("a")), "a", nil}},
+		{"test DiffEq", gomock.DiffEq(Dog{Name: "Fido"}, cmpopts.IgnoreFields(Dog{}, "Breed")),
+			[]e{Dog{Name: "Fido"}, Dog{Breed: "pug", Name: "Fido"}},
+			[]e{Dog{Name: "Rex"}, &Dog{Name: "Fido"}, "Fido", nil}},
+		{"test DiffEq approx", gomock.DiffEq(1.0, cmpopts.EquateApprox(0.01, 0)),
+			[]e{1.0, 1.005},
+			[]e{1.1, 1, nil}},
+		{"test DiffEq unexported", gomock.DiffEq(struct{ x int }{1}),
+			nil,
+			[]e{struct{ x int }{1}}},
 		{"test All", gomock.Eq(4), []e{4}, []e{3, "blah", nil, int64(4)}},
 		{"test Nil", gomock.Nil(),
 			[]e{nil, (error)(nil), (chan bool)(nil), (*int)(nil)},
 			[]e{"", 0, make(chan bool), errors.New("err"), new(int)}},
 		{"test Not", gomock.Not(gomock.Eq(4)), []e{3, "blah", nil, int64(4)}, []e{4}},
 		{"test All", gomock.All(gomock.Any(), gomock.Eq(4)), []e{4}, []e{3, "blah", nil, int64(4)}},
+		{"test Xor", gomock.Xor(gomock.Eq("a"), gomock.Len(1)), []e{"b"}, []e{"a", "aa"}},
+		{"test NoneOf", gomock.NoneOf(gomock.Eq(4), gomock.Eq(5)), []e{3, "blah", nil}, []e{4, 5}},
+		{"test NoneOf empty", gomock.NoneOf(), []e{3, nil}, []e{}},
+		{"test AtLeast", gomock.AtLeast(2, gomock.Len(1), gomock.Eq("a"), gomock.Eq("b")),
+			[]e{"a", "b"},
+			[]e{"c", "ab", nil}},
+		{"test AtLeast zero", gomock.AtLeast(0, gomock.Eq(4)), []e{3, 4}, []e{}},
+		{"test HasPrefix", gomock.HasPrefix("req-"),
+			[]e{"req-42", []byte("req-"), json.RawMessage("req-1")},
+			[]e{"id-42", "re", []byte("id"), 42, nil}},
+		{"test HasSuffix", gomock.HasSuffix(".json"),
+			[]e{"user.json", []byte("a.json")},
+			[]e{"user.yaml", []rune("a.json"), nil}},
+		{"test Contains", gomock.Contains("gopher"),
+			[]e{"hello, gopher!", []byte("gophers")},
+			[]e{"hello, world!", errors.New("gopher"), nil}},
+		{"test EqualFold", gomock.EqualFold("Gopher"),
+			[]e{"GOPHER", "gopher", []byte("gOpHeR")},
+			[]e{"gophers", "", nil}},
 		{"test Len", gomock.Len(2),
 			[]e{[]int{1, 2}, "ab", map[string]int{"a": 0, "b": 1}, [2]string{"a", "b"}},
 			[]e{[]int{1}, "a", 42, 42.0, false, [1]string{"a"}},
 		},
+		{"test LenBetween", gomock.LenBetween(1, 2),
+			[]e{[]int{1, 2}, "a", map[string]int{"a": 0}, [2]string{"a", "b"}},
+			[]e{[]int{}, "abc", 42, nil, [3]string{}},
+		},
+		{"test NotEmpty", gomock.NotEmpty(),
+			[]e{[]int{1}, "abc", map[string]int{"a": 0}, [1]string{}},
+			[]e{[]int(nil), "", map[string]int{}, [0]string{}, 42, nil},
+		},
+		{"test Zero", gomock.Zero(),
+			[]e{nil, 0, "", false, Dog{}, (*Dog)(nil), []int(nil), [2]int{}},
+			[]e{1, "a", Dog{Name: "Fido"}, &Dog{}, []int{}, map[string]int{}, [2]int{0, 1}},
+		},
+		{"test Empty", gomock.Empty(),
+			[]e{[]int{}, []int(nil), "", map[string]int{}, [0]int{}, make(chan int)},
+			[]e{[]int{1}, "a", 0, nil, Dog{}},
+		},
 		{"test assignable types", gomock.Eq(A{"a", "b"}),
 			[]e{[]string{"a", "b"}, A{"a", "b"}},
 			[]e{[]string{"a"}, A{"b"}},
@@ -90,8 +325,27 @@ type Dog struct {
 	Breed, Name string
 }
 
+type Account struct {
+	ID      string `mock:"regex=^u-"`
+	Name    string
+	Token   string `mock:"nonzero"`
+	Updated int64  `mock:"ignore"`
+	secret  string
+}
+
+type Kennel struct {
+	Name   string
+	Dog    *Dog
+	Size   int
+	secret int
+}
+
 type ctxKey struct{}
 
+type testStringer string
+
+func (s testStringer) String() string { return string(s) }
+
 // A thorough test of assignableToTypeOfMatcher
 func TestAssignableToTypeOfMatcher(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -145,6 +399,173 @@ func TestAssignableToTypeOfMatcher(t *testing.T) {
 	}
 }
 
+func TestLikeMatcher(t *testing.T) {
+	if got, want := gomock.Like(Dog{Name: "Fido"}, "Breed").String(), `is like gomock_test.Dog{Breed: "", Name: "Fido"}`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		partial  any
+		required []string
+	}{
+		{"not a struct", "Fido", nil},
+		{"unknown field", Dog{}, []string{"Age"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected Like to panic")
+				}
+			}()
+			gomock.Like(tt.partial, tt.required...)
+		})
+	}
+}
+
+func TestLenMatchers(t *testing.T) {
+	for _, tc := range []struct {
+		m         gomock.Matcher
+		want, got string
+	}{
+		{gomock.Len(2), "has length 2", "[1 2 3] ([]int) of length 3"},
+		{gomock.LenBetween(1, 2), "has length between 1 and 2", "[1 2 3] ([]int) of length 3"},
+		{gomock.NotEmpty(), "is not empty", "[1 2 3] ([]int) of length 3"},
+		{gomock.Empty(), "is empty", "[1 2 3] ([]int) of length 3"},
+	} {
+		if got := tc.m.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+		if got := tc.m.(gomock.GotFormatter).Got([]int{1, 2, 3}); got != tc.got {
+			t.Errorf("Got() = %q, want %q", got, tc.got)
+		}
+	}
+	if got, want := gomock.NotEmpty().(gomock.GotFormatter).Got(42), "42 (int), which has no length"; got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected LenBetween to panic")
+		}
+	}()
+	gomock.LenBetween(2, 1)
+}
+
+func TestZero(t *testing.T) {
+	m := gomock.Zero()
+	if got, want := m.String(), "is the zero value of its type"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	for x, want := range map[any]string{
+		1:                               "1 (int)",
+		Dog{Breed: "pug"}:               "{pug } (gomock_test.Dog), with non-zero fields Breed",
+		Dog{Breed: "pug", Name: "Fido"}: "{pug Fido} (gomock_test.Dog), with non-zero fields Breed, Name",
+	} {
+		if got := m.(gomock.GotFormatter).Got(x); got != want {
+			t.Errorf("Got(%v) = %q, want %q", x, got, want)
+		}
+	}
+}
+
+func TestSame(t *testing.T) {
+	dog := &Dog{Name: "Fido"}
+	m := gomock.Same(dog)
+	if !m.Matches(dog) {
+		t.Errorf("Same should match the same pointer")
+	}
+	if m.Matches(&Dog{Name: "Fido"}) || m.Matches(*dog) || m.Matches(nil) {
+		t.Errorf("Same should not match a copy")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if !gomock.Same(ctx).Matches(ctx) {
+		t.Errorf("Same should match the same context")
+	}
+	derived, cancelDerived := context.WithCancel(ctx)
+	defer cancelDerived()
+	if gomock.Same(ctx).Matches(derived) {
+		t.Errorf("Same should not match a derived context")
+	}
+
+	s := []int{1, 2}
+	if !gomock.Same(s).Matches(s) || gomock.Same(s).Matches([]int{1, 2}) {
+		t.Errorf("Same should match only slices sharing their elements")
+	}
+	if !gomock.Same(nil).Matches(nil) || gomock.Same(nil).Matches((*Dog)(nil)) {
+		t.Errorf("Same(nil) should match only nil")
+	}
+
+	if got, want := m.String(), fmt.Sprintf("is the same as &{ Fido} at %p (*gomock_test.Dog)", dog); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	other := &Dog{Name: "Fido"}
+	if got, want := m.(gomock.GotFormatter).Got(other), fmt.Sprintf("&{ Fido} at %p (*gomock_test.Dog)", other); got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	var inner gomock.Matcher
+	m := gomock.Lazy(func() gomock.Matcher { return inner })
+	if m.Matches(nil) || m.Matches(1) {
+		t.Errorf("Lazy should not match before it is resolved")
+	}
+	if got, want := m.String(), "is matched by a lazy matcher not resolved yet"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	inner = gomock.Eq(1)
+	if !m.Matches(1) || m.Matches(2) {
+		t.Errorf("Lazy should match like Eq(1)")
+	}
+	inner = gomock.Eq(2)
+	if !m.Matches(2) || m.Matches(1) {
+		t.Errorf("Lazy should match like Eq(2) once resolved again")
+	}
+	if got, want := m.String(), "is equal to 2 (int)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	inner = gomock.EqT("a")
+	if got, want := m.(gomock.GotFormatter).Got(1), "1 (int), not of type string"; got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+}
+
+func TestByTags(t *testing.T) {
+	m := gomock.ByTags(Account{Name: "gopher"})
+	want := `is a gomock_test.Account that has fields {ID: matches regexp "^u-", Name: is equal to gopher (string), Token: is not zero}`
+	if got := m.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := m.(gomock.GotFormatter).Got(Account{ID: "1", Name: "gopher"}), `gomock_test.Account{ID: "1", Name: "gopher", Token: ""}`; got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+
+	type invalid struct {
+		Name string `mock:"fuzzy"`
+	}
+	for _, tt := range []struct {
+		name     string
+		expected any
+	}{
+		{"not a struct", "gopher"},
+		{"nil pointer", (*Account)(nil)},
+		{"invalid tag", invalid{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected ByTags to panic")
+				}
+			}()
+			gomock.ByTags(tt.expected)
+		})
+	}
+}
+
 func TestInAnyOrder(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -293,3 +714,289 @@ func TestInAnyOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestMapContainingGot(t *testing.T) {
+	m := gomock.MapContaining(map[string]int{"a": 1, "b": 2, "c": 3})
+	if want := "contains {a: is equal to 1 (int), b: is equal to 2 (int), c: is equal to 3 (int)}"; m.String() != want {
+		t.Errorf("String() == %q, want %q", m.String(), want)
+	}
+	got := m.(gomock.GotFormatter).Got(map[string]int{"a": 1, "b": 5})
+	want := "map[a:1 b:5] (map[string]int)\nmissing keys: c\nmismatched keys: b: got 5 (int), want is equal to 2 (int)"
+	if got != want {
+		t.Errorf("Got() == %q, want %q", got, want)
+	}
+}
+
+func TestElementsMatchGot(t *testing.T) {
+	m := gomock.ElementsMatch(1, 2, 2)
+	if want := "has elements matching [is equal to 1 (int), is equal to 2 (int), is equal to 2 (int)] in any order"; m.String() != want {
+		t.Errorf("String() == %q, want %q", m.String(), want)
+	}
+	got := m.(gomock.GotFormatter).Got([]int{3, 2, 1, 1})
+	want := "[3 2 1 1] ([]int)\nmissing: is equal to 2 (int)\nextra: 3, 1"
+	if got != want {
+		t.Errorf("Got() == %q, want %q", got, want)
+	}
+
+	got = gomock.InAnyOrder([]int{1, 2}).(gomock.GotFormatter).Got([]int{2, 2})
+	want = "[2 2] ([]int)\nmissing: is equal to 1 (int)\nextra: 2"
+	if got != want {
+		t.Errorf("InAnyOrder Got() == %q, want %q", got, want)
+	}
+}
+
+func TestAdaptersString(t *testing.T) {
+	for _, tt := range []struct {
+		m    gomock.Matcher
+		want string
+	}{
+		{gomock.Satisfies(strings.HasPrefix, "/api/"), `satisfies strings.HasPrefix(_, "/api/")`},
+		{gomock.InCIDR("10.1.2.3/8"), "is an IP address in 10.0.0.0/8"},
+		{gomock.TimeAfter(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)), "is a time after 2020-01-02 03:04:05 +0000 UTC"},
+	} {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("String() == %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCombinatorsString(t *testing.T) {
+	for _, tc := range []struct {
+		m    gomock.Matcher
+		want string
+	}{
+		{gomock.Xor(gomock.Eq(4), gomock.Nil()), "either is equal to 4 (int) or is nil, but not both"},
+		{gomock.NoneOf(gomock.Eq(4), gomock.Nil()), "none of: is equal to 4 (int); is nil"},
+		{gomock.AtLeast(1, gomock.Eq(4), gomock.Nil()), "at least 1 of: is equal to 4 (int); is nil"},
+	} {
+		if got := tc.m.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestStringMatchersString(t *testing.T) {
+	for _, tc := range []struct {
+		m    gomock.Matcher
+		want string
+	}{
+		{gomock.HasPrefix("a"), `has prefix "a"`},
+		{gomock.HasSuffix("a"), `has suffix "a"`},
+		{gomock.Contains("a"), `contains "a"`},
+		{gomock.EqualFold("a"), `is equal ignoring case to "a"`},
+	} {
+		if got := tc.m.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestCondT(t *testing.T) {
+	m := gomock.CondT("is even", func(n int) bool { return n%2 == 0 })
+	if got, want := m.String(), "is even (int)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	gf := m.(gomock.GotFormatter)
+	for x, want := range map[any]string{
+		3:        "3 (int), which does not satisfy: is even",
+		4:        "4 (int)",
+		int64(4): "4 (int64), not of type int",
+	} {
+		if got := gf.Got(x); got != want {
+			t.Errorf("Got(%v) = %q, want %q", x, got, want)
+		}
+	}
+}
+
+func TestEqExportedCycle(t *testing.T) {
+	type node struct {
+		Next  *node
+		Value int
+		seen  bool
+	}
+	a := &node{Value: 1}
+	a.Next = a
+	b := &node{Value: 1, seen: true}
+	b.Next = b
+	if !gomock.EqExported(a).Matches(b) {
+		t.Errorf("EqExported(%v) does not match %v", a, b)
+	}
+	b.Value = 2
+	if gomock.EqExported(a).Matches(b) {
+		t.Errorf("EqExported(%v) matches %v", a, b)
+	}
+}
+
+func TestEqExportedOpaque(t *testing.T) {
+	type Event struct {
+		At   time.Time
+		seen bool
+	}
+	t1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	if gomock.EqExported(Event{At: t1}).Matches(Event{At: t2}) {
+		t.Errorf("EqExported(%v) matches %v", t1, t2)
+	}
+	if !gomock.EqExported(Event{At: t1}).Matches(Event{At: t1.In(time.FixedZone("X", 3600)), seen: true}) {
+		t.Errorf("EqExported(%v) does not match the same instant in another zone", t1)
+	}
+
+	type opaque struct{ n int }
+	if gomock.EqExported(opaque{1}).Matches(opaque{2}) {
+		t.Errorf("EqExported(opaque{1}) matches opaque{2}")
+	}
+}
+
+func TestPointsToGot(t *testing.T) {
+	m := gomock.PointsTo(Dog{Name: "Fido"})
+	if got, want := m.String(), "is a pointer to a value that is equal to { Fido} (gomock_test.Dog)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	got := m.(gomock.GotFormatter).Got(&Dog{Name: "Rex"})
+	if want := "pointer to { Rex} (gomock_test.Dog)"; got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+	got = m.(gomock.GotFormatter).Got((*Dog)(nil))
+	if want := "<nil> (*gomock_test.Dog)"; got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+}
+
+func TestTypedMatchersGot(t *testing.T) {
+	for _, m := range []gomock.Matcher{gomock.EqT(5), gomock.AnyT[int]()} {
+		got := m.(gomock.GotFormatter).Got(int64(5))
+		if want := "5 (int64), not of type int"; got != want {
+			t.Errorf("%v: got %q, want %q", m, got, want)
+		}
+	}
+	if got, want := gomock.EqT(5).String(), "is equal to 5 (int)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := gomock.AnyT[error]().String(), "is a error"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := gomock.Implements[fmt.Stringer]().String(), "implements fmt.Stringer"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestContextMatchersGot(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "43")
+	got := gomock.ContextWithValue(ctxKey{}, "42").(gomock.GotFormatter).Got(ctx)
+	if want := `context.Context with value for gomock_test.ctxKey{}: 43 (string)`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got = gomock.ContextNotCancelled().(gomock.GotFormatter).Got(ctx)
+	if want := "context.Context done with context canceled"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestContextChain(t *testing.T) {
+	type ctxKey struct{}
+	m := gomock.ContextChain().WithValue(ctxKey{}, "42").WithDeadline().NotCancelled()
+	if got, want := m.String(), `is a context.Context with value for gomock_test.ctxKey{}: is equal to 42 (string), with a deadline, not cancelled`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "42"))
+	cancel()
+	if got, want := m.Got(ctx), "context.Context with no deadline, done with context canceled"; got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+
+	// Matchers chained from the same one do not share their conditions.
+	base := gomock.ContextChain().WithValue(ctxKey{}, "42")
+	_ = base.WithDeadline()
+	notCancelled := base.NotCancelled()
+	if !notCancelled.Matches(context.WithValue(context.Background(), ctxKey{}, "42")) {
+		t.Errorf("%v should not require a deadline", notCancelled)
+	}
+}
+
+func TestWithinDurationMonotonic(t *testing.T) {
+	now := time.Now()
+	// Both times carry a monotonic clock reading, used by the comparison.
+	later := now.Add(10 * time.Millisecond)
+	if !gomock.WithinDuration(now, 20*time.Millisecond).Matches(later) {
+		t.Errorf("%v is not within 20ms of %v", later, now)
+	}
+	got := gomock.WithinDuration(now, time.Millisecond).(gomock.GotFormatter).Got(later)
+	if !strings.Contains(got, "(10ms from the expected time)") {
+		t.Errorf("Got() == %q, want it to contain the offset", got)
+	}
+}
+
+func TestInCIDRInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("InCIDR did not panic")
+		}
+	}()
+	gomock.InCIDR("10.0.0.0")
+}
+
+func TestImplementsNotInterface(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Implements did not panic")
+		}
+	}()
+	gomock.Implements[int]()
+}
+
+func TestFieldsGot(t *testing.T) {
+	m := gomock.Fields(map[string]any{"Size": 3, "Dog.Name": "Fido"})
+	if want := "has fields {Dog.Name: is equal to Fido (string), Size: is equal to 3 (int)}"; m.String() != want {
+		t.Errorf("String() == %q, want %q", m.String(), want)
+	}
+	got := m.(gomock.GotFormatter).Got(Kennel{Name: "home", Size: 2})
+	if want := "gomock_test.Kennel{Dog.Name: <missing>, Size: 2}"; got != want {
+		t.Errorf("Got() == %q, want %q", got, want)
+	}
+}
+
+func TestFieldsInvalidPath(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Fields did not panic")
+		}
+	}()
+	gomock.Fields(map[string]any{"Dog..Name": "Fido"})
+}
+
+func TestDiffEqGot(t *testing.T) {
+	m := gomock.DiffEq(Dog{Name: "Fido", Breed: "pug"})
+	got := m.(gomock.GotFormatter).Got(Dog{Name: "Rex", Breed: "pug"})
+	// The output of cmp is deliberately unstable, only check its markers.
+	for _, want := range []string{"gomock_test.Dog, diff (-want +got):", "\n-", `"Fido"`, "\n+", `"Rex"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Got() == %q, want it to contain %q", got, want)
+		}
+	}
+	if want := "is cmp-equal to a gomock_test.Dog"; m.String() != want {
+		t.Errorf("String() == %q, want %q", m.String(), want)
+	}
+
+	got = gomock.DiffEq(struct{ x int }{1}).(gomock.GotFormatter).Got(struct{ x int }{1})
+	if !strings.Contains(got, "cannot compare") {
+		t.Errorf("Got() == %q, want it to report the comparison failure", got)
+	}
+}
+
+func TestProtoEqGot(t *testing.T) {
+	m := gomock.ProtoEq(wrapperspb.String("a"))
+	got := m.(gomock.GotFormatter).Got(wrapperspb.String("b"))
+	for _, want := range []string{"*wrapperspb.StringValue{", "Diff (-want +got):", "\n-value:", "\n+value:"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Got() == %q, want it to contain %q", got, want)
+		}
+	}
+	if !strings.HasPrefix(m.String(), "is proto equal to *wrapperspb.StringValue{") {
+		t.Errorf("String() == %q", m.String())
+	}
+}
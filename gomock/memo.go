@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"reflect"
+	"sync"
+)
+
+// matching maps the ID of each goroutine on which a Controller is matching a
+// call against its expectations to that Controller, so that memoized matchers
+// find the attempt they are evaluated in.
+var matching sync.Map // map[uint64]*Controller
+
+// beginMatch starts an attempt at matching a call on the goroutine holding the
+// lock of ctrl. The results of memoized matchers are kept until the returned
+// function is called, which ends the attempt.
+func (ctrl *Controller) beginMatch() (end func()) {
+	ctrl.matchAttempts++
+	id := ctrl.lockedBy.Load()
+	// A matcher may call the mocks of another Controller, which matches the
+	// call in its own attempt on the same goroutine.
+	prev, nested := matching.Swap(id, ctrl)
+	return func() {
+		if nested {
+			matching.Store(id, prev)
+		} else {
+			matching.Delete(id)
+		}
+	}
+}
+
+// currentMatch returns the Controller matching a call on the current
+// goroutine and the number of its attempt, or nil if there is none.
+func currentMatch() (*Controller, uint64) {
+	v, ok := matching.Load(goroutineID())
+	if !ok {
+		return nil, 0
+	}
+	// The Controller is locked by the current goroutine.
+	ctrl := v.(*Controller)
+	return ctrl, ctrl.matchAttempts
+}
+
+type memoMatcher struct {
+	m Matcher
+
+	mu      sync.Mutex
+	ctrl    *Controller // Controller of the attempt the results belong to
+	attempt uint64      // attempt the results belong to
+	results []memoResult
+}
+
+type memoResult struct {
+	arg   any
+	match bool
+}
+
+func (m *memoMatcher) Matches(x any) bool {
+	ctrl, attempt := currentMatch()
+	if ctrl == nil {
+		return m.m.Matches(x)
+	}
+
+	m.mu.Lock()
+	if m.ctrl == ctrl && m.attempt == attempt {
+		for _, r := range m.results {
+			if sameArg(r.arg, x) {
+				m.mu.Unlock()
+				return r.match
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	// The lock is not held while matching, as the matcher may be nested in
+	// itself.
+	match := m.m.Matches(x)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ctrl != ctrl || m.attempt != attempt {
+		m.ctrl, m.attempt, m.results = ctrl, attempt, nil
+	}
+	m.results = append(m.results, memoResult{arg: x, match: match})
+	return match
+}
+
+func (m *memoMatcher) String() string {
+	return m.m.String()
+}
+
+// Got formats x as the memoized matcher does.
+func (m *memoMatcher) Got(x any) string {
+	return formatGottenArg(m.m, x)
+}
+
+// sameArg returns whether a and b are the same argument: equal values of a
+// comparable type, or slices and maps sharing their elements.
+func sameArg(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return !va.IsValid() && !vb.IsValid()
+	}
+	if va.Type() != vb.Type() {
+		return false
+	}
+	switch va.Kind() {
+	case reflect.Slice:
+		return va.Pointer() == vb.Pointer() && va.Len() == vb.Len()
+	case reflect.Map:
+		return va.Pointer() == vb.Pointer()
+	}
+	return va.Comparable() && vb.Comparable() && a == b
+}
+
+// Memoize returns a matcher matching like m, which remembers its results
+// while a Controller matches a call against its expectations, so that m is
+// evaluated once for each argument it is given however many expectations or
+// observers share it. It suits matchers that are expensive to evaluate, such
+// as ones decoding or diffing their arguments. JSONEq, ProtoEq and DiffEq
+// are memoized.
+//
+// Example usage:
+//
+//	isValid := gomock.Memoize(gomock.MatcherFunc(func(x any) bool { return validate(x) == nil }, "is valid"))
+//	m.EXPECT().Send(isValid).Return(nil)
+//	m.EXPECT().Send(isValid).Times(1).Observer()
+func Memoize(m Matcher) Matcher {
+	if _, ok := m.(*memoMatcher); ok {
+		return m
+	}
+	return &memoMatcher{m: m}
+}
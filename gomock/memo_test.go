@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestMemoize(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	var evals int
+	isA := gomock.Memoize(gomock.MatcherFunc(func(x any) bool {
+		evals++
+		return x == "a"
+	}, "is a"))
+	ctrl.RecordCall(subject, "FooMethod", isA).Times(2).Observer()
+	ctrl.RecordCall(subject, "FooMethod", isA).Times(2)
+
+	ctrl.Call(subject, "FooMethod", "a")
+	if evals != 1 {
+		t.Errorf("matcher evaluated %d times for a call, want 1", evals)
+	}
+	ctrl.Call(subject, "FooMethod", "a")
+	if evals != 2 {
+		t.Errorf("matcher evaluated %d times for two calls, want 2", evals)
+	}
+	ctrl.Finish()
+	reporter.assertPass("memoized matcher matched")
+
+	isA.Matches("a")
+	isA.Matches("a")
+	if evals != 4 {
+		t.Errorf("matcher evaluated %d times outside of calls, want 4", evals)
+	}
+}
+
+func TestMemoize_NestedControllers(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	inner := gomock.NewController(reporter)
+	subject, other := new(Subject), new(Subject)
+
+	var evals int
+	isA := gomock.Memoize(gomock.MatcherFunc(func(x any) bool {
+		evals++
+		return x == "a"
+	}, "is a"))
+	inner.RecordCall(other, "FooMethod", isA).Observer()
+	inner.RecordCall(other, "FooMethod", isA)
+	// The matcher of the outer Controller calls a mock of the inner one while
+	// the outer one is matching.
+	callsOther := gomock.MatcherFunc(func(x any) bool {
+		inner.Call(other, "FooMethod", "a")
+		return true
+	}, "calls other")
+	ctrl.RecordCall(subject, "FooMethod", callsOther)
+	ctrl.RecordCall(subject, "BarMethod", isA).Times(1).Observer()
+	ctrl.RecordCall(subject, "BarMethod", isA)
+
+	ctrl.Call(subject, "FooMethod", "b")
+	if evals != 1 {
+		t.Errorf("matcher evaluated %d times for the inner call, want 1", evals)
+	}
+	ctrl.Call(subject, "BarMethod", "a")
+	if evals != 2 {
+		t.Errorf("matcher evaluated %d times after the outer call, want 2", evals)
+	}
+	inner.Finish()
+	ctrl.Finish()
+	reporter.assertPass("memoized matchers of nested Controllers")
+}
+
+func TestMemoize_Got(t *testing.T) {
+	m := gomock.Memoize(gomock.EqT(5))
+	if got, want := m.String(), "is equal to 5 (int)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := m.(gomock.GotFormatter).Got(int64(5)), "5 (int64), not of type int"; got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+	if got, want := gomock.Memoize(gomock.Eq(5)).(gomock.GotFormatter).Got(4), "4 (int)"; got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+type messageTemplatesOption map[ErrorKind]*template.Template
+
+// WithMessageTemplates replaces the failure messages reported by the
+// Controller for the kinds of failures given, such as UnexpectedCall and
+// MissingCall, by the text/template templates given for them. A template is
+// executed with the *ExpectationError describing the failure, which
+// {{.}} formats as the standard message, so that a template can append to
+// the standard message as well as reformat it:
+//
+//	ctrl := gomock.NewController(t, gomock.WithMessageTemplates(map[gomock.ErrorKind]string{
+//	  gomock.MissingCall: "{{.}}\nSee https://wiki.example.com/gomock-missing-calls",
+//	  gomock.UnexpectedCall: `unexpected_call method={{.Method}} origin={{.Origin}}`,
+//	}))
+//
+// The error passed to the TestReporter still unwraps to the
+// *ExpectationError, see ExpectationErrorFrom. WithMessageTemplates panics if
+// a template cannot be parsed. A template failing to execute falls back to
+// the standard message, followed by the error.
+func WithMessageTemplates(templates map[ErrorKind]string) messageTemplatesOption {
+	kinds := make([]ErrorKind, 0, len(templates))
+	for kind := range templates {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+
+	o := make(messageTemplatesOption, len(templates))
+	for _, kind := range kinds {
+		tmpl, err := template.New(kind.String()).Parse(templates[kind])
+		if err != nil {
+			panic(fmt.Sprintf("gomock: WithMessageTemplates: %v", err))
+		}
+		o[kind] = tmpl
+	}
+	return o
+}
+
+func (o messageTemplatesOption) apply(ctrl *Controller) {
+	ctrl.messages = o
+}
+
+// templatedError is an ExpectationError formatted by a template of
+// WithMessageTemplates.
+type templatedError struct {
+	err *ExpectationError
+	msg string
+}
+
+func (e *templatedError) Error() string {
+	return e.msg
+}
+
+func (e *templatedError) Unwrap() error {
+	return e.err
+}
+
+// failure returns the error to report for err, formatted by the template of
+// its kind if there is one.
+func (ctrl *Controller) failure(err *ExpectationError) error {
+	tmpl := ctrl.messages[err.Kind]
+	if tmpl == nil {
+		return err
+	}
+	var msg strings.Builder
+	if terr := tmpl.Execute(&msg, err); terr != nil {
+		return &templatedError{err: err, msg: fmt.Sprintf("%v\n(message template failed: %v)", err, terr)}
+	}
+	return &templatedError{err: err, msg: msg.String()}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithMessageTemplates(t *testing.T) {
+	reporter := &argsReporter{ErrorReporter: NewErrorReporter(t)}
+	ctrl := gomock.NewController(reporter, gomock.WithMessageTemplates(map[gomock.ErrorKind]string{
+		gomock.UnexpectedCall: "unexpected_call method={{.Method}} args={{.Args}}",
+		gomock.MissingCall:    "{{.}}\nSee the runbook.",
+	}))
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}, "unexpected_call method=FooMethod args=[argument]")
+	if _, ok := gomock.ExpectationErrorFrom(reporter.args[0]); !ok {
+		t.Errorf("no *gomock.ExpectationError in %v", reporter.args[0])
+	}
+
+	ctrl.RecordCall(subject, "BarMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
+	var found bool
+	for _, entry := range reporter.log {
+		found = found || strings.HasPrefix(entry, "missing call(s) to *gomock_test.Subject.BarMethod") &&
+			strings.HasSuffix(entry, "\nSee the runbook.")
+	}
+	if !found {
+		t.Errorf("log %q has no templated missing call", reporter.log)
+	}
+}
+
+func TestWithMessageTemplates_ExecuteError(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithMessageTemplates(map[gomock.ErrorKind]string{
+		gomock.UnexpectedCall: "{{.NoSuchField}}",
+	}))
+
+	reporter.assertFatal(func() {
+		ctrl.Call(new(Subject), "FooMethod", "argument")
+	}, "Unexpected call to", "(message template failed:", "NoSuchField")
+}
+
+func TestWithMessageTemplates_ParseError(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected WithMessageTemplates to panic")
+		}
+	}()
+	gomock.WithMessageTemplates(map[gomock.ErrorKind]string{gomock.MissingCall: "{{"})
+}
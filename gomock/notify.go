@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+// Notify makes each call matching the expectation send on ch once its
+// actions have run, so that a test can wait for the calls made by other
+// goroutines instead of polling or sleeping. The send blocks the call until
+// it is received, unless ch has room in its buffer.
+//
+// Example usage:
+//
+//	called := make(chan struct{}, 1)
+//	m.EXPECT().Publish(gomock.Any()).Notify(called)
+//	go worker(m)
+//	<-called
+func (c *Call) Notify(ch chan<- struct{}) *Call {
+	c.t.Helper()
+
+	if ch == nil {
+		c.t.Fatalf("Notify called for %T.%v: the channel is nil, so calls would block forever [%s]", c.receiver, c.method, c.origin)
+		return c
+	}
+	c.addNotify(func([]any) { ch <- struct{}{} })
+	return c
+}
+
+// NotifyArgs is like Notify, sending the arguments of each call on ch.
+//
+// Example usage:
+//
+//	published := make(chan []any, 1)
+//	m.EXPECT().Publish(gomock.Any()).NotifyArgs(published)
+//	go worker(m)
+//	args := <-published
+func (c *Call) NotifyArgs(ch chan<- []any) *Call {
+	c.t.Helper()
+
+	if ch == nil {
+		c.t.Fatalf("NotifyArgs called for %T.%v: the channel is nil, so calls would block forever [%s]", c.receiver, c.method, c.origin)
+		return c
+	}
+	c.addNotify(func(args []any) { ch <- append([]any(nil), args...) })
+	return c
+}
+
+func (c *Call) addNotify(f func(args []any)) {
+	c.lock()
+	defer c.unlock()
+
+	c.notify = append(c.notify, f)
+}
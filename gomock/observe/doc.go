@@ -0,0 +1,28 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package observe provides mocks of common observability interfaces, and
+// matchers of what they are given, for tests to expect the logs and the
+// metrics emitted by the code under test:
+//
+//	h := observe.NewLogHandler(ctrl, slog.LevelWarn)
+//	h.EXPECT().Handle(gomock.Any(), observe.LogRecord(slog.LevelError, "request failed", slog.Int("status", 500)))
+//	serve(slog.New(h), req)
+//
+//	requests := observe.NewMockCounter(ctrl)
+//	requests.EXPECT().Add(1.0, observe.Labels("method", "GET", "status", "200"))
+//	serve(requests, req)
+//
+// The slog mocks and matchers require Go 1.21.
+package observe
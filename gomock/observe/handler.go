@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+
+package observe
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.uber.org/mock/gomock"
+)
+
+// MockHandler is a mock of slog.Handler. It is written by hand rather than
+// generated, as log/slog is more recent than the Go version of the module.
+type MockHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMockRecorder
+}
+
+// MockHandlerMockRecorder is the mock recorder for MockHandler.
+type MockHandlerMockRecorder struct {
+	mock *MockHandler
+}
+
+// NewMockHandler creates a new mock instance.
+func NewMockHandler(ctrl *gomock.Controller) *MockHandler {
+	mock := &MockHandler{ctrl: ctrl}
+	mock.recorder = &MockHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled", ctx, level)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockHandlerMockRecorder) Enabled(ctx, level any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockHandler)(nil).Enabled), ctx, level)
+}
+
+// Handle mocks base method.
+func (m *MockHandler) Handle(ctx context.Context, record slog.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockHandlerMockRecorder) Handle(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockHandler)(nil).Handle), ctx, record)
+}
+
+// WithAttrs mocks base method.
+func (m *MockHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithAttrs", attrs)
+	ret0, _ := ret[0].(slog.Handler)
+	return ret0
+}
+
+// WithAttrs indicates an expected call of WithAttrs.
+func (mr *MockHandlerMockRecorder) WithAttrs(attrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithAttrs", reflect.TypeOf((*MockHandler)(nil).WithAttrs), attrs)
+}
+
+// WithGroup mocks base method.
+func (m *MockHandler) WithGroup(name string) slog.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithGroup", name)
+	ret0, _ := ret[0].(slog.Handler)
+	return ret0
+}
+
+// WithGroup indicates an expected call of WithGroup.
+func (mr *MockHandlerMockRecorder) WithGroup(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithGroup", reflect.TypeOf((*MockHandler)(nil).WithGroup), name)
+}
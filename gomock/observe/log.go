@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+
+package observe
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.uber.org/mock/gomock"
+)
+
+// NewLogHandler returns a mock of slog.Handler, whose records at level or
+// above are expected with EXPECT().Handle, and the records below it dropped
+// as slog.Logger does for disabled levels. The handlers it returns from
+// WithAttrs and WithGroup are the mock itself, so the records only have the
+// attributes of the calls logging them.
+func NewLogHandler(ctrl *gomock.Controller, level slog.Leveler) *MockHandler {
+	h := NewMockHandler(ctrl)
+	h.EXPECT().Enabled(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, l slog.Level) bool {
+		return l >= level.Level()
+	}).AnyTimes()
+	h.EXPECT().WithAttrs(gomock.Any()).Return(h).AnyTimes()
+	h.EXPECT().WithGroup(gomock.Any()).Return(h).AnyTimes()
+	return h
+}
+
+// LogRecord returns a matcher of the slog.Record of a log at level with the
+// message msg, having at least the attributes attrs, whose values are
+// compared with slog.Value.Equal.
+//
+// Example usage:
+//
+//	h.EXPECT().Handle(gomock.Any(), LogRecord(slog.LevelError, "request failed", slog.Int("status", 500)))
+func LogRecord(level slog.Level, msg string, attrs ...slog.Attr) gomock.Matcher {
+	return recordMatcher{level: level, msg: msg, attrs: attrs}
+}
+
+type recordMatcher struct {
+	level slog.Level
+	msg   string
+	attrs []slog.Attr
+}
+
+func (m recordMatcher) Matches(x any) bool {
+	r, ok := x.(slog.Record)
+	if !ok || r.Level != m.level || r.Message != m.msg {
+		return false
+	}
+	got := make(map[string]slog.Value)
+	r.Attrs(func(a slog.Attr) bool {
+		got[a.Key] = a.Value
+		return true
+	})
+	for _, want := range m.attrs {
+		if v, ok := got[want.Key]; !ok || !v.Equal(want.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m recordMatcher) String() string {
+	s := fmt.Sprintf("is a %v log %q", m.level, m.msg)
+	if len(m.attrs) > 0 {
+		s += " with " + formatAttrs(m.attrs)
+	}
+	return s
+}
+
+// Got formats the level, message and attributes of records.
+func (m recordMatcher) Got(x any) string {
+	r, ok := x.(slog.Record)
+	if !ok {
+		return fmt.Sprintf("%v (%T)", x, x)
+	}
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	if len(attrs) == 0 {
+		return fmt.Sprintf("%v log %q", r.Level, r.Message)
+	}
+	return fmt.Sprintf("%v log %q with %s", r.Level, r.Message, formatAttrs(attrs))
+}
+
+func formatAttrs(attrs []slog.Attr) string {
+	ss := make([]string, len(attrs))
+	for i, a := range attrs {
+		ss[i] = a.String()
+	}
+	return strings.Join(ss, " ")
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//go:build go1.21
+
+package observe_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/mock/gomock/observe"
+)
+
+func TestNewLogHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	h := observe.NewLogHandler(ctrl, slog.LevelInfo)
+	h.EXPECT().Handle(gomock.Any(), observe.LogRecord(slog.LevelInfo, "request served"))
+	h.EXPECT().Handle(gomock.Any(), observe.LogRecord(slog.LevelError, "request failed", slog.Int("status", 500)))
+
+	logger := slog.New(h).With("service", "api")
+	logger.Debug("serving", "path", "/")
+	logger.Info("request served", "status", 200)
+	logger.Error("request failed", "status", 500, "path", "/")
+}
+
+func TestLogRecord(t *testing.T) {
+	r := slog.NewRecord(time.Time{}, slog.LevelWarn, "slow request", 0)
+	r.AddAttrs(slog.Int("status", 200), slog.String("path", "/"))
+
+	for _, tc := range []struct {
+		m    gomock.Matcher
+		want bool
+	}{
+		{observe.LogRecord(slog.LevelWarn, "slow request"), true},
+		{observe.LogRecord(slog.LevelWarn, "slow request", slog.Int("status", 200)), true},
+		{observe.LogRecord(slog.LevelWarn, "slow request", slog.Int("status", 500)), false},
+		{observe.LogRecord(slog.LevelWarn, "slow request", slog.String("method", "GET")), false},
+		{observe.LogRecord(slog.LevelError, "slow request"), false},
+		{observe.LogRecord(slog.LevelWarn, "request"), false},
+	} {
+		if got := tc.m.Matches(r); got != tc.want {
+			t.Errorf("%v: Matches() = %v, want %v", tc.m, got, tc.want)
+		}
+	}
+
+	m := observe.LogRecord(slog.LevelError, "request failed", slog.Int("status", 500))
+	if got, want := m.String(), `is a ERROR log "request failed" with status=500`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := m.(gomock.GotFormatter).Got(r), `WARN log "slow request" with status=200 path=/`; got != want {
+		t.Errorf("Got() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observe
+
+import "go.uber.org/mock/gomock"
+
+//go:generate mockgen -destination mock_metrics.go -package observe -write_package_comment=false -source metrics.go
+
+// Counter is a metric adding up values, such as the number of requests
+// served, for each set of labels.
+type Counter interface {
+	Add(value float64, labels map[string]string)
+}
+
+// Histogram is a metric recording the distribution of values, such as the
+// latencies of requests, for each set of labels.
+type Histogram interface {
+	Record(value float64, labels map[string]string)
+}
+
+// Labels returns a matcher of the labels of a metric having at least the
+// given pairs of keys and values, leaving the other labels unconstrained. It
+// panics if kv does not hold pairs.
+//
+// Example usage:
+//
+//	Labels("method", "GET").Matches(map[string]string{"method": "GET", "status": "200"}) // returns true
+//	Labels("method", "GET").Matches(map[string]string{"method": "POST"}) // returns false
+func Labels(kv ...string) gomock.Matcher {
+	if len(kv)%2 != 0 {
+		panic("observe: Labels called with an odd number of arguments")
+	}
+	want := make(map[string]string, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		want[kv[i]] = kv[i+1]
+	}
+	return gomock.MapContaining(want)
+}
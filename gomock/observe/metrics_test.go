@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package observe_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/mock/gomock/observe"
+)
+
+func TestLabels(t *testing.T) {
+	m := observe.Labels("method", "GET", "status", "200")
+	if !m.Matches(map[string]string{"method": "GET", "status": "200", "path": "/"}) {
+		t.Errorf("%v should match labels with more keys", m)
+	}
+	if m.Matches(map[string]string{"method": "GET"}) || m.Matches(map[string]string{"method": "GET", "status": "500"}) {
+		t.Errorf("%v should not match labels missing a pair", m)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Labels to panic")
+		}
+	}()
+	observe.Labels("method")
+}
+
+func TestMockCounter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	requests := observe.NewMockCounter(ctrl)
+	latency := observe.NewMockHistogram(ctrl)
+	requests.EXPECT().Add(1.0, observe.Labels("status", "200")).Times(2)
+	latency.EXPECT().Record(gomock.Approx(0.1, 0.01), observe.Labels("status", "200"))
+
+	requests.Add(1, map[string]string{"status": "200", "method": "GET"})
+	requests.Add(1, map[string]string{"status": "200", "method": "POST"})
+	latency.Record(0.104, map[string]string{"status": "200"})
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import "strconv"
+
+// Page is a page of results returned by ReturnPages.
+type Page struct {
+	// Token is the page token argument requesting the page. A non-Matcher
+	// value is compared with Eq.
+	Token any
+	// Returns are the values returned by the call requesting the page,
+	// typically including the token of the next page.
+	Returns []any
+}
+
+// ReturnPages declares the pages returned by successive calls paging through
+// a listing. The nth call matches only if its argument at index tokenArg
+// matches the Token of the nth page, and returns the Returns of that page.
+// The matcher given for that argument when declaring the call is replaced,
+// and the call is expected once per page.
+//
+// Example usage:
+//
+//	m.EXPECT().List(gomock.Any(), gomock.Any()).ReturnPages(1,
+//	  gomock.Page{Token: "", Returns: []any{[]string{"a", "b"}, "p2", nil}},
+//	  gomock.Page{Token: "p2", Returns: []any{[]string{"c"}, "", nil}},
+//	)
+func (c *Call) ReturnPages(tokenArg int, pages ...Page) *Call {
+	c.t.Helper()
+
+	if tokenArg < 0 || tokenArg >= len(c.args) {
+		c.t.Fatalf("ReturnPages(%d, ...) called for a method with %d args [%s]",
+			tokenArg, len(c.args), c.origin)
+		return c
+	}
+	tokens := make([]Matcher, len(pages))
+	for i, page := range pages {
+		tokens[i] = toMatcher(page.Token)
+		c.checkReturns("ReturnPages", page.Returns)
+	}
+
+	c.lock()
+	c.args[tokenArg] = pageTokenMatcher{c: c, tokens: tokens}
+	c.unlock()
+	c.Times(len(pages))
+
+	var served int
+	c.addAction(func([]any) []any {
+		c.lock()
+		defer c.unlock()
+
+		page := pages[served]
+		served++
+		return page.Returns
+	})
+	return c
+}
+
+// pageTokenMatcher matches the token of the next page of a call declared with
+// ReturnPages. It is called while the Controller holds the lock of the call.
+type pageTokenMatcher struct {
+	c      *Call
+	tokens []Matcher
+}
+
+func (m pageTokenMatcher) Matches(x any) bool {
+	if m.c.numCalls >= len(m.tokens) {
+		return false
+	}
+	return m.tokens[m.c.numCalls].Matches(x)
+}
+
+func (m pageTokenMatcher) String() string {
+	if m.c.numCalls >= len(m.tokens) {
+		return "is the token of a page, but all pages were returned"
+	}
+	return "is the token of page " + strconv.Itoa(m.c.numCalls+1) + ", which " + m.tokens[m.c.numCalls].String()
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func (s *Subject) ListMethod(filter, pageToken string) ([]string, string, error) {
+	return nil, "", nil
+}
+
+func TestReturnPages(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ListMethod", "f", gomock.Any()).ReturnPages(1,
+		gomock.Page{Token: "", Returns: []any{[]string{"a", "b"}, "p2", nil}},
+		gomock.Page{Token: "p2", Returns: []any{[]string{"c"}, "", nil}},
+	)
+
+	var items []string
+	token := ""
+	for {
+		rets := ctrl.Call(subject, "ListMethod", "f", token)
+		items = append(items, rets[0].([]string)...)
+		if token = rets[1].(string); token == "" {
+			break
+		}
+	}
+	assertEqual(t, []string{"a", "b", "c"}, items)
+	ctrl.Finish()
+	reporter.assertPass("all pages were requested")
+}
+
+func TestReturnPages_WrongToken(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ListMethod", "f", gomock.Any()).ReturnPages(1,
+		gomock.Page{Token: "", Returns: []any{[]string{"a"}, "p2", nil}},
+		gomock.Page{Token: "p2", Returns: []any{[]string{"b"}, "", nil}},
+	)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ListMethod", "f", "p2")
+	}, "Unexpected call to", "is the token of page 1")
+}
+
+func TestReturnPages_MissingPage(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ListMethod", "f", gomock.Any()).ReturnPages(1,
+		gomock.Page{Token: "", Returns: []any{[]string{"a"}, "p2", nil}},
+		gomock.Page{Token: "p2", Returns: []any{[]string{"b"}, "", nil}},
+	)
+	ctrl.Call(subject, "ListMethod", "f", "")
+
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+}
+
+func TestReturnPages_InvalidReturns(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "ListMethod", "f", gomock.Any()).ReturnPages(1,
+			gomock.Page{Token: "", Returns: []any{"a", "", nil}},
+		)
+	}, "wrong type of argument 0 to ReturnPages")
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "ListMethod", "f", gomock.Any()).ReturnPages(2)
+	}, "ReturnPages(2, ...) called for a method with 2 args")
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type randSourceOption struct {
+	src    rand.Source
+	seed   int64
+	seeded bool
+}
+
+// WithRandSource makes the Controller draw the random numbers of the
+// features relying on randomness, available through Rand, from src. The
+// sequence of random numbers of a test is then the same from one run to the
+// next, as long as the mocks are called in the same order.
+func WithRandSource(src rand.Source) randSourceOption {
+	return randSourceOption{src: src}
+}
+
+// WithRandSeed is like WithRandSource with a source seeded with seed. The
+// Controllers not given a source get a seed of their own, which they log when
+// the test fails so that WithRandSeed can reproduce the failure:
+//
+//	gomock: random seed 1712345678901234567, use gomock.WithRandSeed(1712345678901234567) to reproduce
+func WithRandSeed(seed int64) randSourceOption {
+	return randSourceOption{src: rand.NewSource(seed), seed: seed, seeded: true}
+}
+
+func (o randSourceOption) apply(ctrl *Controller) {
+	if o.src == nil {
+		return
+	}
+	ctrl.rand = &randSource{src: o.src, seed: o.seed, seeded: o.seeded}
+}
+
+// randSource is the random source of a Controller, safe for concurrent use.
+type randSource struct {
+	mu     sync.Mutex
+	src    rand.Source
+	seed   int64
+	seeded bool // whether src was created from seed
+	used   bool
+}
+
+func (s *randSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.used = true
+	return s.src.Int63()
+}
+
+func (s *randSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+	s.seed, s.seeded = seed, true
+}
+
+// Rand returns the random number generator of the Controller, from the
+// source given to WithRandSource or WithRandSeed, or seeded with the current
+// time otherwise. Features of the Controller relying on randomness use it, as
+// can the actions of the expectations. It is safe for concurrent use.
+func (ctrl *Controller) Rand() *rand.Rand {
+	ctrl.lock()
+	defer ctrl.unlock()
+	return ctrl.randLocked()
+}
+
+// randLocked is like Rand, with ctrl.mu held.
+func (ctrl *Controller) randLocked() *rand.Rand {
+	if ctrl.rand == nil {
+		seed := time.Now().UnixNano()
+		ctrl.rand = &randSource{src: rand.NewSource(seed), seed: seed, seeded: true}
+	}
+	return rand.New(ctrl.rand)
+}
+
+// logRandSeed logs the seed of the random numbers used by a failed test.
+func (ctrl *Controller) logRandSeed() {
+	ctrl.T.Helper()
+
+	s := ctrl.rand
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.used || !s.seeded {
+		return
+	}
+	t, ok := unwrapTestReporter(ctrl.T).(interface {
+		Failed() bool
+		Logf(string, ...any)
+	})
+	if ok && t.Failed() {
+		t.Logf("gomock: random seed %d, use gomock.WithRandSeed(%d) to reproduce", s.seed, s.seed)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithRandSeed_Reproducible(t *testing.T) {
+	draw := func() []int {
+		ctrl := gomock.NewController(t, gomock.WithRandSeed(42))
+		r := ctrl.Rand()
+		return []int{r.Intn(1000), r.Intn(1000), ctrl.Rand().Intn(1000)}
+	}
+	first, second := draw(), draw()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("draws differ: %v and %v", first, second)
+		}
+	}
+}
+
+func TestWithRandSource(t *testing.T) {
+	ctrl := gomock.NewController(t, gomock.WithRandSource(rand.NewSource(7)))
+	if got, want := ctrl.Rand().Int63(), rand.New(rand.NewSource(7)).Int63(); got != want {
+		t.Errorf("Rand().Int63() = %d, want %d", got, want)
+	}
+}
+
+func TestRandSeedLoggedOnFailure(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	ctrl.Rand().Int()
+	ctrl.RecordCall(new(Subject), "FooMethod", "argument")
+
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
+	re := regexp.MustCompile(`gomock: random seed (-?\d+), use gomock.WithRandSeed\((-?\d+)\) to reproduce`)
+	m := re.FindStringSubmatch(strings.Join(reporter.log, "\n"))
+	if m == nil || m[1] != m[2] {
+		t.Errorf("seed not logged: %q", reporter.log)
+	}
+}
+
+func TestRandSeedNotLogged(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []gomock.ControllerOption
+		use  bool
+		fail bool
+	}{
+		{name: "passed", use: true},
+		{name: "unused", fail: true},
+		{name: "unknown seed", opts: []gomock.ControllerOption{gomock.WithRandSource(rand.NewSource(1))}, use: true, fail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := NewErrorReporter(t)
+			ctrl := gomock.NewController(reporter, tt.opts...)
+			if tt.use {
+				ctrl.Rand().Int()
+			}
+			if tt.fail {
+				reporter.Errorf("failure")
+			}
+			ctrl.Finish()
+			for _, entry := range reporter.log {
+				if strings.Contains(entry, "random seed") {
+					t.Errorf("seed logged: %q", entry)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+// Repeat declares a burst of n calls like c, each expected once and after
+// the previous one, and calls customize with the index and the expectation
+// of each call, c being the first, so that they can differ in what they
+// return or match. The clones share the actions and OnExhausted callbacks
+// declared on c before Repeat, and its prerequisites. It returns c.
+//
+// Example usage:
+//
+//	m.EXPECT().Fetch(gomock.Any()).Repeat(3, func(i int, c *gomock.Call) {
+//	  if i < 2 {
+//	    c.Return(nil, errUnavailable)
+//	  } else {
+//	    c.Return(data, nil)
+//	  }
+//	})
+func (c *Call) Repeat(n int, customize func(i int, c *Call)) *Call {
+	c.t.Helper()
+
+	if n < 1 {
+		c.t.Fatalf("Repeat(%d, ...) called for %T.%v: at least 1 call must be declared [%s]",
+			n, c.receiver, c.method, c.origin)
+		return c
+	}
+	if c.ctrl == nil {
+		c.t.Fatalf("Repeat called for %T.%v, which is not recorded by a Controller [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+
+	c.Times(1)
+	calls := []*Call{c}
+	for i := 1; i < n; i++ {
+		clone := c.clone()
+		clone.preReqs = append(clone.preReqs, calls[i-1])
+
+		c.ctrl.lock()
+		c.ctrl.expectedCalls.Add(clone)
+		if len(c.ctrl.scopes) > 0 {
+			c.ctrl.addToScope(clone)
+		}
+		c.ctrl.unlock()
+		calls = append(calls, clone)
+	}
+	if customize != nil {
+		for i, call := range calls {
+			customize(i, call)
+		}
+	}
+	return c
+}
+
+// clone returns a copy of the expectation c, expected once and not called
+// yet.
+func (c *Call) clone() *Call {
+	c.lock()
+	defer c.unlock()
+
+	return &Call{
+		t:           c.t,
+		receiver:    c.receiver,
+		method:      c.method,
+		methodType:  c.methodType,
+		args:        append([]Matcher(nil), c.args...),
+		origin:      c.origin,
+		ctrl:        c.ctrl,
+		preReqs:     append([]*Call(nil), c.preReqs...),
+		tags:        append([]string(nil), c.tags...),
+		name:        c.name,
+		within:      c.within,
+		deadline:    c.deadline,
+		observer:    c.observer,
+		ctx:         c.ctx,
+		results:     c.results,
+		minCalls:    1,
+		maxCalls:    1,
+		actions:     append([]func([]any) []any(nil), c.actions...),
+		notify:      append(c.notify[:0:0], c.notify...),
+		onExhausted: append(c.onExhausted[:0:0], c.onExhausted...),
+	}
+}
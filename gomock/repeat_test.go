@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestRepeat(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Repeat(3, func(i int, c *gomock.Call) {
+		c.Return(i + 1)
+	})
+
+	for _, want := range []int{1, 2, 3} {
+		if got := ctrl.Call(subject, "FooMethod", "argument")[0]; got != want {
+			t.Errorf("call returned %v, want %v", got, want)
+		}
+	}
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}, "has already been called the max number of times")
+}
+
+func TestRepeat_MissingCall(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Repeat(2, nil)
+
+	ctrl.Call(subject, "FooMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+}
+
+func TestRepeat_Prerequisites(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	first := ctrl.RecordCall(subject, "BarMethod", "first")
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).After(first).Repeat(2, nil)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}, "Unexpected call to", "doesn't have a prerequisite call satisfied")
+}
+
+func TestRepeat_OnExhausted(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	var exhausted int
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).OnExhausted(func() { exhausted++ }).Repeat(3, nil)
+
+	for want := 1; want <= 3; want++ {
+		ctrl.Call(subject, "FooMethod", "argument")
+		if exhausted != want {
+			t.Errorf("OnExhausted called %d times after call %d, want %d", exhausted, want, want)
+		}
+	}
+}
+
+func TestRepeat_Invalid(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").Repeat(0, nil)
+	}, "Repeat(0, ...) called for *gomock_test.Subject.FooMethod")
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type replayOption struct{}
+
+// WithReplay makes the Controller log, when a test fails because of an
+// unexpected or a missing call, the calls its mocks received so far written
+// as expectations, ready to be pasted into the test:
+//
+//	mockStore.EXPECT().Get("a")
+//	mockStore.EXPECT().Put("a", 1).Times(2)
+//
+// The mocks are named after their type, and arguments that cannot be written
+// as literals, such as contexts and functions, are matched by gomock.Any().
+func WithReplay() replayOption {
+	return replayOption{}
+}
+
+func (replayOption) apply(ctrl *Controller) {
+	ctrl.replay = new(replayLog)
+}
+
+// replayLog records the calls received by the mocks of a Controller.
+type replayLog struct {
+	calls []replayCall
+}
+
+type replayCall struct {
+	receiver any
+	method   string
+	args     []any
+}
+
+func (l *replayLog) record(receiver any, method string, args []any) {
+	l.calls = append(l.calls, replayCall{receiver: receiver, method: method, args: args})
+}
+
+// script returns the recorded calls written as expectations, one per line.
+// Consecutive identical calls are merged into an expectation with Times.
+func (l *replayLog) script() string {
+	names := make(map[any]string)
+	taken := make(map[string]int)
+	var lines []string
+	var times []int
+	for _, call := range l.calls {
+		name, ok := names[call.receiver]
+		if !ok {
+			name = mockVarName(call.receiver)
+			if taken[name]++; taken[name] > 1 {
+				name = fmt.Sprintf("%s%d", name, taken[name])
+			}
+			names[call.receiver] = name
+		}
+		args := make([]string, len(call.args))
+		for i, arg := range call.args {
+			args[i] = replayArg(arg)
+		}
+		line := fmt.Sprintf("%s.EXPECT().%s(%s)", name, call.method, strings.Join(args, ", "))
+		if n := len(lines); n > 0 && lines[n-1] == line {
+			times[n-1]++
+			continue
+		}
+		lines = append(lines, line)
+		times = append(times, 1)
+	}
+
+	var sb strings.Builder
+	for i, line := range lines {
+		sb.WriteString("\t" + line)
+		if times[i] > 1 {
+			fmt.Fprintf(&sb, ".Times(%d)", times[i])
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// mockVarName returns a variable name for a mock, after its type.
+func mockVarName(receiver any) string {
+	t := reflect.TypeOf(receiver)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if name == "" {
+		return "mock"
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// replayArg writes arg as a Go literal, or as gomock.Any() if it has none.
+func replayArg(arg any) string {
+	if arg == nil {
+		return "nil"
+	}
+	if _, ok := arg.(context.Context); ok {
+		return "gomock.Any()"
+	}
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return "gomock.Any()"
+	case reflect.Ptr:
+		if v.IsNil() {
+			return fmt.Sprintf("(%v)(nil)", v.Type())
+		}
+		if v.Elem().Kind() != reflect.Struct {
+			return "gomock.Any()"
+		}
+		return "&" + fmt.Sprintf("%#v", v.Elem().Interface())
+	}
+	return fmt.Sprintf("%#v", arg)
+}
+
+// logReplay logs the calls received by the mocks, if WithReplay is used.
+func (ctrl *Controller) logReplay() {
+	ctrl.T.Helper()
+
+	if ctrl.replay == nil || len(ctrl.replay.calls) == 0 {
+		return
+	}
+	msg := "calls received, as expectations:\n" + ctrl.replay.script()
+	if l, ok := ctrl.T.(interface{ Logf(string, ...any) }); ok {
+		l.Logf("%s", msg)
+		return
+	}
+	ctrl.T.Errorf("%s", msg)
+}
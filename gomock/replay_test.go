@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithReplay(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithReplay())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "a").Times(2)
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 2)
+	ctrl.Call(subject, "FooMethod", "a")
+	ctrl.Call(subject, "FooMethod", "a")
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 2)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "BarMethod", "b")
+	}, "Unexpected call to")
+
+	want := "calls received, as expectations:\n" +
+		"\tsubject.EXPECT().FooMethod(\"a\").Times(2)\n" +
+		"\tsubject.EXPECT().ActOnTestStructMethod(gomock_test.TestStruct{Number:1, Message:\"\"}, 2)\n" +
+		"\tsubject.EXPECT().BarMethod(\"b\")\n"
+	var found bool
+	for _, entry := range reporter.log {
+		found = found || entry == want
+	}
+	if !found {
+		t.Errorf("log %q does not contain %q", reporter.log, want)
+	}
+}
+
+func TestWithoutReplay(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "BarMethod", "b")
+	}, "Unexpected call to")
+	for _, entry := range reporter.log {
+		if strings.Contains(entry, "calls received") {
+			t.Errorf("unexpected replay in log: %q", entry)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// sampler is implemented by matchers of a single value, such as the ones
+// returned by Eq, which SelfCheck matches against the parameters of the
+// mocked method.
+type sampler interface {
+	sample() any
+}
+
+func (e eqMatcher) sample() any { return e.x }
+
+func (m eqTMatcher[T]) sample() any { return m.want }
+
+// SelfCheck checks the expectations declared so far, before the code under
+// test runs, and fails the test for those that can never be met, which would
+// otherwise be reported as missing calls at the end of the test:
+//
+//   - a value expected as an argument, such as by Eq, that the parameter of
+//     the method cannot hold, such as an int64 for an int parameter, or that
+//     its matcher does not match;
+//   - nil expected for a parameter that is not nillable;
+//   - values given to Return that the method cannot return;
+//   - a minimum number of calls greater than the maximum.
+//
+// Every problem found is reported.
+func (ctrl *Controller) SelfCheck() {
+	ctrl.T.Helper()
+
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	calls, _ := ctrl.expectedCalls.store.All()
+	sort.Slice(calls, func(i, j int) bool { return calls[i].origin < calls[j].origin })
+
+	for _, c := range calls {
+		for _, problem := range c.selfCheck() {
+			ctrl.T.Errorf("gomock: expected call %T.%s at %s %s", c.receiver, c.method, c.origin, problem)
+		}
+	}
+}
+
+// selfCheck returns the reasons why c can never be met.
+func (c *Call) selfCheck() []string {
+	var problems []string
+	mt := c.methodType
+	for i, m := range c.args {
+		if i >= mt.NumIn() || mt.IsVariadic() && i >= mt.NumIn()-1 {
+			// The variadic arguments may be matched one by one or as a slice.
+			break
+		}
+		if problem := checkArgMatcher(m, mt.In(i)); problem != "" {
+			problems = append(problems, fmt.Sprintf("never matches argument %d: %s", i, problem))
+		}
+	}
+	if c.results != nil {
+		if problem := checkResults(c.results, mt); problem != "" {
+			problems = append(problems, "cannot return its results: "+problem)
+		}
+	}
+	if c.minCalls > c.maxCalls {
+		problems = append(problems, fmt.Sprintf("expects at least %d calls, but at most %d", c.minCalls, c.maxCalls))
+	}
+	return problems
+}
+
+// checkArgMatcher returns why m can never match an argument of type t, or ""
+// if it may.
+func checkArgMatcher(m Matcher, t reflect.Type) string {
+	if _, ok := m.(nilMatcher); ok {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return ""
+		}
+		return fmt.Sprintf("%v is not nillable", t)
+	}
+	s, ok := m.(sampler)
+	if !ok || s.sample() == nil {
+		return ""
+	}
+	x := s.sample()
+	xt := reflect.TypeOf(x)
+	if !xt.AssignableTo(t) {
+		return fmt.Sprintf("%v (%T) is not a %v", x, x, t)
+	}
+	// Arguments are passed as the dynamic type of the parameter.
+	v := reflect.New(t).Elem()
+	v.Set(reflect.ValueOf(x))
+	if arg := v.Interface(); !m.Matches(arg) {
+		return fmt.Sprintf("%v does not match %v (%T)", m, arg, arg)
+	}
+	return ""
+}
+
+// checkResults returns why rets cannot be returned by a method of type mt, or
+// "" if they can.
+func checkResults(rets []any, mt reflect.Type) string {
+	if len(rets) != mt.NumOut() {
+		return fmt.Sprintf("got %d values, want %d", len(rets), mt.NumOut())
+	}
+	for i, ret := range rets {
+		want := mt.Out(i)
+		if ret == nil {
+			switch want.Kind() {
+			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+				continue
+			}
+			return fmt.Sprintf("result %d is nil, but %v is not nillable", i, want)
+		}
+		if got := reflect.TypeOf(ret); !got.AssignableTo(want) {
+			return fmt.Sprintf("result %d is a %v, not assignable to %v", i, got, want)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func (s *Subject) FloatMethod(x float64) error {
+	return nil
+}
+
+func TestSelfCheck(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, int64(2))
+	ctrl.RecordCall(subject, "FooMethod", nil)
+	ctrl.RecordCall(subject, "FloatMethod", math.NaN())
+	ctrl.RecordCall(subject, "BarMethod", "a").MinTimes(3).MaxTimes(2)
+	ctrl.SelfCheck()
+
+	for _, want := range []string{
+		"never matches argument 1: 2 (int64) is not a int",
+		"never matches argument 0: string is not nillable",
+		"never matches argument 0: is equal to NaN (float64) does not match NaN (float64)",
+		"expects at least 3 calls, but at most 2",
+	} {
+		var found bool
+		for _, entry := range reporter.log {
+			found = found || strings.Contains(entry, want)
+		}
+		if !found {
+			t.Errorf("log %q does not contain %q", reporter.log, want)
+		}
+	}
+	if len(reporter.log) != 4 {
+		t.Errorf("got %d problems, want 4: %q", len(reporter.log), reporter.log)
+	}
+}
+
+func TestSelfCheck_Valid(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 2).Return(3)
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).AnyTimes()
+	ctrl.RecordCall(subject, "SetArgMethodInterface", nil, 1, gomock.EqT("a"))
+	ctrl.RecordCall(subject, "VariadicMethod", 1, "a", "b")
+	ctrl.SelfCheck()
+
+	reporter.assertPass("valid expectations")
+}
+
+func TestSelfCheck_Stringer(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	stringer := &StringerSubject{ctrl: ctrl}
+
+	ctrl.RecordCall(stringer, "String").Return("stringer").AnyTimes()
+	ctrl.RecordCall(subject, "FooMethod", stringer)
+	ctrl.SelfCheck()
+
+	want := "never matches argument 0: *gomock_test.StringerSubject (*gomock_test.StringerSubject) is not a string"
+	if len(reporter.log) != 1 || !strings.Contains(reporter.log[0], want) {
+		t.Errorf("log %q does not contain %q", reporter.log, want)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+// Snapshot is the state of the expectations of a Controller, as captured by
+// Controller.Snapshot.
+type Snapshot struct {
+	ctrl                *Controller
+	expected, exhausted []*Call
+	calls               map[*Call]callState
+}
+
+// callState is the part of a Call changed by the calls it matches.
+type callState struct {
+	numCalls  int
+	preReqs   []*Call
+	received  [][]any
+	seqServed int
+}
+
+// Snapshot captures the expectations of the Controller and the calls each has
+// matched so far, with their arguments and the position in the values of
+// ReturnSeq they reached, to be restored with Restore. It lets
+// scenarios share a common setup and then diverge:
+//
+//	// Common setup.
+//	m.EXPECT().Open().Return(nil)
+//	st := ctrl.Snapshot()
+//	for _, tc := range cases {
+//	  ctrl.Restore(st)
+//	  // Expectations and calls of the case.
+//	}
+//
+// The options of the expectations, such as their return values, are not
+// captured: changing them after the snapshot affects the restored state too.
+func (ctrl *Controller) Snapshot() *Snapshot {
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	expected, exhausted := ctrl.expectedCalls.store.All()
+	st := &Snapshot{
+		ctrl:      ctrl,
+		expected:  expected,
+		exhausted: exhausted,
+		calls:     make(map[*Call]callState),
+	}
+	for _, calls := range [][]*Call{expected, exhausted} {
+		for _, c := range calls {
+			st.calls[c] = callState{
+				numCalls:  c.numCalls,
+				preReqs:   append([]*Call(nil), c.preReqs...),
+				received:  append([][]any(nil), c.received...),
+				seqServed: c.seqServed,
+			}
+		}
+	}
+	return st
+}
+
+// Restore resets the expectations of the Controller to those captured by st,
+// and the calls each has matched to those at the time of the snapshot. Expectations declared since are dropped. A snapshot can be
+// restored any number of times, but only to the Controller that took it.
+func (ctrl *Controller) Restore(st *Snapshot) {
+	ctrl.T.Helper()
+
+	if st.ctrl != ctrl {
+		ctrl.T.Fatalf("gomock: Restore of a Snapshot of another Controller")
+		return
+	}
+
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	store := ctrl.expectedCalls.store
+	expected, exhausted := store.All()
+	for _, calls := range [][]*Call{expected, exhausted} {
+		for _, c := range calls {
+			store.Drop(c)
+		}
+	}
+	for c, state := range st.calls {
+		c.numCalls = state.numCalls
+		c.preReqs = append([]*Call(nil), state.preReqs...)
+		c.received = append([][]any(nil), state.received...)
+		c.seqServed = state.seqServed
+	}
+	for _, c := range st.expected {
+		store.Add(c)
+	}
+	for _, c := range st.exhausted {
+		store.Add(c)
+		store.Remove(c)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestSnapshot(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	open := ctrl.RecordCall(subject, "FooMethod", "open").Times(2)
+	ctrl.RecordCall(subject, "BarMethod", "bar").After(open)
+	ctrl.Call(subject, "FooMethod", "open")
+	st := ctrl.Snapshot()
+
+	for _, arg := range []string{"a", "b"} {
+		ctrl.Restore(st)
+		ctrl.RecordCall(subject, "FooMethod", arg)
+		ctrl.Call(subject, "FooMethod", "open")
+		ctrl.Call(subject, "BarMethod", "bar")
+		ctrl.Call(subject, "FooMethod", arg)
+		if !ctrl.Satisfied() {
+			t.Errorf("expected the expectations of case %q to be satisfied", arg)
+		}
+	}
+
+	ctrl.Restore(st)
+	reporter.assertFatal(func() {
+		// The expectation of the last case is dropped.
+		ctrl.Call(subject, "FooMethod", "b")
+	}, "Unexpected call to")
+	reporter.assertFatal(func() {
+		// The prerequisite is not satisfied anymore.
+		ctrl.Call(subject, "BarMethod", "bar")
+	}, "doesn't have a prerequisite call satisfied")
+	ctrl.Call(subject, "FooMethod", "open")
+	ctrl.Call(subject, "BarMethod", "bar")
+	ctrl.Finish()
+}
+
+func TestSnapshot_ReturnSeqAndReceivedArgs(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	call := ctrl.RecordCall(subject, "FooMethod", gomock.Any()).ReturnSeq([]any{1}, []any{2}, []any{3}).AnyTimes()
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "a"))
+	st := ctrl.Snapshot()
+
+	for i := 0; i < 2; i++ {
+		ctrl.Restore(st)
+		assertEqual(t, [][]any{{"a"}}, call.ReceivedArgs())
+		assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "b"))
+		assertEqual(t, []any{3}, ctrl.Call(subject, "FooMethod", "c"))
+		assertEqual(t, [][]any{{"a"}, {"b"}, {"c"}}, call.ReceivedArgs())
+	}
+	ctrl.Finish()
+}
+
+func TestRestore_OtherController(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	_, other := createFixtures(t)
+
+	reporter.assertFatal(func() {
+		ctrl.Restore(other.Snapshot())
+	}, "Restore of a Snapshot of another Controller")
+}
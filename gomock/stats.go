@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ExpectationStats summarizes the expectations declared by the tests of a
+// test binary, to track over time how heavily the tests rely on mocks.
+type ExpectationStats struct {
+	// Expectations is the number of expectations declared.
+	Expectations int `json:"expectations"`
+	// Tests is the number of tests which declared expectations, counting
+	// each subtest on its own.
+	Tests int `json:"tests"`
+	// PerTest is the average number of expectations of those tests.
+	PerTest float64 `json:"expectations_per_test"`
+	// Mocks are the numbers of expectations on each type of mock, the most
+	// mocked first.
+	Mocks []MockStats `json:"mocks"`
+}
+
+// MockStats is the number of expectations declared on a type of mock.
+type MockStats struct {
+	Type         string `json:"type"`
+	Expectations int    `json:"expectations"`
+}
+
+// statsCollector counts the expectations declared while
+// RunWithExpectationStats runs the tests.
+type statsCollector struct {
+	mu           sync.Mutex
+	expectations int
+	tests        map[any]bool
+	mocks        map[string]int
+}
+
+// expectationStats is the collector of the running RunWithExpectationStats,
+// if any.
+var expectationStats atomic.Pointer[statsCollector]
+
+// record counts an expectation declared on receiver by the test of ctrl.
+func (s *statsCollector) record(ctrl *Controller, receiver any) {
+	var test any = ctrl
+	if n, ok := ctrl.T.(interface{ Name() string }); ok {
+		test = n.Name()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expectations++
+	s.tests[test] = true
+	s.mocks[fmt.Sprintf("%T", receiver)]++
+}
+
+func (s *statsCollector) stats() ExpectationStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := ExpectationStats{
+		Expectations: s.expectations,
+		Tests:        len(s.tests),
+		Mocks:        make([]MockStats, 0, len(s.mocks)),
+	}
+	if stats.Tests > 0 {
+		stats.PerTest = float64(stats.Expectations) / float64(stats.Tests)
+	}
+	for typ, n := range s.mocks {
+		stats.Mocks = append(stats.Mocks, MockStats{Type: typ, Expectations: n})
+	}
+	sort.Slice(stats.Mocks, func(i, j int) bool {
+		a, b := stats.Mocks[i], stats.Mocks[j]
+		if a.Expectations != b.Expectations {
+			return a.Expectations > b.Expectations
+		}
+		return a.Type < b.Type
+	})
+	return stats
+}
+
+// RunWithExpectationStats runs the tests with m, counting the expectations
+// they declare, and writes the resulting ExpectationStats to path as JSON. It
+// returns the exit code of the tests, or 1 if the stats cannot be written.
+// It is meant to be called from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(gomock.RunWithExpectationStats(m, "testdata/expectations.json"))
+//	}
+//
+// Comparing the stats from one version of the code to the next shows which
+// interfaces are mocked the most, and which tests declare more expectations
+// than they should.
+func RunWithExpectationStats(m interface{ Run() int }, path string) int {
+	s := &statsCollector{tests: make(map[any]bool), mocks: make(map[string]int)}
+	expectationStats.Store(s)
+	defer expectationStats.Store(nil)
+
+	code := m.Run()
+
+	data, err := json.MarshalIndent(s.stats(), "", "  ")
+	if err == nil {
+		err = os.WriteFile(path, append(data, '\n'), 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gomock: writing expectation stats: %v\n", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	return code
+}
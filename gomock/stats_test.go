@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+type runFunc func() int
+
+func (f runFunc) Run() int { return f() }
+
+func TestRunWithExpectationStats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "expectations.json")
+
+	code := gomock.RunWithExpectationStats(runFunc(func() int {
+		t.Run("first", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			subject := new(Subject)
+			ctrl.RecordCall(subject, "FooMethod", "a").AnyTimes()
+			ctrl.RecordCall(subject, "FooMethod", "b").AnyTimes()
+			ctrl.RecordCall(new(NamedSubject), "FooMethod", "c").AnyTimes()
+		})
+		t.Run("second", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctrl.RecordCall(new(Subject), "FooMethod", "d").AnyTimes()
+		})
+		return 3
+	}), path)
+	assertEqual(t, 3, code)
+
+	// Expectations declared after the run are not counted.
+	ctrl := gomock.NewController(t)
+	ctrl.RecordCall(new(Subject), "FooMethod", "e").AnyTimes()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stats gomock.ExpectationStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, gomock.ExpectationStats{
+		Expectations: 4,
+		Tests:        2,
+		PerTest:      2,
+		Mocks: []gomock.MockStats{
+			{Type: "*gomock_test.Subject", Expectations: 3},
+			{Type: "*gomock_test.NamedSubject", Expectations: 1},
+		},
+	}, stats)
+}
+
+func TestRunWithExpectationStats_WriteError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "expectations.json")
+
+	code := gomock.RunWithExpectationStats(runFunc(func() int { return 0 }), path)
+	assertEqual(t, 1, code)
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+// ExpectationStore keeps the expectations of a Controller, by mock and
+// method, telling those still expected from those exhausted, that is called
+// their maximum number of times or dropped as the prerequisites of a later
+// call. The Controller decides which expectations match a call and when they
+// are exhausted; the store decides how they are kept and in which order they
+// are tried. A custom store, given to WithExpectationStore, can thus index the
+// expectations of the mocks with many of them, try some expectations first,
+// or persist them, without forking the Controller.
+//
+// The Controller serializes its calls to the store, which need not be safe
+// for concurrent use.
+type ExpectationStore interface {
+	// Add adds an expectation, not exhausted, for its mock and method.
+	Add(call *Call)
+	// Remove marks an expectation added before as exhausted.
+	Remove(call *Call)
+	// Drop removes an expectation, whether it is exhausted or not.
+	Drop(call *Call)
+	// Expected returns the expectations of the method of the mock that are
+	// not exhausted, in the order the Controller tries to match them with a
+	// call.
+	Expected(receiver any, method string) []*Call
+	// Exhausted returns the exhausted expectations of the method of the
+	// mock, in the order they were exhausted.
+	Exhausted(receiver any, method string) []*Call
+	// All returns every expectation of the store, those not exhausted then
+	// those exhausted, in the order of Expected and Exhausted for each
+	// method of each mock.
+	All() (expected, exhausted []*Call)
+}
+
+type expectationStoreOption struct {
+	store ExpectationStore
+}
+
+// WithExpectationStore makes the Controller keep its expectations in store
+// rather than in memory, in the order they are declared.
+func WithExpectationStore(store ExpectationStore) expectationStoreOption {
+	return expectationStoreOption{store: store}
+}
+
+func (o expectationStoreOption) apply(ctrl *Controller) {
+	ctrl.expectedCalls.store = o.store
+}
+
+// memoryStore is the default ExpectationStore, keeping the expectations in
+// the order they are added.
+type memoryStore struct {
+	expected  map[callSetKey][]*Call
+	exhausted map[callSetKey][]*Call
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		expected:  make(map[callSetKey][]*Call),
+		exhausted: make(map[callSetKey][]*Call),
+	}
+}
+
+func (s *memoryStore) Add(call *Call) {
+	key := callSetKey{call.receiver, call.method}
+	s.expected[key] = append(s.expected[key], call)
+}
+
+func (s *memoryStore) Remove(call *Call) {
+	key := callSetKey{call.receiver, call.method}
+	calls := s.expected[key]
+	for i, c := range calls {
+		if c == call {
+			// maintain order for remaining calls
+			s.expected[key] = append(calls[:i], calls[i+1:]...)
+			s.exhausted[key] = append(s.exhausted[key], call)
+			break
+		}
+	}
+}
+
+func (s *memoryStore) Drop(call *Call) {
+	key := callSetKey{call.receiver, call.method}
+	for _, m := range []map[callSetKey][]*Call{s.expected, s.exhausted} {
+		calls := m[key]
+		for i, c := range calls {
+			if c == call {
+				m[key] = append(calls[:i:i], calls[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (s *memoryStore) Expected(receiver any, method string) []*Call {
+	return s.expected[callSetKey{receiver, method}]
+}
+
+func (s *memoryStore) Exhausted(receiver any, method string) []*Call {
+	return s.exhausted[callSetKey{receiver, method}]
+}
+
+func (s *memoryStore) All() (expected, exhausted []*Call) {
+	for _, calls := range s.expected {
+		expected = append(expected, calls...)
+	}
+	for _, calls := range s.exhausted {
+		exhausted = append(exhausted, calls...)
+	}
+	return expected, exhausted
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+type storeKey struct {
+	receiver any
+	method   string
+}
+
+// latestFirstStore is an ExpectationStore trying the latest expectations
+// first.
+type latestFirstStore struct {
+	expected, exhausted map[storeKey][]*gomock.Call
+	adds                int
+}
+
+func newLatestFirstStore() *latestFirstStore {
+	return &latestFirstStore{
+		expected:  make(map[storeKey][]*gomock.Call),
+		exhausted: make(map[storeKey][]*gomock.Call),
+	}
+}
+
+func (s *latestFirstStore) Add(call *gomock.Call) {
+	key := storeKey{call.Receiver(), call.Method()}
+	s.expected[key] = append([]*gomock.Call{call}, s.expected[key]...)
+	s.adds++
+}
+
+func (s *latestFirstStore) Remove(call *gomock.Call) {
+	key := storeKey{call.Receiver(), call.Method()}
+	if s.drop(s.expected, key, call) {
+		s.exhausted[key] = append(s.exhausted[key], call)
+	}
+}
+
+func (s *latestFirstStore) Drop(call *gomock.Call) {
+	key := storeKey{call.Receiver(), call.Method()}
+	_ = s.drop(s.expected, key, call) || s.drop(s.exhausted, key, call)
+}
+
+func (s *latestFirstStore) drop(m map[storeKey][]*gomock.Call, key storeKey, call *gomock.Call) bool {
+	for i, c := range m[key] {
+		if c == call {
+			m[key] = append(m[key][:i:i], m[key][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *latestFirstStore) Expected(receiver any, method string) []*gomock.Call {
+	return s.expected[storeKey{receiver, method}]
+}
+
+func (s *latestFirstStore) Exhausted(receiver any, method string) []*gomock.Call {
+	return s.exhausted[storeKey{receiver, method}]
+}
+
+func (s *latestFirstStore) All() (expected, exhausted []*gomock.Call) {
+	for _, calls := range s.expected {
+		expected = append(expected, calls...)
+	}
+	for _, calls := range s.exhausted {
+		exhausted = append(exhausted, calls...)
+	}
+	return expected, exhausted
+}
+
+func TestWithExpectationStore(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	store := newLatestFirstStore()
+	ctrl := gomock.NewController(reporter, gomock.WithExpectationStore(store))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Return(1).AnyTimes()
+	ctrl.RecordCall(subject, "FooMethod", "argument").Return(2)
+
+	assertEqual(t, 2, store.adds)
+	assertEqual(t, 2, ctrl.Call(subject, "FooMethod", "argument")[0])
+	assertEqual(t, 1, ctrl.Call(subject, "FooMethod", "argument")[0])
+	assertEqual(t, 1, len(store.Exhausted(subject, "FooMethod")))
+
+	ctrl.Finish()
+	reporter.assertPass("Expected all calls")
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import (
+	"reflect"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Stub declares request/response expectations for an RPC-style method, that
+// is a method whose last parameter is a request and which returns a response
+// and an error, such as:
+//
+//	Get(ctx context.Context, req *GetRequest) (*GetResponse, error)
+//
+// Every other parameter, typically a context, matches anything. The
+// expectations declared by a Stub may be called any number of times.
+//
+// Example usage:
+//
+//	ctrl.Stub(mockClient, "Get").
+//	  When(&GetRequest{ID: 1}).Reply(&GetResponse{Name: "one"}).
+//	  When(&GetRequest{ID: 2}).Reply(&GetResponse{Name: "two"}).
+//	  Else(ErrNotFound)
+type Stub struct {
+	ctrl       *Controller
+	receiver   any
+	method     string
+	methodType reflect.Type
+	when       Matcher // request matcher awaiting its Reply
+}
+
+// Stub returns a Stub for the given RPC-style method of a mock. It fails the
+// test if the method does not have the shape described in the Stub
+// documentation.
+func (ctrl *Controller) Stub(receiver any, method string) *Stub {
+	ctrl.T.Helper()
+
+	recv := reflect.ValueOf(receiver)
+	m, ok := recv.Type().MethodByName(method)
+	if !ok {
+		ctrl.T.Fatalf("gomock: failed finding method %s on %T", method, receiver)
+		return nil
+	}
+	mt := recv.Method(m.Index).Type()
+	if mt.NumIn() == 0 || mt.IsVariadic() || mt.NumOut() != 2 || mt.Out(1) != errorType {
+		ctrl.T.Fatalf("gomock: %T.%v is not a request/response method: %v", receiver, method, mt)
+		return nil
+	}
+	return &Stub{ctrl: ctrl, receiver: receiver, method: method, methodType: mt}
+}
+
+// When sets the request matched by the next Reply. A non-Matcher value is
+// compared with Eq.
+func (s *Stub) When(req any) *Stub {
+	s.when = toMatcher(req)
+	return s
+}
+
+// Reply declares resp as the response to the request given to the preceding
+// When. Requests are matched in the order their replies are declared.
+func (s *Stub) Reply(resp any) *Stub {
+	s.ctrl.T.Helper()
+
+	if s.when == nil {
+		s.ctrl.T.Fatalf("gomock: Reply for %T.%v must follow When", s.receiver, s.method)
+		return s
+	}
+	s.ctrl.RecordCallWithMethodType(s.receiver, s.method, s.methodType, s.args(s.when)...).AnyTimes().Return(resp, nil)
+	s.when = nil
+	return s
+}
+
+// Else declares err as the error returned, along with a zero response, for
+// every request not matched by a preceding When. It should be declared last.
+func (s *Stub) Else(err error) {
+	s.ctrl.T.Helper()
+
+	zero := reflect.Zero(s.methodType.Out(0)).Interface()
+	s.ctrl.RecordCallWithMethodType(s.receiver, s.method, s.methodType, s.args(Any())...).AnyTimes().Return(zero, err)
+}
+
+// args returns the argument matchers of an expectation on the request req.
+func (s *Stub) args(req Matcher) []any {
+	args := make([]any, s.methodType.NumIn())
+	for i := range args {
+		args[i] = Any()
+	}
+	args[len(args)-1] = req
+	return args
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"errors"
+	"testing"
+)
+
+type Request struct{ ID int }
+
+type Response struct{ Name string }
+
+func (s *Subject) RPCMethod(req Request) (*Response, error) {
+	return nil, nil
+}
+
+func TestStub(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+	errNotFound := errors.New("not found")
+
+	ctrl.Stub(subject, "RPCMethod").
+		When(Request{ID: 1}).Reply(&Response{Name: "one"}).
+		Else(errNotFound)
+
+	assertEqual(t, []any{&Response{Name: "one"}, nil}, ctrl.Call(subject, "RPCMethod", Request{ID: 1}))
+	assertEqual(t, []any{(*Response)(nil), errNotFound}, ctrl.Call(subject, "RPCMethod", Request{ID: 2}))
+	ctrl.Finish()
+	reporter.assertPass("stubbed calls may be made any number of times")
+}
+
+func TestStub_NotRPCMethod(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+
+	reporter.assertFatal(func() {
+		ctrl.Stub(new(Subject), "FooMethod")
+	}, "is not a request/response method")
+	reporter.assertFatal(func() {
+		ctrl.Stub(new(Subject), "NoSuchMethod")
+	}, "failed finding method NoSuchMethod")
+}
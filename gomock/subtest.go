@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+// Scope is a set of expectations of a Controller bound to a subtest, see
+// Controller.ForSubtest.
+type Scope struct {
+	ctrl      *Controller
+	t         TestHelper
+	goroutine uint64
+	calls     []*Call // guarded by ctrl.mu
+}
+
+// ForSubtest binds to the subtest t the expectations declared from now on by
+// the goroutine of t, until t finishes. When it does, they are checked, the
+// missing calls failing t rather than the test of the Controller, and removed
+// from the Controller, so that they cannot be satisfied by the calls of the
+// other subtests sharing its mocks:
+//
+//	ctrl := gomock.NewController(t)
+//	m := NewMockStore(ctrl)
+//	for _, tc := range cases {
+//	  t.Run(tc.name, func(t *testing.T) {
+//	    t.Parallel()
+//	    ctrl.ForSubtest(t)
+//	    m.EXPECT().Get(tc.key).Return(tc.value)
+//	    // ...
+//	  })
+//	}
+//
+// Expectations of the scope still match the calls of any goroutine while t is
+// running, so subtests sharing mocks should expect different arguments. t must
+// have a Cleanup method, such as *testing.T.
+func (ctrl *Controller) ForSubtest(t TestReporter) *Scope {
+	h, ok := t.(TestHelper)
+	if !ok {
+		h = &nopTestHelper{t}
+	}
+	h.Helper()
+
+	c, ok := isCleanuper(h)
+	if !ok {
+		h.Fatalf("gomock: ForSubtest requires a TestReporter with a Cleanup method")
+		return nil
+	}
+	s := &Scope{ctrl: ctrl, t: h, goroutine: goroutineID()}
+
+	ctrl.lock()
+	ctrl.scopes = append(ctrl.scopes, s)
+	ctrl.unlock()
+
+	c.Cleanup(func() {
+		h.Helper()
+		s.end()
+	})
+	return s
+}
+
+// Expect adds to the scope expectations declared by other goroutines than
+// the one of its subtest, such as those of a helper running the setup
+// concurrently.
+func (s *Scope) Expect(calls ...*Call) *Scope {
+	s.ctrl.lock()
+	defer s.ctrl.unlock()
+
+	for _, call := range calls {
+		for _, other := range s.ctrl.scopes {
+			other.remove(call)
+		}
+		s.calls = append(s.calls, call)
+	}
+	return s
+}
+
+// addToScope adds call to the scope of the current goroutine, if any. The
+// Controller must be locked.
+func (ctrl *Controller) addToScope(call *Call) {
+	id := goroutineID()
+	for _, s := range ctrl.scopes {
+		if s.goroutine == id {
+			s.calls = append(s.calls, call)
+			return
+		}
+	}
+}
+
+func (s *Scope) remove(call *Call) {
+	for i, c := range s.calls {
+		if c == call {
+			s.calls = append(s.calls[:i:i], s.calls[i+1:]...)
+			return
+		}
+	}
+}
+
+// end checks and drops the expectations of the scope.
+func (s *Scope) end() {
+	s.t.Helper()
+
+	ctrl := s.ctrl
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	for i, other := range ctrl.scopes {
+		if other == s {
+			ctrl.scopes = append(ctrl.scopes[:i:i], ctrl.scopes[i+1:]...)
+			break
+		}
+	}
+	for _, call := range s.calls {
+		if !call.satisfied() {
+			s.t.Errorf("%v", ctrl.failure(newMissingCallError(call, ctrl.actualCalls[callSetKey{call.receiver, call.method}])))
+		}
+		ctrl.expectedCalls.Drop(call)
+	}
+	s.calls = nil
+}
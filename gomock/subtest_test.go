@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+)
+
+func TestForSubtest_MissingCall(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var subReporter *ErrorReporter
+	t.Run("sub", func(t *testing.T) {
+		subReporter = NewErrorReporter(t)
+		ctrl.ForSubtest(subReporter)
+		ctrl.RecordCall(subject, "FooMethod", "argument")
+	})
+	subReporter.assertFail("Expected the missing call to fail the subtest")
+
+	ctrl.Finish()
+	reporter.assertPass("Expected the scoped expectation to be dropped")
+}
+
+func TestForSubtest_NoLeak(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "BarMethod", "argument")
+
+	t.Run("sub", func(t *testing.T) {
+		subReporter := NewErrorReporter(t)
+		ctrl.ForSubtest(subReporter)
+		ctrl.RecordCall(subject, "FooMethod", "argument").AnyTimes()
+		ctrl.Call(subject, "FooMethod", "argument")
+		ctrl.Call(subject, "BarMethod", "argument")
+	})
+
+	// The expectation of the subtest no longer matches.
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}, "Unexpected call", "there are no expected calls")
+	ctrl.Finish()
+}
+
+func TestForSubtest_Expect(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var subReporter *ErrorReporter
+	t.Run("sub", func(t *testing.T) {
+		subReporter = NewErrorReporter(t)
+		s := ctrl.ForSubtest(subReporter)
+		inBackground(func() {
+			s.Expect(ctrl.RecordCall(subject, "FooMethod", "argument"))
+		})
+	})
+	subReporter.assertFail("Expected the missing call to fail the subtest")
+
+	ctrl.Finish()
+	reporter.assertPass("Expected the scoped expectation to be dropped")
+}
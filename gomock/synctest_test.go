@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.25
+
+package gomock_test
+
+import (
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithLeakCheck_Synctest(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithLeakCheck(time.Minute))
+		subject := new(Subject)
+		ctrl.RecordCall(subject, "FooMethod", "argument")
+
+		go func() {
+			ctrl.Call(subject, "FooMethod", "argument")
+			time.Sleep(30 * time.Second)
+		}()
+		synctest.Wait()
+
+		// Finish waits for the goroutine on the fake clock of the bubble.
+		start := time.Now()
+		ctrl.Finish()
+		if elapsed := time.Since(start); elapsed < 30*time.Second || elapsed >= time.Minute {
+			t.Errorf("Finish waited %v, want between 30s and 1m", elapsed)
+		}
+		reporter.assertPass("Expected the goroutine to have exited")
+	})
+}
+
+func TestWithLeakCheck_SynctestLeak(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithLeakCheck(time.Second))
+		subject := new(Subject)
+		ctrl.RecordCall(subject, "FooMethod", "argument")
+
+		release := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			ctrl.Call(subject, "FooMethod", "argument")
+			<-release
+		}()
+		synctest.Wait()
+
+		ctrl.Finish()
+		close(release)
+		<-done
+		reporter.assertFail("Expected the leaked goroutine to be reported")
+	})
+}
+
+func TestDelay_Synctest(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter)
+		subject := new(Subject)
+		ctrl.RecordCall(subject, "FooMethod", "argument").Delay(time.Hour)
+
+		// The delay follows the fake clock of the bubble.
+		start := time.Now()
+		ctrl.Call(subject, "FooMethod", "argument")
+		if elapsed := time.Since(start); elapsed != time.Hour {
+			t.Errorf("call returned after %v, want 1h", elapsed)
+		}
+		ctrl.Finish()
+		reporter.assertPass("Expected the delayed call")
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock
+
+import "time"
+
+// Within makes Controller.Wait fail the test if the expectation is not
+// satisfied, that is called its minimum number of times, within d of being
+// declared. It lets tests of event-driven code wait for the calls made in
+// reaction to an event, failing fast with a clear message rather than
+// sleeping for an arbitrary time:
+//
+//	m.EXPECT().Publish(gomock.Any()).Within(time.Second)
+//	bus.Emit(event)
+//	ctrl.Wait()
+//
+// The deadline relies on the time package only, so in a testing/synctest
+// bubble it follows the fake clock of the bubble.
+func (c *Call) Within(d time.Duration) *Call {
+	c.t.Helper()
+
+	if d <= 0 {
+		c.t.Fatalf("Within(%v) called for %T.%v: the duration is not positive [%s]", d, c.receiver, c.method, c.origin)
+		return c
+	}
+
+	c.lock()
+	defer c.unlock()
+
+	c.within = d
+	c.deadline = time.Now().Add(d)
+	return c
+}
+
+// Wait waits for the expectations declared with Call.Within to be satisfied.
+// It fails the test with Fatalf as soon as one of them is still not
+// satisfied past its deadline, and returns at once if there are none.
+func (ctrl *Controller) Wait() {
+	ctrl.T.Helper()
+
+	for {
+		late, pending := ctrl.waiting()
+		if late != nil {
+			ctrl.T.Fatalf("expected call %v did not arrive within %v", late, late.within)
+			return
+		}
+		if !pending {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// waiting returns an expectation declared with Within which is not satisfied
+// past its deadline, if any, or else whether some are not satisfied yet.
+func (ctrl *Controller) waiting() (late *Call, pending bool) {
+	ctrl.lock()
+	defer ctrl.unlock()
+
+	now := time.Now()
+	expected, _ := ctrl.expectedCalls.store.All()
+	for _, call := range expected {
+		if call.within == 0 || call.satisfied() {
+			continue
+		}
+		if !now.Before(call.deadline) {
+			return call, true
+		}
+		pending = true
+	}
+	return nil, pending
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomock_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithin(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").Within(time.Second)
+	ctrl.RecordCall(subject, "BarMethod", "argument").AnyTimes()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ctrl.Call(subject, "FooMethod", "argument")
+	}()
+
+	ctrl.Wait()
+	ctrl.Finish()
+	reporter.assertPass("Expected the call within the deadline")
+}
+
+func TestWithin_Late(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").Name("publish event").Within(20 * time.Millisecond)
+
+	start := time.Now()
+	reporter.assertFatal(func() {
+		ctrl.Wait()
+	}, "expected call expectation 'publish event' (*gomock_test.Subject.FooMethod(is equal to argument (string)))", "did not arrive within 20ms")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait failed after %v, want about 20ms", elapsed)
+	}
+}
+
+func TestWithin_Invalid(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").Within(0)
+	}, "Within(0s) called for *gomock_test.Subject.FooMethod: the duration is not positive")
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// folder shares, with -fold_signatures, the code generated for the methods
+// of the same signature: the conversion of the results of the mocks, and the
+// typed call wrappers, which become aliases of a wrapper per signature.
+// Generic interfaces are not folded, as their signatures depend on their
+// type parameters.
+type folder struct {
+	// Names of the helpers by key, for the keys of several methods.
+	results, calls map[string]string
+	// Functions generating the helpers used so far, run at the end of the
+	// file.
+	pending []func()
+	done    map[string]bool
+}
+
+// initFold names the helpers shared by the methods of the interfaces of pkg.
+func (g *generator) initFold(pkg *model.Package, pkgOverride string) {
+	f := &folder{
+		results: make(map[string]string),
+		calls:   make(map[string]string),
+		done:    make(map[string]bool),
+	}
+	g.fold = f
+
+	var prefix string
+	resultCounts := make(map[string]int)
+	callCounts := make(map[string]int)
+	var resultKeys, callKeys []string
+	for _, intf := range pkg.Interfaces {
+		if len(intf.TypeParams) > 0 {
+			continue
+		}
+		if prefix == "" {
+			// The name of a mock is unique in its package, unlike the
+			// names of the helpers of different files.
+			name := g.mockName(intf.Name)
+			r, size := utf8.DecodeRuneInString(name)
+			prefix = string(unicode.ToLower(r)) + name[size:]
+		}
+		for _, m := range intf.Methods {
+			if len(m.Out) > 0 {
+				key := g.resultsKey(m, pkgOverride)
+				if resultCounts[key]++; resultCounts[key] == 2 {
+					resultKeys = append(resultKeys, key)
+				}
+			}
+			key := g.callKey(m, pkgOverride)
+			if callCounts[key]++; callCounts[key] == 2 {
+				callKeys = append(callKeys, key)
+			}
+		}
+	}
+	for i, key := range resultKeys {
+		f.results[key] = fmt.Sprintf("%sResults%d", prefix, i)
+	}
+	for i, key := range callKeys {
+		f.calls[key] = fmt.Sprintf("%sCall%d", prefix, i)
+	}
+}
+
+// foldedNames returns the names of the helpers, which the names of the
+// parameters of the methods must not shadow.
+func (f *folder) foldedNames() []string {
+	var names []string
+	for _, name := range f.results {
+		names = append(names, name)
+	}
+	for _, name := range f.calls {
+		names = append(names, name)
+	}
+	return names
+}
+
+// resultTypes returns the types of the results of m.
+func (g *generator) resultTypes(m *model.Method, pkgOverride string) []string {
+	rets := make([]string, len(m.Out))
+	for i, p := range m.Out {
+		rets[i] = p.Type.String(g.packageMap, pkgOverride)
+	}
+	return rets
+}
+
+func (g *generator) resultsKey(m *model.Method, pkgOverride string) string {
+	return strings.Join(g.resultTypes(m, pkgOverride), ", ")
+}
+
+func (g *generator) callKey(m *model.Method, pkgOverride string) string {
+	return fmt.Sprintf("(%s) (%s)",
+		strings.Join(g.getArgTypes(m, pkgOverride, true /* in */), ", "),
+		g.resultsKey(m, pkgOverride))
+}
+
+// foldedResults returns the name of the function converting the results of
+// the calls to m, shared with other methods, or "" if there is none.
+func (g *generator) foldedResults(m *model.Method, pkgOverride, shortTp string) string {
+	if g.fold == nil || shortTp != "" || len(m.Out) == 0 {
+		return ""
+	}
+	key := g.resultsKey(m, pkgOverride)
+	name, ok := g.fold.results[key]
+	if !ok {
+		return ""
+	}
+	if !g.fold.done[name] {
+		g.fold.done[name] = true
+		rets := g.resultTypes(m, pkgOverride)
+		g.fold.pending = append(g.fold.pending, func() {
+			g.generateFoldedResults(name, rets)
+		})
+	}
+	return name
+}
+
+func (g *generator) generateFoldedResults(name string, rets []string) {
+	retString := strings.Join(rets, ", ")
+	if len(rets) > 1 {
+		retString = "(" + retString + ")"
+	}
+	names := make([]string, len(rets))
+	g.p("")
+	g.p("// %s converts the results of a call to a method returning %s.", name, retString)
+	g.p("func %s(ret []%s) %s {", name, g.anyType(), retString)
+	g.in()
+	for i, t := range rets {
+		names[i] = fmt.Sprintf("ret%d", i)
+		g.p("%s, _ := ret[%d].(%s)", names[i], i, t)
+	}
+	g.p("return %s", strings.Join(names, ", "))
+	g.out()
+	g.p("}")
+}
+
+// foldedCall returns the name of the typed call wrapper of m, shared with
+// other methods, or "" if there is none.
+func (g *generator) foldedCall(m *model.Method, pkgOverride, shortTp string) string {
+	if g.fold == nil || shortTp != "" {
+		return ""
+	}
+	name, ok := g.fold.calls[g.callKey(m, pkgOverride)]
+	if !ok {
+		return ""
+	}
+	if !g.fold.done[name] {
+		g.fold.done[name] = true
+		g.fold.pending = append(g.fold.pending, func() {
+			g.p("")
+			_ = g.generateCallType(name, m, pkgOverride, "", "")
+		})
+	}
+	return name
+}
+
+// generateFolded generates the helpers used by the mocks of the file.
+func (g *generator) generateFolded() {
+	if g.fold == nil {
+		return
+	}
+	for _, f := range g.fold.pending {
+		f()
+	}
+}
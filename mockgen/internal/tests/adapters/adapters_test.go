@@ -0,0 +1,42 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCombinedMock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := NewMockStoreV1AndStoreV2(ctrl)
+	store.EXPECT().Get("a").Return("1", nil)
+	store.EXPECT().GetContext(gomock.Any(), "b").Return("2", nil)
+	store.EXPECT().Close().Return(nil)
+
+	var v1 StoreV1 = store
+	if got, err := Lookup(v1, "a"); err != nil || got != "1" {
+		t.Errorf("Lookup(a) = %q, %v", got, err)
+	}
+	var v2 StoreV2 = store
+	if got, err := v2.GetContext(context.Background(), "b"); err != nil || got != "2" {
+		t.Errorf("GetContext(b) = %q, %v", got, err)
+	}
+}
+
+func TestAdapter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	v2 := NewMockStoreV2(ctrl)
+	v2.EXPECT().GetContext(gomock.Any(), "a").Return("1", nil)
+	v2.EXPECT().Close().Return(nil)
+
+	v1 := &StoreV1FromStoreV2{
+		StoreV2: v2,
+		GetFunc: func(key string) (string, error) {
+			return v2.GetContext(context.Background(), key)
+		},
+	}
+	if got, err := Lookup(v1, "a"); err != nil || got != "1" {
+		t.Errorf("Lookup(a) = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,23 @@
+package adapters
+
+//go:generate mockgen -package adapters -destination mock.go -source input.go -adapters StoreV1=StoreV2
+
+import "context"
+
+// StoreV1 is the version of the interface still used by production code.
+type StoreV1 interface {
+	Get(key string) (string, error)
+	Close() error
+}
+
+// StoreV2 is the version of the interface targeted by the tests.
+type StoreV2 interface {
+	GetContext(ctx context.Context, key string) (string, error)
+	Close() error
+}
+
+// Lookup uses the old version of the interface.
+func Lookup(s StoreV1, key string) (string, error) {
+	defer s.Close()
+	return s.Get(key)
+}
@@ -0,0 +1,208 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package adapters -destination mock.go -source input.go -adapters StoreV1=StoreV2
+//
+// Package adapters is a generated GoMock package.
+package adapters
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStoreV1 is a mock of StoreV1 interface.
+type MockStoreV1 struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreV1MockRecorder
+}
+
+// MockStoreV1MockRecorder is the mock recorder for MockStoreV1.
+type MockStoreV1MockRecorder struct {
+	mock *MockStoreV1
+}
+
+// NewMockStoreV1 creates a new mock instance.
+func NewMockStoreV1(ctrl *gomock.Controller) *MockStoreV1 {
+	mock := &MockStoreV1{ctrl: ctrl}
+	mock.recorder = &MockStoreV1MockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreV1) EXPECT() *MockStoreV1MockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockStoreV1) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockStoreV1MockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockStoreV1)(nil).Close))
+}
+
+// Get mocks base method.
+func (m *MockStoreV1) Get(key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStoreV1MockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStoreV1)(nil).Get), key)
+}
+
+// MockStoreV2 is a mock of StoreV2 interface.
+type MockStoreV2 struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreV2MockRecorder
+}
+
+// MockStoreV2MockRecorder is the mock recorder for MockStoreV2.
+type MockStoreV2MockRecorder struct {
+	mock *MockStoreV2
+}
+
+// NewMockStoreV2 creates a new mock instance.
+func NewMockStoreV2(ctrl *gomock.Controller) *MockStoreV2 {
+	mock := &MockStoreV2{ctrl: ctrl}
+	mock.recorder = &MockStoreV2MockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreV2) EXPECT() *MockStoreV2MockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockStoreV2) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockStoreV2MockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockStoreV2)(nil).Close))
+}
+
+// GetContext mocks base method.
+func (m *MockStoreV2) GetContext(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContext", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContext indicates an expected call of GetContext.
+func (mr *MockStoreV2MockRecorder) GetContext(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContext", reflect.TypeOf((*MockStoreV2)(nil).GetContext), ctx, key)
+}
+
+// MockStoreV1AndStoreV2 is a mock of StoreV1AndStoreV2 interface.
+type MockStoreV1AndStoreV2 struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreV1AndStoreV2MockRecorder
+}
+
+// MockStoreV1AndStoreV2MockRecorder is the mock recorder for MockStoreV1AndStoreV2.
+type MockStoreV1AndStoreV2MockRecorder struct {
+	mock *MockStoreV1AndStoreV2
+}
+
+// NewMockStoreV1AndStoreV2 creates a new mock instance.
+func NewMockStoreV1AndStoreV2(ctrl *gomock.Controller) *MockStoreV1AndStoreV2 {
+	mock := &MockStoreV1AndStoreV2{ctrl: ctrl}
+	mock.recorder = &MockStoreV1AndStoreV2MockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreV1AndStoreV2) EXPECT() *MockStoreV1AndStoreV2MockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockStoreV1AndStoreV2) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockStoreV1AndStoreV2MockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockStoreV1AndStoreV2)(nil).Close))
+}
+
+// Get mocks base method.
+func (m *MockStoreV1AndStoreV2) Get(key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStoreV1AndStoreV2MockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStoreV1AndStoreV2)(nil).Get), key)
+}
+
+// GetContext mocks base method.
+func (m *MockStoreV1AndStoreV2) GetContext(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContext", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContext indicates an expected call of GetContext.
+func (mr *MockStoreV1AndStoreV2MockRecorder) GetContext(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContext", reflect.TypeOf((*MockStoreV1AndStoreV2)(nil).GetContext), ctx, key)
+}
+
+// StoreV1FromStoreV2 adapts a StoreV2 to StoreV1. The methods of StoreV1 also in
+// StoreV2 are forwarded to it, and the others to the function fields named
+// after them.
+type StoreV1FromStoreV2 struct {
+	StoreV2 StoreV2
+	GetFunc func(key string) (string, error)
+}
+
+// Close implements StoreV1.
+func (a *StoreV1FromStoreV2) Close() error {
+	return a.StoreV2.Close()
+}
+
+// Get implements StoreV1.
+func (a *StoreV1FromStoreV2) Get(key string) (string, error) {
+	if a.GetFunc == nil {
+		panic("StoreV1FromStoreV2.GetFunc is not set")
+	}
+	return a.GetFunc(key)
+}
@@ -0,0 +1,68 @@
+package close_guard
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// fatalReporter records the failure of a test, stopping the failing call.
+type fatalReporter struct {
+	testing.TB
+	fatal string
+}
+
+func (r *fatalReporter) Fatalf(format string, args ...any) {
+	r.fatal = fmt.Sprintf(format, args...)
+	panic(r)
+}
+
+func (r *fatalReporter) call(f func()) {
+	defer func() {
+		if p := recover(); p != nil && p != r {
+			panic(p)
+		}
+	}()
+	f()
+}
+
+func TestCallAfterClose(t *testing.T) {
+	reporter := &fatalReporter{TB: t}
+	ctrl := gomock.NewController(reporter)
+	conn := NewMockConn(ctrl)
+	conn.EXPECT().Read(gomock.Any()).Return(1, nil).AnyTimes()
+	conn.EXPECT().Close().Return(nil)
+
+	conn.Read(make([]byte, 1))
+	conn.Close()
+	reporter.call(func() { conn.Read(make([]byte, 1)) })
+	if !strings.Contains(reporter.fatal, "Unexpected call to *close_guard.MockConn.Read") ||
+		!strings.Contains(reporter.fatal, "the mock was closed by Close at") {
+		t.Errorf("unexpected failure: %q", reporter.fatal)
+	}
+
+	reporter.fatal = ""
+	reporter.call(func() { conn.Close() })
+	if !strings.Contains(reporter.fatal, "Unexpected call to *close_guard.MockConn.Close") {
+		t.Errorf("expected a second Close to fail, got %q", reporter.fatal)
+	}
+}
+
+func TestCallsBeforeShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	server := NewMockServer(ctrl)
+	reader := NewMockReader(ctrl)
+	gomock.InOrder(
+		server.EXPECT().Serve().Return(nil),
+		server.EXPECT().Shutdown(),
+	)
+	reader.EXPECT().Read(gomock.Any()).Return(0, nil)
+
+	if err := server.Serve(); err != nil {
+		t.Errorf("Serve() = %v, want nil", err)
+	}
+	server.Shutdown()
+	reader.Read(nil)
+}
@@ -0,0 +1,18 @@
+package close_guard
+
+//go:generate mockgen -package close_guard -destination mock.go -source input.go -close_guard Close,Shutdown
+
+type Conn interface {
+	Read(p []byte) (int, error)
+	Close() error
+}
+
+type Server interface {
+	Serve() error
+	Shutdown()
+}
+
+// Reader has no method closing it, so its mock is not guarded.
+type Reader interface {
+	Read(p []byte) (int, error)
+}
@@ -0,0 +1,46 @@
+package diamond
+
+//go:generate mockgen -package diamond -destination mock.go -source diamond.go
+
+import "io"
+
+// Base is embedded by Left and Right, so Diamond reaches it twice.
+type Base interface {
+	ID() string
+	io.Closer
+}
+
+type Left interface {
+	Base
+	io.ReadCloser
+}
+
+type Right interface {
+	Base
+	io.WriteCloser
+}
+
+type Diamond interface {
+	Left
+	Right
+}
+
+// Getter is embedded, with the same type argument, through both GetterLeft
+// and GetterRight.
+type Getter[T any] interface {
+	Get() T
+}
+
+type GetterLeft[T any] interface {
+	Getter[T]
+}
+
+type GetterRight[T any] interface {
+	Getter[T]
+	Set(T)
+}
+
+type GetterDiamond interface {
+	GetterLeft[int]
+	GetterRight[int]
+}
@@ -0,0 +1,33 @@
+package diamond
+
+import (
+	"testing"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestDiamond(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var d Diamond = NewMockDiamond(ctrl)
+	d.(*MockDiamond).EXPECT().Close().Return(nil)
+	d.(*MockDiamond).EXPECT().ID().Return("id")
+
+	if err := d.Close(); err != nil {
+		t.Errorf("Close() == %v, want nil", err)
+	}
+	if id := d.ID(); id != "id" {
+		t.Errorf("ID() == %q, want %q", id, "id")
+	}
+}
+
+func TestGetterDiamond(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var g GetterDiamond = NewMockGetterDiamond(ctrl)
+	g.(*MockGetterDiamond).EXPECT().Get().Return(1)
+
+	if v := g.Get(); v != 1 {
+		t.Errorf("Get() == %d, want 1", v)
+	}
+}
@@ -0,0 +1,451 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: diamond.go
+//
+// Generated by this command:
+//
+//	mockgen -package diamond -destination mock.go -source diamond.go
+//
+// Package diamond is a generated GoMock package.
+package diamond
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBase is a mock of Base interface.
+type MockBase struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseMockRecorder
+}
+
+// MockBaseMockRecorder is the mock recorder for MockBase.
+type MockBaseMockRecorder struct {
+	mock *MockBase
+}
+
+// NewMockBase creates a new mock instance.
+func NewMockBase(ctrl *gomock.Controller) *MockBase {
+	mock := &MockBase{ctrl: ctrl}
+	mock.recorder = &MockBaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBase) EXPECT() *MockBaseMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockBase) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockBaseMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockBase)(nil).Close))
+}
+
+// ID mocks base method.
+func (m *MockBase) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockBaseMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockBase)(nil).ID))
+}
+
+// MockLeft is a mock of Left interface.
+type MockLeft struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeftMockRecorder
+}
+
+// MockLeftMockRecorder is the mock recorder for MockLeft.
+type MockLeftMockRecorder struct {
+	mock *MockLeft
+}
+
+// NewMockLeft creates a new mock instance.
+func NewMockLeft(ctrl *gomock.Controller) *MockLeft {
+	mock := &MockLeft{ctrl: ctrl}
+	mock.recorder = &MockLeftMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeft) EXPECT() *MockLeftMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockLeft) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockLeftMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockLeft)(nil).Close))
+}
+
+// ID mocks base method.
+func (m *MockLeft) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockLeftMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockLeft)(nil).ID))
+}
+
+// Read mocks base method.
+func (m *MockLeft) Read(p []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockLeftMockRecorder) Read(p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockLeft)(nil).Read), p)
+}
+
+// MockRight is a mock of Right interface.
+type MockRight struct {
+	ctrl     *gomock.Controller
+	recorder *MockRightMockRecorder
+}
+
+// MockRightMockRecorder is the mock recorder for MockRight.
+type MockRightMockRecorder struct {
+	mock *MockRight
+}
+
+// NewMockRight creates a new mock instance.
+func NewMockRight(ctrl *gomock.Controller) *MockRight {
+	mock := &MockRight{ctrl: ctrl}
+	mock.recorder = &MockRightMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRight) EXPECT() *MockRightMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRight) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRightMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRight)(nil).Close))
+}
+
+// ID mocks base method.
+func (m *MockRight) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockRightMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockRight)(nil).ID))
+}
+
+// Write mocks base method.
+func (m *MockRight) Write(p []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockRightMockRecorder) Write(p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockRight)(nil).Write), p)
+}
+
+// MockDiamond is a mock of Diamond interface.
+type MockDiamond struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiamondMockRecorder
+}
+
+// MockDiamondMockRecorder is the mock recorder for MockDiamond.
+type MockDiamondMockRecorder struct {
+	mock *MockDiamond
+}
+
+// NewMockDiamond creates a new mock instance.
+func NewMockDiamond(ctrl *gomock.Controller) *MockDiamond {
+	mock := &MockDiamond{ctrl: ctrl}
+	mock.recorder = &MockDiamondMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiamond) EXPECT() *MockDiamondMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockDiamond) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockDiamondMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDiamond)(nil).Close))
+}
+
+// ID mocks base method.
+func (m *MockDiamond) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockDiamondMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockDiamond)(nil).ID))
+}
+
+// Read mocks base method.
+func (m *MockDiamond) Read(p []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockDiamondMockRecorder) Read(p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockDiamond)(nil).Read), p)
+}
+
+// Write mocks base method.
+func (m *MockDiamond) Write(p []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockDiamondMockRecorder) Write(p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockDiamond)(nil).Write), p)
+}
+
+// MockGetter is a mock of Getter interface.
+type MockGetter[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetterMockRecorder[T]
+}
+
+// MockGetterMockRecorder is the mock recorder for MockGetter.
+type MockGetterMockRecorder[T any] struct {
+	mock *MockGetter[T]
+}
+
+// NewMockGetter creates a new mock instance.
+func NewMockGetter[T any](ctrl *gomock.Controller) *MockGetter[T] {
+	mock := &MockGetter[T]{ctrl: ctrl}
+	mock.recorder = &MockGetterMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetter[T]) EXPECT() *MockGetterMockRecorder[T] {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockGetter[T]) Get() T {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get")
+	ret0, _ := ret[0].(T)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGetterMockRecorder[T]) Get() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGetter[T])(nil).Get))
+}
+
+// MockGetterLeft is a mock of GetterLeft interface.
+type MockGetterLeft[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetterLeftMockRecorder[T]
+}
+
+// MockGetterLeftMockRecorder is the mock recorder for MockGetterLeft.
+type MockGetterLeftMockRecorder[T any] struct {
+	mock *MockGetterLeft[T]
+}
+
+// NewMockGetterLeft creates a new mock instance.
+func NewMockGetterLeft[T any](ctrl *gomock.Controller) *MockGetterLeft[T] {
+	mock := &MockGetterLeft[T]{ctrl: ctrl}
+	mock.recorder = &MockGetterLeftMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetterLeft[T]) EXPECT() *MockGetterLeftMockRecorder[T] {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockGetterLeft[T]) Get() T {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get")
+	ret0, _ := ret[0].(T)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGetterLeftMockRecorder[T]) Get() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGetterLeft[T])(nil).Get))
+}
+
+// MockGetterRight is a mock of GetterRight interface.
+type MockGetterRight[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetterRightMockRecorder[T]
+}
+
+// MockGetterRightMockRecorder is the mock recorder for MockGetterRight.
+type MockGetterRightMockRecorder[T any] struct {
+	mock *MockGetterRight[T]
+}
+
+// NewMockGetterRight creates a new mock instance.
+func NewMockGetterRight[T any](ctrl *gomock.Controller) *MockGetterRight[T] {
+	mock := &MockGetterRight[T]{ctrl: ctrl}
+	mock.recorder = &MockGetterRightMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetterRight[T]) EXPECT() *MockGetterRightMockRecorder[T] {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockGetterRight[T]) Get() T {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get")
+	ret0, _ := ret[0].(T)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGetterRightMockRecorder[T]) Get() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGetterRight[T])(nil).Get))
+}
+
+// Set mocks base method.
+func (m *MockGetterRight[T]) Set(arg0 T) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", arg0)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockGetterRightMockRecorder[T]) Set(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockGetterRight[T])(nil).Set), arg0)
+}
+
+// MockGetterDiamond is a mock of GetterDiamond interface.
+type MockGetterDiamond struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetterDiamondMockRecorder
+}
+
+// MockGetterDiamondMockRecorder is the mock recorder for MockGetterDiamond.
+type MockGetterDiamondMockRecorder struct {
+	mock *MockGetterDiamond
+}
+
+// NewMockGetterDiamond creates a new mock instance.
+func NewMockGetterDiamond(ctrl *gomock.Controller) *MockGetterDiamond {
+	mock := &MockGetterDiamond{ctrl: ctrl}
+	mock.recorder = &MockGetterDiamondMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetterDiamond) EXPECT() *MockGetterDiamondMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockGetterDiamond) Get() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGetterDiamondMockRecorder) Get() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGetterDiamond)(nil).Get))
+}
+
+// Set mocks base method.
+func (m *MockGetterDiamond) Set(arg0 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", arg0)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockGetterDiamondMockRecorder) Set(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockGetterDiamond)(nil).Set), arg0)
+}
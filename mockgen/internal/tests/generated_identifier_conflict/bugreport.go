@@ -2,6 +2,8 @@ package bugreport
 
 //go:generate mockgen -destination bugreport_mock.go -package bugreport -source=bugreport.go
 
+import "context"
+
 type Example interface {
 	// _m and _mr were used by the buggy code: the '_' prefix was there hoping
 	// that no one will use method argument names starting with '_' reducing
@@ -14,3 +16,13 @@ type Example interface {
 
 	VarargMethod(_s, _x, a, ret int, varargs ...int)
 }
+
+type Shadowing interface {
+	// Parameters named after identifiers the generated code refers to.
+	Method(context context.Context, reflect, gomock, any int, MockShadowing string) context.Context
+
+	// Methods named after fields of the generated mock and recorder.
+	ctrl() int
+	recorder()
+	mock()
+}
@@ -9,6 +9,7 @@
 package bugreport
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -65,3 +66,78 @@ func (mr *MockExampleMockRecorder) VarargMethod(_s, _x, a, ret any, varargs ...a
 	varargs_2 := append([]any{_s, _x, a, ret}, varargs...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VarargMethod", reflect.TypeOf((*MockExample)(nil).VarargMethod), varargs_2...)
 }
+
+// MockShadowing is a mock of Shadowing interface.
+type MockShadowing struct {
+	ctrl_2     *gomock.Controller
+	recorder_2 *MockShadowingMockRecorder
+}
+
+// MockShadowingMockRecorder is the mock recorder for MockShadowing.
+type MockShadowingMockRecorder struct {
+	mock_2 *MockShadowing
+}
+
+// NewMockShadowing creates a new mock instance.
+func NewMockShadowing(ctrl *gomock.Controller) *MockShadowing {
+	mock := &MockShadowing{ctrl_2: ctrl}
+	mock.recorder_2 = &MockShadowingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShadowing) EXPECT() *MockShadowingMockRecorder {
+	return m.recorder_2
+}
+
+// Method mocks base method.
+func (m *MockShadowing) Method(context_2 context.Context, reflect_2, gomock_2, any_2 int, MockShadowing_2 string) context.Context {
+	m.ctrl_2.T.Helper()
+	ret := m.ctrl_2.Call(m, "Method", context_2, reflect_2, gomock_2, any_2, MockShadowing_2)
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Method indicates an expected call of Method.
+func (mr *MockShadowingMockRecorder) Method(context_2, reflect_2, gomock_2, any_2, MockShadowing_2 any) *gomock.Call {
+	mr.mock_2.ctrl_2.T.Helper()
+	return mr.mock_2.ctrl_2.RecordCallWithMethodType(mr.mock_2, "Method", reflect.TypeOf((*MockShadowing)(nil).Method), context_2, reflect_2, gomock_2, any_2, MockShadowing_2)
+}
+
+// ctrl mocks base method.
+func (m *MockShadowing) ctrl() int {
+	m.ctrl_2.T.Helper()
+	ret := m.ctrl_2.Call(m, "ctrl")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// ctrl indicates an expected call of ctrl.
+func (mr *MockShadowingMockRecorder) ctrl() *gomock.Call {
+	mr.mock_2.ctrl_2.T.Helper()
+	return mr.mock_2.ctrl_2.RecordCallWithMethodType(mr.mock_2, "ctrl", reflect.TypeOf((*MockShadowing)(nil).ctrl))
+}
+
+// mock mocks base method.
+func (m *MockShadowing) mock() {
+	m.ctrl_2.T.Helper()
+	m.ctrl_2.Call(m, "mock")
+}
+
+// mock indicates an expected call of mock.
+func (mr *MockShadowingMockRecorder) mock() *gomock.Call {
+	mr.mock_2.ctrl_2.T.Helper()
+	return mr.mock_2.ctrl_2.RecordCallWithMethodType(mr.mock_2, "mock", reflect.TypeOf((*MockShadowing)(nil).mock))
+}
+
+// recorder mocks base method.
+func (m *MockShadowing) recorder() {
+	m.ctrl_2.T.Helper()
+	m.ctrl_2.Call(m, "recorder")
+}
+
+// recorder indicates an expected call of recorder.
+func (mr *MockShadowingMockRecorder) recorder() *gomock.Call {
+	mr.mock_2.ctrl_2.T.Helper()
+	return mr.mock_2.ctrl_2.RecordCallWithMethodType(mr.mock_2, "recorder", reflect.TypeOf((*MockShadowing)(nil).recorder))
+}
@@ -1,8 +1,10 @@
 package bugreport
 
 import (
-	"go.uber.org/mock/gomock"
+	"context"
 	"testing"
+
+	"go.uber.org/mock/gomock"
 )
 
 func TestExample_Method(t *testing.T) {
@@ -24,3 +26,22 @@ func TestExample_VarargMethod(t *testing.T) {
 
 	ctrl.Finish()
 }
+
+func TestShadowing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockShadowing(ctrl)
+	ctx := context.Background()
+	m.EXPECT().Method(ctx, 1, 2, 3, "4").Return(ctx)
+	m.EXPECT().ctrl().Return(5)
+	m.EXPECT().recorder()
+	m.EXPECT().mock()
+
+	if got := m.Method(ctx, 1, 2, 3, "4"); got != ctx {
+		t.Errorf("Method() = %v, want %v", got, ctx)
+	}
+	if got := m.ctrl(); got != 5 {
+		t.Errorf("ctrl() = %v, want 5", got)
+	}
+	m.recorder()
+	m.mock()
+}
@@ -7,4 +7,10 @@ require (
 	golang.org/x/exp v0.0.0-20220428152302-39d4317da171
 )
 
+require (
+	github.com/golang/protobuf v1.5.0 // indirect
+	github.com/google/go-cmp v0.5.5 // indirect
+	google.golang.org/protobuf v1.30.0 // indirect
+)
+
 replace go.uber.org/mock => ../../../..
@@ -0,0 +1,16 @@
+package source
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock/examplecheck"
+	"go.uber.org/mock/mockgen/internal/tests/generics"
+)
+
+func TestMocks(t *testing.T) {
+	examplecheck.Check[generics.Bar[int, string]](t, NewMockBar[int, string])
+	examplecheck.Check[generics.Universe[int]](t, NewMockUniverse[int])
+	examplecheck.Check[generics.ExternalConstraint[int, float64]](t, NewMockExternalConstraint[int, float64])
+	examplecheck.Check[generics.EmbeddingIface[int, float64]](t, NewMockEmbeddingIface[int, float64])
+	examplecheck.Check[generics.Group[generics.Generator[any]]](t, NewMockGroup[generics.Generator[any]])
+}
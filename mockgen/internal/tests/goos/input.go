@@ -0,0 +1,11 @@
+package goos
+
+import "go.uber.org/mock/mockgen/internal/tests/goos/platform"
+
+//go:generate mockgen -package goos -destination mock.go -source input.go -goos windows
+
+// Console embeds the Handle of Windows, generated from any platform.
+type Console interface {
+	platform.Handle
+	Close() error
+}
@@ -0,0 +1,69 @@
+//go:build windows
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package goos -destination mock.go -source input.go -goos windows
+//
+// Package goos is a generated GoMock package.
+package goos
+
+import (
+	reflect "reflect"
+	syscall "syscall"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConsole is a mock of Console interface.
+type MockConsole struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsoleMockRecorder
+}
+
+// MockConsoleMockRecorder is the mock recorder for MockConsole.
+type MockConsoleMockRecorder struct {
+	mock *MockConsole
+}
+
+// NewMockConsole creates a new mock instance.
+func NewMockConsole(ctrl *gomock.Controller) *MockConsole {
+	mock := &MockConsole{ctrl: ctrl}
+	mock.recorder = &MockConsoleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsole) EXPECT() *MockConsoleMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockConsole) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockConsoleMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockConsole)(nil).Close))
+}
+
+// Handle mocks base method.
+func (m *MockConsole) Handle() syscall.Handle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle")
+	ret0, _ := ret[0].(syscall.Handle)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockConsoleMockRecorder) Handle() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockConsole)(nil).Handle))
+}
@@ -0,0 +1,7 @@
+//go:build unix
+
+package platform
+
+type Handle interface {
+	Fd() uintptr
+}
@@ -0,0 +1,7 @@
+package platform
+
+import "syscall"
+
+type Handle interface {
+	Handle() syscall.Handle
+}
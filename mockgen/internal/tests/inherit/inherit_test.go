@@ -0,0 +1,30 @@
+package inherit
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// expectHeader is a fixture declaring the reading of a header on a Reader.
+func expectHeader(r *MockReader) {
+	r.EXPECT().Read(gomock.Len(4)).Return(4, nil)
+}
+
+func TestInheritFrom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reader := NewMockReader(ctrl)
+	expectHeader(reader)
+
+	rw := NewMockReadWriter(ctrl)
+	rw.InheritFrom(reader)
+	rw.EXPECT().Write(gomock.Any()).Return(2, nil)
+
+	var _ ReadWriter = rw
+	if n, err := rw.Read(make([]byte, 4)); n != 4 || err != nil {
+		t.Errorf("Read() = %d, %v, want 4, nil", n, err)
+	}
+	if n, err := rw.Write([]byte("ok")); n != 2 || err != nil {
+		t.Errorf("Write() = %d, %v, want 2, nil", n, err)
+	}
+}
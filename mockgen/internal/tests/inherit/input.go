@@ -0,0 +1,12 @@
+package inherit
+
+//go:generate mockgen -package inherit -destination mock.go -source input.go -inherit
+
+type Reader interface {
+	Read(p []byte) (int, error)
+}
+
+type ReadWriter interface {
+	Reader
+	Write(p []byte) (int, error)
+}
@@ -0,0 +1,8 @@
+package lang
+
+//go:generate mockgen -package lang -destination mock.go -source input.go -lang go1.17
+
+type Logger interface {
+	Log(level int, format string, args ...interface{})
+	Fields() map[string]interface{}
+}
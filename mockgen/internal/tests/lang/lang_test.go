@@ -0,0 +1,15 @@
+package lang
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestLang(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := NewMockLogger(ctrl)
+	logger.EXPECT().Log(1, "%s=%d", "a", 1)
+
+	logger.Log(1, "%s=%d", "a", 1)
+}
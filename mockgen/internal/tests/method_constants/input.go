@@ -0,0 +1,8 @@
+package method_constants
+
+//go:generate mockgen -package method_constants -destination mock.go -source input.go -method_constants
+
+type Math interface {
+	Sum(a, b int) int
+	Neg(a int) int
+}
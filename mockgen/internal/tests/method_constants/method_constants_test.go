@@ -0,0 +1,26 @@
+package method_constants
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestMethodConstants(t *testing.T) {
+	ctrl := gomock.NewController(t, gomock.WithArgSnapshots())
+	m := NewMockMath(ctrl)
+
+	ctrl.RecordCall(m, MockMathSumMethod, 1, 2).Return(3)
+	m.EXPECT().Neg(3).Return(-3)
+
+	if got := m.Neg(m.Sum(1, 2)); got != -3 {
+		t.Errorf("Neg(Sum(1, 2)) = %d, want -3", got)
+	}
+	var methods []string
+	for _, call := range ctrl.ReceivedCalls() {
+		methods = append(methods, call.Method)
+	}
+	if len(methods) != 2 || methods[0] != MockMathSumMethod || methods[1] != MockMathNegMethod {
+		t.Errorf("received calls to %q, want Sum then Neg", methods)
+	}
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package method_constants -destination mock.go -source input.go -method_constants
+//
+// Package method_constants is a generated GoMock package.
+package method_constants
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMath is a mock of Math interface.
+type MockMath struct {
+	ctrl     *gomock.Controller
+	recorder *MockMathMockRecorder
+}
+
+// MockMathMockRecorder is the mock recorder for MockMath.
+type MockMathMockRecorder struct {
+	mock *MockMath
+}
+
+// NewMockMath creates a new mock instance.
+func NewMockMath(ctrl *gomock.Controller) *MockMath {
+	mock := &MockMath{ctrl: ctrl}
+	mock.recorder = &MockMathMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMath) EXPECT() *MockMathMockRecorder {
+	return m.recorder
+}
+
+// Names of the methods of MockMath.
+const (
+	MockMathNegMethod = "Neg"
+	MockMathSumMethod = "Sum"
+)
+
+// Neg mocks base method.
+func (m *MockMath) Neg(a int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, MockMathNegMethod, a)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Neg indicates an expected call of Neg.
+func (mr *MockMathMockRecorder) Neg(a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, MockMathNegMethod, reflect.TypeOf((*MockMath)(nil).Neg), a)
+}
+
+// Sum mocks base method.
+func (m *MockMath) Sum(a, b int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, MockMathSumMethod, a, b)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Sum indicates an expected call of Sum.
+func (mr *MockMathMockRecorder) Sum(a, b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, MockMathSumMethod, reflect.TypeOf((*MockMath)(nil).Sum), a, b)
+}
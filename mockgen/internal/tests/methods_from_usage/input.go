@@ -0,0 +1,20 @@
+package methods_from_usage
+
+//go:generate mockgen -package methods_from_usage -destination mock.go -source input.go -methods_from_usage .
+
+// Client is a large interface, of which Sync only uses Get and Put.
+type Client interface {
+	Get(key string) (string, error)
+	Put(key, value string) error
+	Delete(key string) error
+	List(prefix string, limit int) ([]string, error)
+}
+
+// Sync copies the value of key from src to dst.
+func Sync(src, dst Client, key string) error {
+	v, err := src.Get(key)
+	if err != nil {
+		return err
+	}
+	return dst.Put(key, v)
+}
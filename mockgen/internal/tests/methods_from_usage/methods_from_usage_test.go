@@ -0,0 +1,25 @@
+package methods_from_usage
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestMethodsFromUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	src, dst := NewMockClient(ctrl), NewMockClient(ctrl)
+
+	src.EXPECT().Get("k").Return("v", nil)
+	dst.EXPECT().Put("k", "v").Return(nil)
+	if err := Sync(src, dst, "k"); err != nil {
+		t.Errorf("Sync() = %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected the unused method Delete to panic")
+		}
+	}()
+	_ = src.Delete("k")
+}
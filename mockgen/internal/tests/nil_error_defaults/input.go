@@ -0,0 +1,9 @@
+package nil_error_defaults
+
+//go:generate mockgen -package nil_error_defaults -destination mock.go -source input.go -nil_error_defaults
+
+type Writer interface {
+	Write(p []byte) (int, error)
+	Flush() error
+	Close() error
+}
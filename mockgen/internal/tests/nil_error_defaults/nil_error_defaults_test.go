@@ -0,0 +1,33 @@
+package nil_error_defaults
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestNoExpectations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	w := NewMockWriter(ctrl)
+	w.EXPECT().Write([]byte("a")).Return(1, nil)
+
+	w.Write([]byte("a"))
+	if err := w.Flush(); err != nil {
+		t.Errorf("Flush() = %v, want nil", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() = %v, want nil", err)
+	}
+}
+
+func TestExpectations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	w := NewMockWriter(ctrl)
+	errClosed := errors.New("closed")
+	w.EXPECT().Close().Return(errClosed)
+
+	if err := w.Close(); err != errClosed {
+		t.Errorf("Close() = %v, want %v", err, errClosed)
+	}
+}
@@ -0,0 +1,13 @@
+package recorder_package
+
+//go:generate mockgen -package recorder_package -destination mock.go -source input.go -recorder_package recorders
+//go:generate mockgen -package typed -destination typed/mock.go -source input.go -recorder_package recorders -typed
+
+type Item struct {
+	Name string
+}
+
+type Store interface {
+	Get(key string) (Item, error)
+	Put(key string, items ...Item)
+}
@@ -0,0 +1,33 @@
+package recorder_package_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/mock/mockgen/internal/tests/recorder_package"
+	"go.uber.org/mock/mockgen/internal/tests/recorder_package/typed"
+)
+
+func TestRecorderPackage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := recorder_package.NewMockStore(ctrl)
+	store.EXPECT().Get("a").Return(recorder_package.Item{Name: "a"}, nil)
+	store.EXPECT().Put("a", gomock.Any(), gomock.Any())
+
+	if item, err := store.Get("a"); err != nil || item.Name != "a" {
+		t.Errorf("Get(a) = %v, %v", item, err)
+	}
+	store.Put("a", recorder_package.Item{}, recorder_package.Item{})
+}
+
+func TestRecorderPackage_Typed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := typed.NewMockStore(ctrl)
+	store.EXPECT().Get("a").DoAndReturn(func(key string) (recorder_package.Item, error) {
+		return recorder_package.Item{Name: key}, nil
+	})
+
+	if item, err := store.Get("a"); err != nil || item.Name != "a" {
+		t.Errorf("Get(a) = %v, %v", item, err)
+	}
+}
@@ -0,0 +1,39 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package recorder_package -destination mock.go -source input.go -recorder_package recorders
+//
+// Package recorders is a generated GoMock package.
+package recorders
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock any
+	ctrl *gomock.Controller
+}
+
+// NewMockStoreMockRecorder creates the recorder of mock, for use by NewMockStore.
+func NewMockStoreMockRecorder(mock any, ctrl *gomock.Controller) *MockStoreMockRecorder {
+	return &MockStoreMockRecorder{mock: mock, ctrl: ctrl}
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStoreMockRecorder) Get(key any) *gomock.Call {
+	mr.ctrl.T.Helper()
+	return mr.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.ValueOf(mr.mock).MethodByName("Get").Type(), key)
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockStoreMockRecorder) Put(key any, items ...any) *gomock.Call {
+	mr.ctrl.T.Helper()
+	varargs := append([]any{key}, items...)
+	return mr.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.ValueOf(mr.mock).MethodByName("Put").Type(), varargs...)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package typed -destination typed/mock.go -source input.go -recorder_package recorders -typed
+//
+// Package typed is a generated GoMock package.
+package typed
+
+import (
+	gomock "go.uber.org/mock/gomock"
+	recorder_package "go.uber.org/mock/mockgen/internal/tests/recorder_package"
+	recorders "go.uber.org/mock/mockgen/internal/tests/recorder_package/typed/recorders"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *recorders.MockStoreMockRecorder
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = recorders.NewMockStoreMockRecorder(mock, ctrl)
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *recorders.MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockStore) Get(key string) (recorder_package.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(recorder_package.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Put mocks base method.
+func (m *MockStore) Put(key string, items ...recorder_package.Item) {
+	m.ctrl.T.Helper()
+	varargs := []any{key}
+	for _, a := range items {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Put", varargs...)
+}
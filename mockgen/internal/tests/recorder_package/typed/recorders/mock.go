@@ -0,0 +1,121 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package typed -destination typed/mock.go -source input.go -recorder_package recorders -typed
+//
+// Package recorders is a generated GoMock package.
+package recorders
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	recorder_package "go.uber.org/mock/mockgen/internal/tests/recorder_package"
+)
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock any
+	ctrl *gomock.Controller
+}
+
+// NewMockStoreMockRecorder creates the recorder of mock, for use by NewMockStore.
+func NewMockStoreMockRecorder(mock any, ctrl *gomock.Controller) *MockStoreMockRecorder {
+	return &MockStoreMockRecorder{mock: mock, ctrl: ctrl}
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStoreMockRecorder) Get(key any) *StoreGetCall {
+	mr.ctrl.T.Helper()
+	call := mr.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.ValueOf(mr.mock).MethodByName("Get").Type(), key)
+	return &StoreGetCall{Call: call}
+}
+
+// StoreGetCall wrap *gomock.Call
+type StoreGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *StoreGetCall) Return(arg0 recorder_package.Item, arg1 error) *StoreGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *StoreGetCall) ReturnSeq(valuesPerCall ...[]any) *StoreGetCall {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *StoreGetCall) Do(f func(string) (recorder_package.Item, error)) *StoreGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *StoreGetCall) DoAndReturn(f func(string) (recorder_package.Item, error)) *StoreGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *StoreGetCall) EachReceivedArgs(f func(string)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		key, _ := args[0].(string)
+		f(key)
+	}
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockStoreMockRecorder) Put(key any, items ...any) *StorePutCall {
+	mr.ctrl.T.Helper()
+	varargs := append([]any{key}, items...)
+	call := mr.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.ValueOf(mr.mock).MethodByName("Put").Type(), varargs...)
+	return &StorePutCall{Call: call}
+}
+
+// StorePutCall wrap *gomock.Call
+type StorePutCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *StorePutCall) Return() *StorePutCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *StorePutCall) ReturnSeq(valuesPerCall ...[]any) *StorePutCall {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *StorePutCall) Do(f func(string, ...recorder_package.Item)) *StorePutCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *StorePutCall) DoAndReturn(f func(string, ...recorder_package.Item)) *StorePutCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *StorePutCall) EachReceivedArgs(f func(string, ...recorder_package.Item)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		key, _ := args[0].(string)
+		var items []recorder_package.Item
+		for _, a := range args[1:] {
+			v, _ := a.(recorder_package.Item)
+			items = append(items, v)
+		}
+		f(key, items...)
+	}
+}
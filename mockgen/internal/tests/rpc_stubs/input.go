@@ -0,0 +1,18 @@
+package rpc_stubs
+
+//go:generate mockgen -package rpc_stubs -destination mock.go -source input.go -rpc_stubs
+
+import "context"
+
+type GetRequest struct {
+	ID int
+}
+
+type GetResponse struct {
+	Name string
+}
+
+type Client interface {
+	Get(ctx context.Context, req *GetRequest) (*GetResponse, error)
+	Close() error
+}
@@ -0,0 +1,44 @@
+package rpc_stubs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestStub(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockClient(ctrl)
+	errNotFound := errors.New("not found")
+
+	client.Stub("Get").
+		When(&GetRequest{ID: 1}).Reply(&GetResponse{Name: "one"}).
+		When(&GetRequest{ID: 2}).Reply(&GetResponse{Name: "two"}).
+		Else(errNotFound)
+
+	ctx := context.Background()
+	for _, tt := range []struct {
+		id      int
+		want    string
+		wantErr error
+	}{
+		{1, "one", nil},
+		{2, "two", nil},
+		{3, "", errNotFound},
+		{1, "one", nil},
+	} {
+		resp, err := client.Get(ctx, &GetRequest{ID: tt.id})
+		if err != tt.wantErr {
+			t.Errorf("Get(%d) error = %v, want %v", tt.id, err, tt.wantErr)
+		}
+		var got string
+		if resp != nil {
+			got = resp.Name
+		}
+		if got != tt.want {
+			t.Errorf("Get(%d) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package store
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+//go:generate mockgen -source=cache_test.go -destination=mock_cache_test.go
+
+// key is only declared in the tests of the package.
+type key string
+
+type Cache interface {
+	Get(k key) (Item, bool)
+}
+
+func TestCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cache := NewMockCache(ctrl)
+	cache.EXPECT().Get(key("a")).Return(Item{Key: "a", Value: "1"}, true)
+
+	if item, ok := cache.Get("a"); !ok || item.Value != "1" {
+		t.Errorf("Get(a) = %v, %v", item, ok)
+	}
+}
@@ -0,0 +1,29 @@
+package store_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/mock/mockgen/internal/tests/test_file_interface"
+)
+
+//go:generate mockgen -source=loader_test.go -destination=mock_loader_test.go
+
+// Options is only declared in the external tests of the package.
+type Options struct {
+	Refresh bool
+}
+
+type Loader interface {
+	Load(key string, opts Options) (store.Item, error)
+}
+
+func TestLoader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loader := NewMockLoader(ctrl)
+	loader.EXPECT().Load("a", Options{Refresh: true}).Return(store.Item{Key: "a"}, nil)
+
+	if item, err := loader.Load("a", Options{Refresh: true}); err != nil || item.Key != "a" {
+		t.Errorf("Load(a) = %v, %v", item, err)
+	}
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: loader_test.go
+//
+// Generated by this command:
+//
+//	mockgen -source=loader_test.go -destination=mock_loader_test.go
+//
+// Package store_test is a generated GoMock package.
+package store_test
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	store "go.uber.org/mock/mockgen/internal/tests/test_file_interface"
+)
+
+// MockLoader is a mock of Loader interface.
+type MockLoader struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoaderMockRecorder
+}
+
+// MockLoaderMockRecorder is the mock recorder for MockLoader.
+type MockLoaderMockRecorder struct {
+	mock *MockLoader
+}
+
+// NewMockLoader creates a new mock instance.
+func NewMockLoader(ctrl *gomock.Controller) *MockLoader {
+	mock := &MockLoader{ctrl: ctrl}
+	mock.recorder = &MockLoaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoader) EXPECT() *MockLoaderMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockLoader) Load(key string, opts Options) (store.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", key, opts)
+	ret0, _ := ret[0].(store.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockLoaderMockRecorder) Load(key, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockLoader)(nil).Load), key, opts)
+}
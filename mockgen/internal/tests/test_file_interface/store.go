@@ -0,0 +1,7 @@
+package store
+
+// Item is an item held by a store.
+type Item struct {
+	Key   string
+	Value string
+}
@@ -60,6 +60,12 @@ func (c *SourceErrorCall) Return(arg0 string) *SourceErrorCall {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *SourceErrorCall) ReturnSeq(valuesPerCall ...[]any) *SourceErrorCall {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *SourceErrorCall) Do(f func() string) *SourceErrorCall {
 	c.Call = c.Call.Do(f)
@@ -72,6 +78,22 @@ func (c *SourceErrorCall) DoAndReturn(f func() string) *SourceErrorCall {
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *SourceErrorCall) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubError makes Error behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockSource) StubError(fn func() string) *SourceErrorCall {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Error()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Method mocks base method.
 func (m *MockSource) Method() faux.Return {
 	m.ctrl.T.Helper()
@@ -98,6 +120,12 @@ func (c *SourceMethodCall) Return(arg0 faux.Return) *SourceMethodCall {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *SourceMethodCall) ReturnSeq(valuesPerCall ...[]any) *SourceMethodCall {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *SourceMethodCall) Do(f func() faux.Return) *SourceMethodCall {
 	c.Call = c.Call.Do(f)
@@ -109,3 +137,19 @@ func (c *SourceMethodCall) DoAndReturn(f func() faux.Return) *SourceMethodCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *SourceMethodCall) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubMethod makes Method behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockSource) StubMethod(fn func() faux.Return) *SourceMethodCall {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Method()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
@@ -8,3 +8,9 @@ require (
 	go.uber.org/mock v0.0.0-00010101000000-000000000000
 	golang.org/x/exp v0.0.0-20220609121020-a51bd0440498
 )
+
+require (
+	github.com/golang/protobuf v1.5.0 // indirect
+	github.com/google/go-cmp v0.5.5 // indirect
+	google.golang.org/protobuf v1.30.0 // indirect
+)
@@ -0,0 +1,13 @@
+package source
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock/examplecheck"
+	"go.uber.org/mock/mockgen/internal/tests/typed"
+)
+
+func TestMocks(t *testing.T) {
+	examplecheck.Check[typed.Bar[int, string]](t, NewMockBar[int, string])
+	examplecheck.Check[typed.ExternalConstraint[int, float64]](t, NewMockExternalConstraint[int, float64])
+}
@@ -62,6 +62,12 @@ func (c *ExternalConstraintEightCall[I, F]) Return(arg0 other.Two[I, F]) *Extern
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintEightCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintEightCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintEightCall[I, F]) Do(f func(F) other.Two[I, F]) *ExternalConstraintEightCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -74,6 +80,23 @@ func (c *ExternalConstraintEightCall[I, F]) DoAndReturn(f func(F) other.Two[I, F
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintEightCall[I, F]) EachReceivedArgs(f func(F)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(F)
+		f(arg0)
+	}
+}
+
+// StubEight makes Eight behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubEight(fn func(F) other.Two[I, F]) *ExternalConstraintEightCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Eight(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Five mocks base method.
 func (m *MockExternalConstraint[I, F]) Five(arg0 I) typed.Baz[F] {
 	m.ctrl.T.Helper()
@@ -100,6 +123,12 @@ func (c *ExternalConstraintFiveCall[I, F]) Return(arg0 typed.Baz[F]) *ExternalCo
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintFiveCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintFiveCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintFiveCall[I, F]) Do(f func(I) typed.Baz[F]) *ExternalConstraintFiveCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -112,6 +141,23 @@ func (c *ExternalConstraintFiveCall[I, F]) DoAndReturn(f func(I) typed.Baz[F]) *
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintFiveCall[I, F]) EachReceivedArgs(f func(I)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(I)
+		f(arg0)
+	}
+}
+
+// StubFive makes Five behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubFive(fn func(I) typed.Baz[F]) *ExternalConstraintFiveCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Five(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Four mocks base method.
 func (m *MockExternalConstraint[I, F]) Four(arg0 I) typed.Foo[I, F] {
 	m.ctrl.T.Helper()
@@ -138,6 +184,12 @@ func (c *ExternalConstraintFourCall[I, F]) Return(arg0 typed.Foo[I, F]) *Externa
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintFourCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintFourCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintFourCall[I, F]) Do(f func(I) typed.Foo[I, F]) *ExternalConstraintFourCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -150,6 +202,23 @@ func (c *ExternalConstraintFourCall[I, F]) DoAndReturn(f func(I) typed.Foo[I, F]
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintFourCall[I, F]) EachReceivedArgs(f func(I)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(I)
+		f(arg0)
+	}
+}
+
+// StubFour makes Four behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubFour(fn func(I) typed.Foo[I, F]) *ExternalConstraintFourCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Four(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Nine mocks base method.
 func (m *MockExternalConstraint[I, F]) Nine(arg0 typed.Iface[I]) {
 	m.ctrl.T.Helper()
@@ -174,6 +243,12 @@ func (c *ExternalConstraintNineCall[I, F]) Return() *ExternalConstraintNineCall[
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintNineCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintNineCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintNineCall[I, F]) Do(f func(typed.Iface[I])) *ExternalConstraintNineCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -186,6 +261,23 @@ func (c *ExternalConstraintNineCall[I, F]) DoAndReturn(f func(typed.Iface[I])) *
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintNineCall[I, F]) EachReceivedArgs(f func(typed.Iface[I])) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(typed.Iface[I])
+		f(arg0)
+	}
+}
+
+// StubNine makes Nine behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubNine(fn func(typed.Iface[I])) *ExternalConstraintNineCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Nine(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // One mocks base method.
 func (m *MockExternalConstraint[I, F]) One(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -212,6 +304,12 @@ func (c *ExternalConstraintOneCall[I, F]) Return(arg0 string) *ExternalConstrain
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintOneCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintOneCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintOneCall[I, F]) Do(f func(string) string) *ExternalConstraintOneCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -224,6 +322,23 @@ func (c *ExternalConstraintOneCall[I, F]) DoAndReturn(f func(string) string) *Ex
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintOneCall[I, F]) EachReceivedArgs(f func(string)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(string)
+		f(arg0)
+	}
+}
+
+// StubOne makes One behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubOne(fn func(string) string) *ExternalConstraintOneCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().One(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Seven mocks base method.
 func (m *MockExternalConstraint[I, F]) Seven(arg0 I) other.One[I] {
 	m.ctrl.T.Helper()
@@ -250,6 +365,12 @@ func (c *ExternalConstraintSevenCall[I, F]) Return(arg0 other.One[I]) *ExternalC
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintSevenCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintSevenCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintSevenCall[I, F]) Do(f func(I) other.One[I]) *ExternalConstraintSevenCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -262,6 +383,23 @@ func (c *ExternalConstraintSevenCall[I, F]) DoAndReturn(f func(I) other.One[I])
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintSevenCall[I, F]) EachReceivedArgs(f func(I)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(I)
+		f(arg0)
+	}
+}
+
+// StubSeven makes Seven behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubSeven(fn func(I) other.One[I]) *ExternalConstraintSevenCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Seven(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Six mocks base method.
 func (m *MockExternalConstraint[I, F]) Six(arg0 I) *typed.Baz[F] {
 	m.ctrl.T.Helper()
@@ -288,6 +426,12 @@ func (c *ExternalConstraintSixCall[I, F]) Return(arg0 *typed.Baz[F]) *ExternalCo
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintSixCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintSixCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintSixCall[I, F]) Do(f func(I) *typed.Baz[F]) *ExternalConstraintSixCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -300,6 +444,23 @@ func (c *ExternalConstraintSixCall[I, F]) DoAndReturn(f func(I) *typed.Baz[F]) *
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintSixCall[I, F]) EachReceivedArgs(f func(I)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(I)
+		f(arg0)
+	}
+}
+
+// StubSix makes Six behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubSix(fn func(I) *typed.Baz[F]) *ExternalConstraintSixCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Six(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Ten mocks base method.
 func (m *MockExternalConstraint[I, F]) Ten(arg0 *I) {
 	m.ctrl.T.Helper()
@@ -324,6 +485,12 @@ func (c *ExternalConstraintTenCall[I, F]) Return() *ExternalConstraintTenCall[I,
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintTenCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintTenCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintTenCall[I, F]) Do(f func(*I)) *ExternalConstraintTenCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -336,6 +503,23 @@ func (c *ExternalConstraintTenCall[I, F]) DoAndReturn(f func(*I)) *ExternalConst
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintTenCall[I, F]) EachReceivedArgs(f func(*I)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(*I)
+		f(arg0)
+	}
+}
+
+// StubTen makes Ten behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubTen(fn func(*I)) *ExternalConstraintTenCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Ten(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Three mocks base method.
 func (m *MockExternalConstraint[I, F]) Three(arg0 I) F {
 	m.ctrl.T.Helper()
@@ -362,6 +546,12 @@ func (c *ExternalConstraintThreeCall[I, F]) Return(arg0 F) *ExternalConstraintTh
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintThreeCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintThreeCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintThreeCall[I, F]) Do(f func(I) F) *ExternalConstraintThreeCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -374,6 +564,23 @@ func (c *ExternalConstraintThreeCall[I, F]) DoAndReturn(f func(I) F) *ExternalCo
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintThreeCall[I, F]) EachReceivedArgs(f func(I)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(I)
+		f(arg0)
+	}
+}
+
+// StubThree makes Three behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubThree(fn func(I) F) *ExternalConstraintThreeCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Three(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Two mocks base method.
 func (m *MockExternalConstraint[I, F]) Two(arg0 I) string {
 	m.ctrl.T.Helper()
@@ -400,6 +607,12 @@ func (c *ExternalConstraintTwoCall[I, F]) Return(arg0 string) *ExternalConstrain
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *ExternalConstraintTwoCall[I, F]) ReturnSeq(valuesPerCall ...[]any) *ExternalConstraintTwoCall[I, F] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *ExternalConstraintTwoCall[I, F]) Do(f func(I) string) *ExternalConstraintTwoCall[I, F] {
 	c.Call = c.Call.Do(f)
@@ -411,3 +624,20 @@ func (c *ExternalConstraintTwoCall[I, F]) DoAndReturn(f func(I) string) *Externa
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *ExternalConstraintTwoCall[I, F]) EachReceivedArgs(f func(I)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(I)
+		f(arg0)
+	}
+}
+
+// StubTwo makes Two behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockExternalConstraint[I, F]) StubTwo(fn func(I) string) *ExternalConstraintTwoCall[I, F] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Two(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
@@ -61,6 +61,12 @@ func (c *BarEightCall[T, R]) Return(arg0 other.Two[T, R]) *BarEightCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarEightCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarEightCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarEightCall[T, R]) Do(f func(T) other.Two[T, R]) *BarEightCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -73,6 +79,23 @@ func (c *BarEightCall[T, R]) DoAndReturn(f func(T) other.Two[T, R]) *BarEightCal
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarEightCall[T, R]) EachReceivedArgs(f func(T)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(T)
+		f(arg0)
+	}
+}
+
+// StubEight makes Eight behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubEight(fn func(T) other.Two[T, R]) *BarEightCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Eight(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Eighteen mocks base method.
 func (m *MockBar[T, R]) Eighteen() (typed.Iface[*other.Five], error) {
 	m.ctrl.T.Helper()
@@ -100,6 +123,12 @@ func (c *BarEighteenCall[T, R]) Return(arg0 typed.Iface[*other.Five], arg1 error
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarEighteenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarEighteenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarEighteenCall[T, R]) Do(f func() (typed.Iface[*other.Five], error)) *BarEighteenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -112,6 +141,22 @@ func (c *BarEighteenCall[T, R]) DoAndReturn(f func() (typed.Iface[*other.Five],
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarEighteenCall[T, R]) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubEighteen makes Eighteen behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubEighteen(fn func() (typed.Iface[*other.Five], error)) *BarEighteenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Eighteen()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Eleven mocks base method.
 func (m *MockBar[T, R]) Eleven() (*other.One[T], error) {
 	m.ctrl.T.Helper()
@@ -139,6 +184,12 @@ func (c *BarElevenCall[T, R]) Return(arg0 *other.One[T], arg1 error) *BarElevenC
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarElevenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarElevenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarElevenCall[T, R]) Do(f func() (*other.One[T], error)) *BarElevenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -151,6 +202,22 @@ func (c *BarElevenCall[T, R]) DoAndReturn(f func() (*other.One[T], error)) *BarE
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarElevenCall[T, R]) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubEleven makes Eleven behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubEleven(fn func() (*other.One[T], error)) *BarElevenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Eleven()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Fifteen mocks base method.
 func (m *MockBar[T, R]) Fifteen() (typed.Iface[typed.StructType], error) {
 	m.ctrl.T.Helper()
@@ -178,6 +245,12 @@ func (c *BarFifteenCall[T, R]) Return(arg0 typed.Iface[typed.StructType], arg1 e
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarFifteenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarFifteenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarFifteenCall[T, R]) Do(f func() (typed.Iface[typed.StructType], error)) *BarFifteenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -190,6 +263,22 @@ func (c *BarFifteenCall[T, R]) DoAndReturn(f func() (typed.Iface[typed.StructTyp
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarFifteenCall[T, R]) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubFifteen makes Fifteen behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubFifteen(fn func() (typed.Iface[typed.StructType], error)) *BarFifteenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Fifteen()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Five mocks base method.
 func (m *MockBar[T, R]) Five(arg0 T) typed.Baz[T] {
 	m.ctrl.T.Helper()
@@ -216,6 +305,12 @@ func (c *BarFiveCall[T, R]) Return(arg0 typed.Baz[T]) *BarFiveCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarFiveCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarFiveCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarFiveCall[T, R]) Do(f func(T) typed.Baz[T]) *BarFiveCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -228,6 +323,23 @@ func (c *BarFiveCall[T, R]) DoAndReturn(f func(T) typed.Baz[T]) *BarFiveCall[T,
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarFiveCall[T, R]) EachReceivedArgs(f func(T)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(T)
+		f(arg0)
+	}
+}
+
+// StubFive makes Five behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubFive(fn func(T) typed.Baz[T]) *BarFiveCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Five(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Four mocks base method.
 func (m *MockBar[T, R]) Four(arg0 T) typed.Foo[T, R] {
 	m.ctrl.T.Helper()
@@ -254,6 +366,12 @@ func (c *BarFourCall[T, R]) Return(arg0 typed.Foo[T, R]) *BarFourCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarFourCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarFourCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarFourCall[T, R]) Do(f func(T) typed.Foo[T, R]) *BarFourCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -266,6 +384,23 @@ func (c *BarFourCall[T, R]) DoAndReturn(f func(T) typed.Foo[T, R]) *BarFourCall[
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarFourCall[T, R]) EachReceivedArgs(f func(T)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(T)
+		f(arg0)
+	}
+}
+
+// StubFour makes Four behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubFour(fn func(T) typed.Foo[T, R]) *BarFourCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Four(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Fourteen mocks base method.
 func (m *MockBar[T, R]) Fourteen() (*typed.Foo[typed.StructType, typed.StructType2], error) {
 	m.ctrl.T.Helper()
@@ -293,6 +428,12 @@ func (c *BarFourteenCall[T, R]) Return(arg0 *typed.Foo[typed.StructType, typed.S
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarFourteenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarFourteenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarFourteenCall[T, R]) Do(f func() (*typed.Foo[typed.StructType, typed.StructType2], error)) *BarFourteenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -305,6 +446,22 @@ func (c *BarFourteenCall[T, R]) DoAndReturn(f func() (*typed.Foo[typed.StructTyp
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarFourteenCall[T, R]) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubFourteen makes Fourteen behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubFourteen(fn func() (*typed.Foo[typed.StructType, typed.StructType2], error)) *BarFourteenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Fourteen()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Nine mocks base method.
 func (m *MockBar[T, R]) Nine(arg0 typed.Iface[T]) {
 	m.ctrl.T.Helper()
@@ -329,6 +486,12 @@ func (c *BarNineCall[T, R]) Return() *BarNineCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarNineCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarNineCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarNineCall[T, R]) Do(f func(typed.Iface[T])) *BarNineCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -341,6 +504,23 @@ func (c *BarNineCall[T, R]) DoAndReturn(f func(typed.Iface[T])) *BarNineCall[T,
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarNineCall[T, R]) EachReceivedArgs(f func(typed.Iface[T])) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(typed.Iface[T])
+		f(arg0)
+	}
+}
+
+// StubNine makes Nine behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubNine(fn func(typed.Iface[T])) *BarNineCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Nine(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Nineteen mocks base method.
 func (m *MockBar[T, R]) Nineteen() typed.AliasType {
 	m.ctrl.T.Helper()
@@ -367,6 +547,12 @@ func (c *BarNineteenCall[T, R]) Return(arg0 typed.AliasType) *BarNineteenCall[T,
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarNineteenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarNineteenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarNineteenCall[T, R]) Do(f func() typed.AliasType) *BarNineteenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -379,6 +565,22 @@ func (c *BarNineteenCall[T, R]) DoAndReturn(f func() typed.AliasType) *BarNinete
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarNineteenCall[T, R]) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubNineteen makes Nineteen behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubNineteen(fn func() typed.AliasType) *BarNineteenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Nineteen()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // One mocks base method.
 func (m *MockBar[T, R]) One(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -405,6 +607,12 @@ func (c *BarOneCall[T, R]) Return(arg0 string) *BarOneCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarOneCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarOneCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarOneCall[T, R]) Do(f func(string) string) *BarOneCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -417,6 +625,23 @@ func (c *BarOneCall[T, R]) DoAndReturn(f func(string) string) *BarOneCall[T, R]
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarOneCall[T, R]) EachReceivedArgs(f func(string)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(string)
+		f(arg0)
+	}
+}
+
+// StubOne makes One behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubOne(fn func(string) string) *BarOneCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().One(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Seven mocks base method.
 func (m *MockBar[T, R]) Seven(arg0 T) other.One[T] {
 	m.ctrl.T.Helper()
@@ -443,6 +668,12 @@ func (c *BarSevenCall[T, R]) Return(arg0 other.One[T]) *BarSevenCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarSevenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarSevenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarSevenCall[T, R]) Do(f func(T) other.One[T]) *BarSevenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -455,6 +686,23 @@ func (c *BarSevenCall[T, R]) DoAndReturn(f func(T) other.One[T]) *BarSevenCall[T
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarSevenCall[T, R]) EachReceivedArgs(f func(T)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(T)
+		f(arg0)
+	}
+}
+
+// StubSeven makes Seven behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubSeven(fn func(T) other.One[T]) *BarSevenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Seven(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Seventeen mocks base method.
 func (m *MockBar[T, R]) Seventeen() (*typed.Foo[other.Three, other.Four], error) {
 	m.ctrl.T.Helper()
@@ -482,6 +730,12 @@ func (c *BarSeventeenCall[T, R]) Return(arg0 *typed.Foo[other.Three, other.Four]
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarSeventeenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarSeventeenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarSeventeenCall[T, R]) Do(f func() (*typed.Foo[other.Three, other.Four], error)) *BarSeventeenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -494,6 +748,22 @@ func (c *BarSeventeenCall[T, R]) DoAndReturn(f func() (*typed.Foo[other.Three, o
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarSeventeenCall[T, R]) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubSeventeen makes Seventeen behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubSeventeen(fn func() (*typed.Foo[other.Three, other.Four], error)) *BarSeventeenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Seventeen()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Six mocks base method.
 func (m *MockBar[T, R]) Six(arg0 T) *typed.Baz[T] {
 	m.ctrl.T.Helper()
@@ -520,6 +790,12 @@ func (c *BarSixCall[T, R]) Return(arg0 *typed.Baz[T]) *BarSixCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarSixCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarSixCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarSixCall[T, R]) Do(f func(T) *typed.Baz[T]) *BarSixCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -532,6 +808,23 @@ func (c *BarSixCall[T, R]) DoAndReturn(f func(T) *typed.Baz[T]) *BarSixCall[T, R
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarSixCall[T, R]) EachReceivedArgs(f func(T)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(T)
+		f(arg0)
+	}
+}
+
+// StubSix makes Six behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubSix(fn func(T) *typed.Baz[T]) *BarSixCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Six(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Sixteen mocks base method.
 func (m *MockBar[T, R]) Sixteen() (typed.Baz[other.Three], error) {
 	m.ctrl.T.Helper()
@@ -559,6 +852,12 @@ func (c *BarSixteenCall[T, R]) Return(arg0 typed.Baz[other.Three], arg1 error) *
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarSixteenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarSixteenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarSixteenCall[T, R]) Do(f func() (typed.Baz[other.Three], error)) *BarSixteenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -571,6 +870,22 @@ func (c *BarSixteenCall[T, R]) DoAndReturn(f func() (typed.Baz[other.Three], err
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarSixteenCall[T, R]) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubSixteen makes Sixteen behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubSixteen(fn func() (typed.Baz[other.Three], error)) *BarSixteenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Sixteen()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Ten mocks base method.
 func (m *MockBar[T, R]) Ten(arg0 *T) {
 	m.ctrl.T.Helper()
@@ -595,6 +910,12 @@ func (c *BarTenCall[T, R]) Return() *BarTenCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarTenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarTenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarTenCall[T, R]) Do(f func(*T)) *BarTenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -607,6 +928,23 @@ func (c *BarTenCall[T, R]) DoAndReturn(f func(*T)) *BarTenCall[T, R] {
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarTenCall[T, R]) EachReceivedArgs(f func(*T)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(*T)
+		f(arg0)
+	}
+}
+
+// StubTen makes Ten behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubTen(fn func(*T)) *BarTenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Ten(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Thirteen mocks base method.
 func (m *MockBar[T, R]) Thirteen() (typed.Baz[typed.StructType], error) {
 	m.ctrl.T.Helper()
@@ -634,6 +972,12 @@ func (c *BarThirteenCall[T, R]) Return(arg0 typed.Baz[typed.StructType], arg1 er
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarThirteenCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarThirteenCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarThirteenCall[T, R]) Do(f func() (typed.Baz[typed.StructType], error)) *BarThirteenCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -646,6 +990,22 @@ func (c *BarThirteenCall[T, R]) DoAndReturn(f func() (typed.Baz[typed.StructType
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarThirteenCall[T, R]) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubThirteen makes Thirteen behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubThirteen(fn func() (typed.Baz[typed.StructType], error)) *BarThirteenCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Thirteen()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Three mocks base method.
 func (m *MockBar[T, R]) Three(arg0 T) R {
 	m.ctrl.T.Helper()
@@ -672,6 +1032,12 @@ func (c *BarThreeCall[T, R]) Return(arg0 R) *BarThreeCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarThreeCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarThreeCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarThreeCall[T, R]) Do(f func(T) R) *BarThreeCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -684,6 +1050,23 @@ func (c *BarThreeCall[T, R]) DoAndReturn(f func(T) R) *BarThreeCall[T, R] {
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarThreeCall[T, R]) EachReceivedArgs(f func(T)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(T)
+		f(arg0)
+	}
+}
+
+// StubThree makes Three behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubThree(fn func(T) R) *BarThreeCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Three(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Twelve mocks base method.
 func (m *MockBar[T, R]) Twelve() (*other.Two[T, R], error) {
 	m.ctrl.T.Helper()
@@ -711,6 +1094,12 @@ func (c *BarTwelveCall[T, R]) Return(arg0 *other.Two[T, R], arg1 error) *BarTwel
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarTwelveCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarTwelveCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarTwelveCall[T, R]) Do(f func() (*other.Two[T, R], error)) *BarTwelveCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -723,6 +1112,22 @@ func (c *BarTwelveCall[T, R]) DoAndReturn(f func() (*other.Two[T, R], error)) *B
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarTwelveCall[T, R]) EachReceivedArgs(f func()) {
+	for range c.Call.ReceivedArgs() {
+		f()
+	}
+}
+
+// StubTwelve makes Twelve behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubTwelve(fn func() (*other.Two[T, R], error)) *BarTwelveCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Twelve()
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // Two mocks base method.
 func (m *MockBar[T, R]) Two(arg0 T) string {
 	m.ctrl.T.Helper()
@@ -749,6 +1154,12 @@ func (c *BarTwoCall[T, R]) Return(arg0 string) *BarTwoCall[T, R] {
 	return c
 }
 
+// ReturnSeq rewrite *gomock.Call.ReturnSeq
+func (c *BarTwoCall[T, R]) ReturnSeq(valuesPerCall ...[]any) *BarTwoCall[T, R] {
+	c.Call = c.Call.ReturnSeq(valuesPerCall...)
+	return c
+}
+
 // Do rewrite *gomock.Call.Do
 func (c *BarTwoCall[T, R]) Do(f func(T) string) *BarTwoCall[T, R] {
 	c.Call = c.Call.Do(f)
@@ -761,6 +1172,23 @@ func (c *BarTwoCall[T, R]) DoAndReturn(f func(T) string) *BarTwoCall[T, R] {
 	return c
 }
 
+// EachReceivedArgs calls f with the arguments of each call matched by c, in order
+func (c *BarTwoCall[T, R]) EachReceivedArgs(f func(T)) {
+	for _, args := range c.Call.ReceivedArgs() {
+		arg0, _ := args[0].(T)
+		f(arg0)
+	}
+}
+
+// StubTwo makes Two behave like fn for the rest of the test, whatever its
+// arguments.
+func (m *MockBar[T, R]) StubTwo(fn func(T) string) *BarTwoCall[T, R] {
+	m.ctrl.T.Helper()
+	call := m.EXPECT().Two(gomock.Any())
+	call.AnyTimes()
+	return call.DoAndReturn(fn)
+}
+
 // MockIface is a mock of Iface interface.
 type MockIface[T any] struct {
 	ctrl     *gomock.Controller
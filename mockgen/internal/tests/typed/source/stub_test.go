@@ -0,0 +1,46 @@
+package source
+
+import (
+	"strconv"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestStub(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockExternalConstraint[int, float64](ctrl)
+
+	var got []int
+	m.StubTwo(func(i int) string {
+		got = append(got, i)
+		return strconv.Itoa(i)
+	})
+	for i := 0; i < 3; i++ {
+		if s := m.Two(i); s != strconv.Itoa(i) {
+			t.Errorf("Two(%d) = %q, want %q", i, s, strconv.Itoa(i))
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("stub called %d times, want 3", len(got))
+	}
+}
+
+func TestStubNotCalled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockExternalConstraint[int, float64](ctrl)
+
+	m.StubOne(func(s string) string { return s })
+}
+
+func TestReturnSeq(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockExternalConstraint[int, float64](ctrl)
+
+	m.EXPECT().One("a").ReturnSeq([]any{"x"}, []any{"y"}).Times(2)
+	for _, want := range []string{"x", "y"} {
+		if got := m.One("a"); got != want {
+			t.Errorf("One(%q) = %q, want %q", "a", got, want)
+		}
+	}
+}
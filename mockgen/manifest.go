@@ -0,0 +1,245 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// manifestFile is the name of the manifest written by -manifest into the
+// directory of the destination.
+const manifestFile = "mocks_manifest.json"
+
+// manifest lists the mock files generated into a directory.
+type manifest struct {
+	Files []manifestEntry `json:"files"`
+}
+
+// manifestEntry describes a mock file and how it was generated.
+type manifestEntry struct {
+	// Destination is the name of the mock file.
+	Destination string `json:"destination"`
+	// Source is the source file of the interfaces, relative to the
+	// directory of the manifest, in source mode.
+	Source string `json:"source,omitempty"`
+	// Package is the import path of the package of the interfaces, in
+	// reflect mode.
+	Package string `json:"package,omitempty"`
+	// Mocks are the mocks in the file.
+	Mocks []manifestMock `json:"mocks"`
+	// Args are the arguments mockgen was run with.
+	Args []string `json:"args"`
+}
+
+// manifestMock is a mock and the interface it implements.
+type manifestMock struct {
+	Interface string `json:"interface"`
+	Mock      string `json:"mock"`
+	// Combined is set for the interfaces combined by -adapters, which are
+	// not declared by the source.
+	Combined bool `json:"combined,omitempty"`
+}
+
+// updateManifest records the mocks generated by g from pkg in the manifest of
+// the directory of the destination of g, replacing any previous entry for the
+// destination.
+func updateManifest(g *generator, pkg *model.Package, args []string) error {
+	dir := filepath.Dir(g.destination)
+	m, err := readManifest(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	combined := make(map[string]bool)
+	if *adapters != "" {
+		for _, pair := range parseAdapters(*adapters) {
+			combined[pair[0]+"And"+pair[1]] = true
+		}
+	}
+	entry := manifestEntry{
+		Destination: filepath.Base(g.destination),
+		Package:     g.srcPackage,
+		Args:        args,
+	}
+	if g.filename != "" {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		absSrc, err := filepath.Abs(g.filename)
+		if err != nil {
+			return err
+		}
+		if entry.Source, err = filepath.Rel(absDir, absSrc); err != nil {
+			return err
+		}
+		entry.Source = filepath.ToSlash(entry.Source)
+	}
+	for _, intf := range pkg.Interfaces {
+		entry.Mocks = append(entry.Mocks, manifestMock{
+			Interface: intf.Name,
+			Mock:      g.mockName(intf.Name),
+			Combined:  combined[intf.Name],
+		})
+	}
+
+	files := m.Files[:0]
+	for _, e := range m.Files {
+		if e.Destination != entry.Destination {
+			files = append(files, e)
+		}
+	}
+	m.Files = append(files, entry)
+	sort.Slice(m.Files, func(i, j int) bool {
+		return m.Files[i].Destination < m.Files[j].Destination
+	})
+
+	out, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	writeOutput(filepath.Join(dir, manifestFile), append(out, '\n'))
+	return nil
+}
+
+// readManifest reads the manifest of dir.
+func readManifest(dir string) (manifest, error) {
+	var m manifest
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("%s: %v", filepath.Join(dir, manifestFile), err)
+	}
+	return m, nil
+}
+
+var mockDocRegexp = regexp.MustCompile(`^// (\w+) is a mock of (\w+) interface\.$`)
+
+// verifyManifest checks that the manifest of dir matches the mock files in
+// dir and, in source mode, the interfaces declared by their sources. It
+// returns a description of every difference found.
+func verifyManifest(dir string) ([]string, error) {
+	m, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []string
+	listed := make(map[string]bool)
+	for _, e := range m.Files {
+		listed[e.Destination] = true
+		dst := filepath.Join(dir, e.Destination)
+		data, err := os.ReadFile(dst)
+		if err != nil {
+			drift = append(drift, fmt.Sprintf("%s: %v", dst, err))
+			continue
+		}
+
+		generated := make(map[manifestMock]bool)
+		for _, line := range strings.Split(string(data), "\n") {
+			if sm := mockDocRegexp.FindStringSubmatch(line); sm != nil {
+				generated[manifestMock{Interface: sm[2], Mock: sm[1]}] = true
+			}
+		}
+		for _, mock := range e.Mocks {
+			key := manifestMock{Interface: mock.Interface, Mock: mock.Mock}
+			if !generated[key] {
+				drift = append(drift, fmt.Sprintf("%s: missing mock %s of %s", dst, mock.Mock, mock.Interface))
+			}
+			delete(generated, key)
+		}
+		for mock := range generated {
+			drift = append(drift, fmt.Sprintf("%s: mock %s of %s is not in the manifest", dst, mock.Mock, mock.Interface))
+		}
+
+		if e.Source == "" {
+			continue
+		}
+		src := filepath.Join(dir, filepath.FromSlash(e.Source))
+		declared, err := sourceInterfaces(src)
+		if err != nil {
+			drift = append(drift, err.Error())
+			continue
+		}
+		for _, mock := range e.Mocks {
+			if !mock.Combined && !declared[mock.Interface] {
+				drift = append(drift, fmt.Sprintf("%s: interface %s is no longer declared", src, mock.Interface))
+			}
+			delete(declared, mock.Interface)
+		}
+		for name := range declared {
+			drift = append(drift, fmt.Sprintf("%s: interface %s is not mocked in %s", src, name, dst))
+		}
+	}
+
+	// Mock files missing from the manifest.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || listed[name] || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Contains(data, []byte("// Code generated by MockGen. DO NOT EDIT.\n")) {
+			drift = append(drift, fmt.Sprintf("%s: mock file is not in the manifest", filepath.Join(dir, name)))
+		}
+	}
+	sort.Strings(drift)
+	return drift, nil
+}
+
+// sourceInterfaces returns the names of the interfaces declared by the Go
+// source file src.
+func sourceInterfaces(src string) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), src, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if _, ok := ts.Type.(*ast.InterfaceType); ok {
+				names[ts.Name.Name] = true
+			}
+		}
+	}
+	return names, nil
+}
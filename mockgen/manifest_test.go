@@ -0,0 +1,78 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+func TestManifest(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	verify := func(want ...string) {
+		t.Helper()
+		drift, err := verifyManifest(dir)
+		if err != nil {
+			t.Fatalf("verifyManifest: %v", err)
+		}
+		for i := range want {
+			want[i] = filepath.Join(dir, want[i])
+		}
+		if !reflect.DeepEqual(drift, want) {
+			t.Errorf("verifyManifest() == %q, want %q", drift, want)
+		}
+	}
+
+	write("foo.go", "package foo\n\ntype Foo interface{ Foo() }\n\ntype Bar interface{ Bar() }\n")
+	write("mock_foo.go", `// Code generated by MockGen. DO NOT EDIT.
+package foo
+
+// MockFoo is a mock of Foo interface.
+type MockFoo struct{}
+
+// MockBar is a mock of Bar interface.
+type MockBar struct{}
+`)
+	g := &generator{filename: filepath.Join(dir, "foo.go"), destination: filepath.Join(dir, "mock_foo.go")}
+	pkg := &model.Package{Interfaces: []*model.Interface{{Name: "Foo"}, {Name: "Bar"}}}
+	if err := updateManifest(g, pkg, []string{"-source", "foo.go"}); err != nil {
+		t.Fatalf("updateManifest: %v", err)
+	}
+	verify()
+
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := manifest{Files: []manifestEntry{{
+		Destination: "mock_foo.go",
+		Source:      "foo.go",
+		Mocks:       []manifestMock{{Interface: "Foo", Mock: "MockFoo"}, {Interface: "Bar", Mock: "MockBar"}},
+		Args:        []string{"-source", "foo.go"},
+	}}}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("manifest == %+v, want %+v", m, want)
+	}
+
+	write("foo.go", "package foo\n\ntype Foo interface{ Foo() }\n\ntype Baz interface{ Baz() }\n")
+	verify(
+		"foo.go: interface Bar is no longer declared",
+		"foo.go: interface Baz is not mocked in "+filepath.Join(dir, "mock_foo.go"),
+	)
+
+	write("foo.go", "package foo\n\ntype Foo interface{ Foo() }\n\ntype Bar interface{ Bar() }\n")
+	write("mock_foo.go", "// Code generated by MockGen. DO NOT EDIT.\npackage foo\n\n// MockFoo is a mock of Foo interface.\ntype MockFoo struct{}\n")
+	write("mock_other.go", "// Code generated by MockGen. DO NOT EDIT.\npackage foo\n")
+	verify(
+		"mock_foo.go: missing mock MockBar of Bar",
+		"mock_other.go: mock file is not in the manifest",
+	)
+}
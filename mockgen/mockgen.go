@@ -24,13 +24,16 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"go/build"
 	"go/token"
 	"io"
+	"io/fs"
 	"log"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -57,12 +60,26 @@ var (
 	destination            = flag.String("destination", "", "Output file; defaults to stdout.")
 	mockNames              = flag.String("mock_names", "", "Comma-separated interfaceName=mockName pairs of explicit mock names to use. Mock names default to 'Mock'+ interfaceName suffix.")
 	packageOut             = flag.String("package", "", "Package of the generated code; defaults to the package of the input with a 'mock_' prefix.")
+	packagePath            = flag.String("package_path", "", "The import path of the package of the -destination file, overriding the one inferred from the go.mod or GOPATH of its directory, such as for a directory of a module of mocks that is not set up yet. Ignored if -self_package is set.")
 	selfPackage            = flag.String("self_package", "", "The full package import path for the generated code. The purpose of this flag is to prevent import cycles in the generated code by trying to include its own package. This can happen if the mock's package is set to one of its inputs (usually the main one) and the output is stdio so mockgen cannot detect the final output package. Setting this flag will then tell mockgen which import to exclude.")
 	writePkgComment        = flag.Bool("write_package_comment", true, "Writes package documentation comment (godoc) if true.")
 	writeSourceComment     = flag.Bool("write_source_comment", true, "Writes original file (source mode) or interface names (reflect mode) comment if true.")
 	writeGenerateDirective = flag.Bool("write_generate_directive", false, "Add //go:generate directive to regenerate the mock")
 	copyrightFile          = flag.String("copyright_file", "", "Copyright file used to add copyright header")
+	goos                   = flag.String("goos", "", "GOOS, such as windows, to load the packages for instead of the one of the machine running mockgen, so that the interfaces of another platform can be mocked; also added to the build constraint of the generated code. In reflect mode, the interfaces of another platform are parsed from source.")
+	goarch                 = flag.String("goarch", "", "GOARCH, such as arm64, to load the packages for instead of the one of the machine running mockgen; see -goos.")
 	typed                  = flag.Bool("typed", false, "Generate Type-safe 'Return', 'Do', 'DoAndReturn' function")
+	rpcStubs               = flag.Bool("rpc_stubs", false, "Generate a 'Stub' method declaring request/response expectations for mocks of interfaces with RPC-style methods")
+	lang                   = flag.String("lang", "", "Go language version, such as go1.17, that the generated code must compile with; defaults to the latest version. Before go1.18, generic interfaces cannot be mocked and interface{} is used instead of any.")
+	recorderPackage        = flag.String("recorder_package", "", "Name of a sub-package of the destination's directory to generate the mock recorders into, keeping them out of the API of the mocks package; requires -destination.")
+	adapters               = flag.String("adapters", "", "Comma-separated oldInterface=newInterface pairs of versions of an interface to also generate a combined mock for, implementing the methods of both, and an adapter implementing the old version with the new one.")
+	foldSignatures         = flag.Bool("fold_signatures", false, "Share the code generated for the methods with the same signature, to reduce the size of the mocks of large interfaces.")
+	inherit                = flag.Bool("inherit", false, "Generate an 'InheritFrom' method moving the expectations declared on another mock, such as the mock of an embedded interface, to the mock.")
+	methodConstants        = flag.Bool("method_constants", false, "Generate a constant for the name of every method of a mock, such as MockStoreGetMethod, to use instead of a string with the gomock APIs taking method names.")
+	methodsFromUsage       = flag.String("methods_from_usage", "", "Comma-separated packages, such as ./..., whose non-test files are scanned for the methods of the mocked interfaces they use; the other methods are generated as stubs that panic, with no recorder method.")
+	closeGuard             = flag.String("close_guard", "", "Comma-separated names of methods closing the mocked objects, such as Close,Shutdown; the mocks of interfaces having one of them fail the test when called after it.")
+	nilErrorDefaults       = flag.Bool("nil_error_defaults", false, "Make the methods returning only an error, such as Close or Flush, return nil when the test declares no expectations on them, rather than failing it with an unexpected call; the other methods stay strict.")
+	writeManifest          = flag.Bool("manifest", false, "Record the generated mocks in a mocks_manifest.json file in the directory of -destination, to be checked with 'mockgen verify-manifest'; requires -destination.")
 	imports                = flag.String("imports", "", "(source mode) Comma-separated name=path pairs of explicit imports to use.")
 	auxFiles               = flag.String("aux_files", "", "(source mode) Comma-separated pkg=path pairs of auxiliary Go source files.")
 
@@ -79,6 +96,11 @@ func main() {
 		return
 	}
 
+	if flag.Arg(0) == "verify-manifest" {
+		runVerifyManifest(flag.Args()[1:])
+		return
+	}
+
 	var pkg *model.Package
 	var err error
 	var packageName string
@@ -107,6 +129,14 @@ func main() {
 		log.Fatalf("Loading input failed: %v", err)
 	}
 
+	var adapterPairs [][2]string
+	if *adapters != "" {
+		adapterPairs = parseAdapters(*adapters)
+		if err := addCombinedInterfaces(pkg, adapterPairs); err != nil {
+			log.Fatalf("Combining interfaces failed: %v", err)
+		}
+	}
+
 	if *debugParser {
 		pkg.Print(os.Stdout)
 		return
@@ -130,17 +160,55 @@ func main() {
 		dstPath, err := filepath.Abs(filepath.Dir(*destination))
 		if err == nil {
 			pkgPath, err := parsePackageImport(dstPath)
-			if err == nil {
+			switch {
+			case *packagePath != "":
+				if err == nil && pkgPath != *packagePath {
+					log.Printf("Warning: -package_path %s overrides the import path %s inferred for %s", *packagePath, pkgPath, dstPath)
+				}
+				outputPackagePath = *packagePath
+			case err == nil:
 				outputPackagePath = pkgPath
-			} else {
-				log.Println("Unable to infer -self_package from destination file path:", err)
+			default:
+				log.Println("Unable to infer -self_package from destination file path, set -package_path:", err)
 			}
 		} else {
 			log.Println("Unable to determine destination file path:", err)
 		}
+	} else if outputPackagePath == "" {
+		outputPackagePath = *packagePath
+	}
+
+	if *source != "" && isTestFile(*source) {
+		// Declarations of a test file can only be used by the tests of its
+		// own package, so the mocks are generated into that package.
+		if *packageOut == "" {
+			outputPackageName = pkg.Name
+		}
+		if outputPackageName == pkg.Name && *selfPackage == "" {
+			outputPackagePath = pkg.PkgPath
+		}
+		if *destination != "" && !isTestFile(*destination) {
+			log.Printf("Warning: mocks of %s should be written to a _test.go file", *source)
+		}
+	}
+
+	if *destination != "" {
+		if err := checkDestinationPackage(*destination, outputPackageName); err != nil {
+			log.Fatalf("Bad -destination: %v", err)
+		}
 	}
 
 	g := new(generator)
+	if *lang != "" {
+		minor, err := parseLang(*lang)
+		if err != nil {
+			log.Fatalf("Bad -lang: %v", err)
+		}
+		g.goMinor = minor
+		if minor < 18 {
+			spellAnyAsInterface(pkg)
+		}
+	}
 	if *source != "" {
 		g.filename = *source
 	} else {
@@ -148,6 +216,23 @@ func main() {
 		g.srcInterfaces = flag.Arg(1)
 	}
 	g.destination = *destination
+	g.buildConstraint = platformConstraint()
+	g.foldSignatures = *foldSignatures
+	g.adapters = adapterPairs
+	g.methodConstants = *methodConstants
+	g.nilErrorDefaults = *nilErrorDefaults
+	if *closeGuard != "" {
+		g.closeMethods = make(map[string]bool)
+		for _, name := range strings.Split(*closeGuard, ",") {
+			g.closeMethods[strings.TrimSpace(name)] = true
+		}
+	}
+	if *methodsFromUsage != "" {
+		g.usedMethods, err = usedNames(strings.Split(*methodsFromUsage, ","))
+		if err != nil {
+			log.Fatalf("Scanning -methods_from_usage failed: %v", err)
+		}
+	}
 
 	if *mockNames != "" {
 		g.mockNames = parseMockNames(*mockNames)
@@ -160,23 +245,112 @@ func main() {
 
 		g.copyrightHeader = string(header)
 	}
+	if *recorderPackage != "" {
+		rg := generateRecorders(g, pkg)
+		writeOutput(rg.destination, rg.Output())
+	}
 	if err := g.Generate(pkg, outputPackageName, outputPackagePath); err != nil {
 		log.Fatalf("Failed generating mock: %v", err)
 	}
-	output := g.Output()
+	writeOutput(*destination, g.Output())
+	if *writeManifest {
+		if *destination == "" {
+			log.Fatal("-manifest requires -destination")
+		}
+		if err := updateManifest(g, pkg, os.Args[1:]); err != nil {
+			log.Fatalf("Failed updating manifest: %v", err)
+		}
+	}
+}
+
+// runVerifyManifest checks the manifests of the given directories, or of the
+// current directory, and exits with an error if any does not match the mocks.
+func runVerifyManifest(dirs []string) {
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	var failed bool
+	for _, dir := range dirs {
+		drift, err := verifyManifest(dir)
+		if err != nil {
+			log.Fatalf("Verifying manifest failed: %v", err)
+		}
+		for _, d := range drift {
+			fmt.Fprintln(os.Stderr, d)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// generateRecorders generates the recorders of the mocks generated by g into
+// the sub-package named by -recorder_package, and points g at them.
+func generateRecorders(g *generator, pkg *model.Package) *generator {
+	if *destination == "" {
+		log.Fatal("-recorder_package requires -destination")
+	}
+	for _, intf := range pkg.Interfaces {
+		if len(intf.TypeParams) > 0 {
+			log.Fatalf("-recorder_package does not support generic interface %s", intf.Name)
+		}
+		for _, m := range intf.Methods {
+			if !token.IsExported(m.Name) {
+				log.Fatalf("-recorder_package does not support unexported method %s of %s", m.Name, intf.Name)
+			}
+		}
+	}
+
+	dir := filepath.Join(filepath.Dir(*destination), *recorderPackage)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		log.Fatalf("Unable to create directory: %v", err)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		log.Fatalf("Unable to determine recorder package path: %v", err)
+	}
+	recorderPath, err := parsePackageImport(absDir)
+	if err != nil {
+		log.Fatalf("Unable to determine recorder package path: %v", err)
+	}
+
+	rg := &generator{
+		mockNames:       g.mockNames,
+		filename:        g.filename,
+		destination:     filepath.Join(dir, filepath.Base(*destination)),
+		srcPackage:      g.srcPackage,
+		srcInterfaces:   g.srcInterfaces,
+		copyrightHeader: g.copyrightHeader,
+		buildConstraint: g.buildConstraint,
+		goMinor:         g.goMinor,
+		foldSignatures:  g.foldSignatures,
+		usedMethods:     g.usedMethods,
+		recordersOnly:   true,
+	}
+	if err := rg.Generate(pkg, sanitize(*recorderPackage), recorderPath); err != nil {
+		log.Fatalf("Failed generating mock recorders: %v", err)
+	}
+	g.recorderPkg = recorderPath
+	return rg
+}
+
+// writeOutput writes output to destination, or to stdout if destination is
+// empty. An existing destination with the same content is left untouched.
+func writeOutput(destination string, output []byte) {
 	dst := os.Stdout
-	if len(*destination) > 0 {
-		if err := os.MkdirAll(filepath.Dir(*destination), os.ModePerm); err != nil {
+	if len(destination) > 0 {
+		if err := os.MkdirAll(filepath.Dir(destination), os.ModePerm); err != nil {
 			log.Fatalf("Unable to create directory: %v", err)
 		}
-		existing, err := os.ReadFile(*destination)
+		existing, err := os.ReadFile(destination)
 		if err != nil && !errors.Is(err, os.ErrNotExist) {
 			log.Fatalf("Failed reading pre-exiting destination file: %v", err)
 		}
 		if len(existing) == len(output) && bytes.Compare(existing, output) == 0 {
 			return
 		}
-		f, err := os.Create(*destination)
+		f, err := os.Create(destination)
 		if err != nil {
 			log.Fatalf("Failed opening destination file: %v", err)
 		}
@@ -200,6 +374,205 @@ func parseMockNames(names string) map[string]string {
 	return mocksMap
 }
 
+// parseLang parses a Go language version such as go1.20, and returns its
+// minor version.
+func parseLang(lang string) (int, error) {
+	m := regexp.MustCompile(`^go1\.(\d+)(\.\d+)?$`).FindStringSubmatch(lang)
+	if m == nil {
+		return 0, fmt.Errorf("%q is not a Go version such as go1.20", lang)
+	}
+	return strconv.Atoi(m[1])
+}
+
+// spellAnyAsInterface replaces any with interface{} in the types of the
+// methods of pkg, for Go versions before go1.18.
+func spellAnyAsInterface(pkg *model.Package) {
+	var spell func(t model.Type) model.Type
+	params := func(ps []*model.Parameter) {
+		for _, p := range ps {
+			p.Type = spell(p.Type)
+		}
+	}
+	spell = func(t model.Type) model.Type {
+		switch t := t.(type) {
+		case model.PredeclaredType:
+			if t == "any" {
+				return model.PredeclaredType("interface{}")
+			}
+		case *model.ArrayType:
+			t.Type = spell(t.Type)
+		case *model.ChanType:
+			t.Type = spell(t.Type)
+		case *model.FuncType:
+			params(t.In)
+			params(t.Out)
+			if t.Variadic != nil {
+				params([]*model.Parameter{t.Variadic})
+			}
+		case *model.MapType:
+			t.Key, t.Value = spell(t.Key), spell(t.Value)
+		case *model.PointerType:
+			t.Type = spell(t.Type)
+		}
+		return t
+	}
+	for _, intf := range pkg.Interfaces {
+		for _, m := range intf.Methods {
+			params(m.In)
+			params(m.Out)
+			if m.Variadic != nil {
+				params([]*model.Parameter{m.Variadic})
+			}
+		}
+	}
+}
+
+// parseAdapters parses the -adapters flag into pairs of old and new
+// interface names.
+func parseAdapters(pairs string) [][2]string {
+	var adapters [][2]string
+	for _, kv := range strings.Split(pairs, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			log.Fatalf("bad adapters spec: %v", kv)
+		}
+		adapters = append(adapters, [2]string{parts[0], parts[1]})
+	}
+	return adapters
+}
+
+// addCombinedInterfaces adds to pkg, for each pair of versions of an
+// interface, an interface named OldAndNew with the methods of both versions,
+// so that its mock can stand in for either version while code migrates from
+// the old version to the new one. Methods present in both versions must have
+// the same signature.
+func addCombinedInterfaces(pkg *model.Package, adapters [][2]string) error {
+	// Qualify types with their full package paths to compare signatures.
+	pm := make(map[string]string)
+	for pth := range pkg.Imports() {
+		pm[pth] = pth
+	}
+	byName := make(map[string]*model.Interface, len(pkg.Interfaces))
+	for _, intf := range pkg.Interfaces {
+		byName[intf.Name] = intf
+	}
+	for _, pair := range adapters {
+		oldIntf, newIntf := byName[pair[0]], byName[pair[1]]
+		if oldIntf == nil || newIntf == nil {
+			return fmt.Errorf("interfaces %s and %s must both be mocked", pair[0], pair[1])
+		}
+		if len(oldIntf.TypeParams) > 0 || len(newIntf.TypeParams) > 0 {
+			return fmt.Errorf("cannot combine generic interfaces %s and %s", pair[0], pair[1])
+		}
+		combined := &model.Interface{Name: pair[0] + "And" + pair[1]}
+		for _, m := range newIntf.Methods {
+			combined.AddMethod(m)
+		}
+		for _, m := range oldIntf.Methods {
+			for _, other := range combined.Methods {
+				if other.Name == m.Name && methodSignature(other, pm) != methodSignature(m, pm) {
+					return fmt.Errorf("method %s has different signatures in %s and %s", m.Name, pair[0], pair[1])
+				}
+			}
+			combined.AddMethod(m)
+		}
+		pkg.Interfaces = append(pkg.Interfaces, combined)
+	}
+	return nil
+}
+
+// methodSignature returns the signature of m, with types qualified by pm.
+func methodSignature(m *model.Method, pm map[string]string) string {
+	params := func(ps []*model.Parameter) string {
+		types := make([]string, len(ps))
+		for i, p := range ps {
+			types[i] = p.Type.String(pm, "")
+		}
+		return strings.Join(types, ", ")
+	}
+	sig := "(" + params(m.In)
+	if m.Variadic != nil {
+		sig += ", ..." + m.Variadic.Type.String(pm, "")
+	}
+	return sig + ") (" + params(m.Out) + ")"
+}
+
+// GenerateAdapter generates the type oldName + "From" + newName, which
+// implements the old version of an interface by forwarding to an
+// implementation of the new one, such as its mock, so that code still using
+// the old version can run against it. The methods only in the old version are
+// forwarded to function fields named after them, with a Func suffix.
+func (g *generator) GenerateAdapter(pkg *model.Package, oldName, newName, outputPackagePath string) error {
+	var oldIntf, newIntf *model.Interface
+	for _, intf := range pkg.Interfaces {
+		switch intf.Name {
+		case oldName:
+			oldIntf = intf
+		case newName:
+			newIntf = intf
+		}
+	}
+	if oldIntf == nil || newIntf == nil {
+		return fmt.Errorf("interfaces %s and %s must both be mocked", oldName, newName)
+	}
+	inNew := make(map[string]bool, len(newIntf.Methods))
+	for _, m := range newIntf.Methods {
+		inNew[m.Name] = true
+	}
+	adapterType := oldName + "From" + newName
+	newType := (&model.NamedType{Package: pkg.PkgPath, Type: newName}).String(g.packageMap, outputPackagePath)
+	if pkg.PkgPath == "" {
+		newType = newName
+	}
+
+	g.p("")
+	g.p("// %v adapts a %v to %v. The methods of %v also in", adapterType, newName, oldName, oldName)
+	g.p("// %v are forwarded to it, and the others to the function fields named", newName)
+	g.p("// after them.")
+	g.p("type %v struct {", adapterType)
+	g.in()
+	g.p("%v %v", newName, newType)
+	for _, m := range oldIntf.Methods {
+		if !inNew[m.Name] {
+			_, _, argString, retString := g.signature(m, outputPackagePath)
+			g.p("%vFunc func(%v)%v", m.Name, argString, retString)
+		}
+	}
+	g.out()
+	g.p("}")
+
+	for _, m := range oldIntf.Methods {
+		argNames, _, argString, retString := g.signature(m, outputPackagePath)
+		idRecv := newIdentifierAllocator(argNames).allocateIdentifier("a")
+		callArgs := strings.Join(argNames, ", ")
+		if m.Variadic != nil {
+			callArgs += "..."
+		}
+		ret := ""
+		if len(m.Out) > 0 {
+			ret = "return "
+		}
+
+		g.p("")
+		g.p("// %v implements %v.", m.Name, oldName)
+		g.p("func (%v *%v) %v(%v)%v {", idRecv, adapterType, m.Name, argString, retString)
+		g.in()
+		if inNew[m.Name] {
+			g.p("%v%v.%v.%v(%v)", ret, idRecv, newName, m.Name, callArgs)
+		} else {
+			g.p("if %v.%vFunc == nil {", idRecv, m.Name)
+			g.in()
+			g.p("panic(%q)", fmt.Sprintf("%s.%sFunc is not set", adapterType, m.Name))
+			g.out()
+			g.p("}")
+			g.p("%v%v.%vFunc(%v)", ret, idRecv, m.Name, callArgs)
+		}
+		g.out()
+		g.p("}")
+	}
+	return nil
+}
+
 func usage() {
 	_, _ = io.WriteString(os.Stderr, usageText)
 	flag.PrintDefaults()
@@ -220,6 +593,12 @@ comma-separated list of symbols.
 Example:
 	mockgen database/sql/driver Conn,Driver
 
+Mocks generated with -manifest are recorded in a mocks_manifest.json
+file next to them. The verify-manifest command checks that the
+manifests of the given directories match the mocks and their sources.
+Example:
+	mockgen verify-manifest ./mocks
+
 `
 
 type generator struct {
@@ -230,8 +609,56 @@ type generator struct {
 	destination               string            // may be empty
 	srcPackage, srcInterfaces string            // may be empty
 	copyrightHeader           string
+	buildConstraint           string // may be empty
 
 	packageMap map[string]string // map from import path to package name
+
+	// Names of the fields of the mock and recorder types being generated.
+	// They differ from the usual ones when a method of the mocked interface
+	// would collide with them.
+	ctrlField, recorderField, mockField string
+	// Identifiers referred to by the generated method bodies, which
+	// parameter names must not shadow.
+	reservedNames map[string]bool
+
+	// Import path of the package the recorders are generated into, if not
+	// the package of the mocks.
+	recorderPkg string
+	// Whether to only generate the recorders, into their own package.
+	recordersOnly bool
+	// Minor version of the Go language the generated code must compile
+	// with, or 0 for the latest.
+	goMinor int
+	// Whether to share the code of the methods of the same signature, see
+	// folder.
+	foldSignatures bool
+	// Pairs of old and new versions of an interface to generate adapters
+	// for, see GenerateAdapter.
+	adapters [][2]string
+	fold     *folder
+	// Whether to generate constants for the names of the methods of the
+	// mocks, see methodName.
+	methodConstants bool
+	// Names of the methods to mock, with -methods_from_usage, the others
+	// being stubs; nil to mock every method.
+	usedMethods map[string]bool
+	// Names of the methods closing the mocks, with -close_guard, and whether
+	// the mock being generated has one, its methods then failing the test
+	// when called after it.
+	closeMethods map[string]bool
+	closeGuard   bool
+	// Whether the methods returning only an error return nil when no call
+	// to them is expected, with -nil_error_defaults.
+	nilErrorDefaults bool
+}
+
+// anyType returns the empty interface type, spelled as the Go language version
+// of the generated code allows.
+func (g *generator) anyType() string {
+	if g.goMinor != 0 && g.goMinor < 18 {
+		return "interface{}"
+	}
+	return "any"
 }
 
 func (g *generator) p(format string, args ...any) {
@@ -272,8 +699,9 @@ func sanitize(s string) string {
 }
 
 func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPackagePath string) error {
-	if outputPkgName != pkg.Name && *selfPackage == "" {
+	if outputPkgName != pkg.Name && *selfPackage == "" && *packagePath == "" {
 		// reset outputPackagePath if it's not passed in through -self_package
+		// or -package_path
 		outputPackagePath = ""
 	}
 
@@ -284,6 +712,10 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 		}
 		g.p("")
 	}
+	if g.buildConstraint != "" {
+		g.p("//go:build %s", g.buildConstraint)
+		g.p("")
+	}
 
 	g.p("// Code generated by MockGen. DO NOT EDIT.")
 	if *writeSourceComment {
@@ -300,13 +732,23 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 	// Get all required imports, and generate unique names for them all.
 	im := pkg.Imports()
 	im[gomockImportPath] = true
+	if g.recorderPkg != "" {
+		im[g.recorderPkg] = true
+	}
+	// The adapters refer to the interfaces they adapt.
+	if len(g.adapters) > 0 && pkg.PkgPath != "" {
+		im[pkg.PkgPath] = true
+	}
 
 	// Only import reflect if it's used. We only use reflect in mocked methods
-	// so only import if any of the mocked interfaces have methods.
+	// so only import if any of the mocked interfaces have methods, not all
+	// left unmocked by -methods_from_usage.
 	for _, intf := range pkg.Interfaces {
-		if len(intf.Methods) > 0 {
-			im["reflect"] = true
-			break
+		for _, m := range intf.Methods {
+			if g.usedMethods == nil || g.usedMethods[m.Name] {
+				im["reflect"] = true
+				break
+			}
 		}
 	}
 
@@ -384,15 +826,24 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 	g.out()
 	g.p(")")
 
-	if *writeGenerateDirective {
+	if *writeGenerateDirective && !g.recordersOnly {
 		g.p("//go:generate %v", strings.Join(os.Args, " "))
 	}
 
+	if g.foldSignatures {
+		g.initFold(pkg, outputPackagePath)
+	}
 	for _, intf := range pkg.Interfaces {
 		if err := g.GenerateMockInterface(intf, outputPackagePath); err != nil {
 			return err
 		}
 	}
+	g.generateFolded()
+	for _, pair := range g.adapters {
+		if err := g.GenerateAdapter(pkg, pair[0], pair[1], outputPackagePath); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -431,51 +882,207 @@ func (g *generator) formattedTypeParams(it *model.Interface, pkgOverride string)
 	return long.String(), short.String()
 }
 
+// setIdentifiers picks the identifiers used by the generated code for intf so
+// that they don't collide with the names of its methods and parameters.
+func (g *generator) setIdentifiers(intf *model.Interface, mockType string) error {
+	methodNames := make([]string, len(intf.Methods))
+	for i, m := range intf.Methods {
+		if m.Name == "EXPECT" {
+			return fmt.Errorf("method %s.EXPECT collides with the EXPECT method of the generated mock", intf.Name)
+		}
+		methodNames[i] = m.Name
+	}
+	mockFields := newIdentifierAllocator(methodNames)
+	g.ctrlField = mockFields.allocateIdentifier("ctrl")
+	g.recorderField = mockFields.allocateIdentifier("recorder")
+	g.mockField = newIdentifierAllocator(methodNames).allocateIdentifier("mock")
+
+	g.reservedNames = map[string]bool{"any": true, mockType: true}
+	for _, name := range g.packageMap {
+		g.reservedNames[name] = true
+	}
+	for _, tp := range intf.TypeParams {
+		g.reservedNames[tp.Name] = true
+	}
+	for _, m := range intf.Methods {
+		g.reservedNames[intf.Name+m.Name+"Call"] = true
+		if g.methodConstants {
+			g.reservedNames[mockType+m.Name+"Method"] = true
+		}
+	}
+	if g.fold != nil {
+		for _, name := range g.fold.foldedNames() {
+			g.reservedNames[name] = true
+		}
+	}
+	return nil
+}
+
 func (g *generator) GenerateMockInterface(intf *model.Interface, outputPackagePath string) error {
 	mockType := g.mockName(intf.Name)
 	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+	if err := g.setIdentifiers(intf, mockType); err != nil {
+		return err
+	}
+	if len(intf.TypeParams) > 0 && g.goMinor != 0 && g.goMinor < 18 {
+		return fmt.Errorf("generic interface %s cannot be mocked before go1.18", intf.Name)
+	}
+	if g.recordersOnly {
+		g.GenerateMockRecorder(intf, mockType)
+		return nil
+	}
+
+	recorderType := mockType + "MockRecorder"
+	newRecorder := fmt.Sprintf("&%vMockRecorder%v{mock}", mockType, shortTp)
+	if g.recorderPkg != "" {
+		recorderType = g.packageMap[g.recorderPkg] + "." + recorderType
+		newRecorder = fmt.Sprintf("%v.New%vMockRecorder(mock, ctrl)", g.packageMap[g.recorderPkg], mockType)
+	}
 
 	g.p("")
 	g.p("// %v is a mock of %v interface.", mockType, intf.Name)
 	g.p("type %v%v struct {", mockType, longTp)
 	g.in()
-	g.p("ctrl     *gomock.Controller")
-	g.p("recorder *%vMockRecorder%v", mockType, shortTp)
+	g.p("%v *gomock.Controller", g.ctrlField)
+	g.p("%v *%v%v", g.recorderField, recorderType, shortTp)
 	g.out()
 	g.p("}")
 	g.p("")
 
-	g.p("// %vMockRecorder is the mock recorder for %v.", mockType, mockType)
-	g.p("type %vMockRecorder%v struct {", mockType, longTp)
-	g.in()
-	g.p("mock *%v%v", mockType, shortTp)
-	g.out()
-	g.p("}")
-	g.p("")
+	if g.recorderPkg == "" {
+		g.p("// %vMockRecorder is the mock recorder for %v.", mockType, mockType)
+		g.p("type %vMockRecorder%v struct {", mockType, longTp)
+		g.in()
+		g.p("%v *%v%v", g.mockField, mockType, shortTp)
+		g.out()
+		g.p("}")
+		g.p("")
+	}
 
 	g.p("// New%v creates a new mock instance.", mockType)
 	g.p("func New%v%v(ctrl *gomock.Controller) *%v%v {", mockType, longTp, mockType, shortTp)
 	g.in()
-	g.p("mock := &%v%v{ctrl: ctrl}", mockType, shortTp)
-	g.p("mock.recorder = &%vMockRecorder%v{mock}", mockType, shortTp)
+	g.p("mock := &%v%v{%v: ctrl}", mockType, shortTp, g.ctrlField)
+	g.p("mock.%v = %v", g.recorderField, newRecorder)
 	g.p("return mock")
 	g.out()
 	g.p("}")
 	g.p("")
 
-	// XXX: possible name collision here if someone has EXPECT in their interface.
 	g.p("// EXPECT returns an object that allows the caller to indicate expected use.")
-	g.p("func (m *%v%v) EXPECT() *%vMockRecorder%v {", mockType, shortTp, mockType, shortTp)
+	g.p("func (m *%v%v) EXPECT() *%v%v {", mockType, shortTp, recorderType, shortTp)
 	g.in()
-	g.p("return m.recorder")
+	g.p("return m.%v", g.recorderField)
 	g.out()
 	g.p("}")
 
+	if *rpcStubs && hasRPCMethod(intf) {
+		g.p("")
+		g.p("// Stub returns a gomock.Stub declaring request/response expectations for method.")
+		g.p("func (m *%v%v) Stub(method string) *gomock.Stub {", mockType, shortTp)
+		g.in()
+		g.p("m.%v.T.Helper()", g.ctrlField)
+		g.p("return m.%v.Stub(m, method)", g.ctrlField)
+		g.out()
+		g.p("}")
+	}
+
+	if *inherit && !hasMethod(intf, "InheritFrom") {
+		g.p("")
+		g.p("// InheritFrom moves the expectations declared on the mock from, such as the")
+		g.p("// mock of an embedded interface, to m.")
+		g.p("func (m *%v%v) InheritFrom(from %v) {", mockType, shortTp, g.anyType())
+		g.in()
+		g.p("m.%v.T.Helper()", g.ctrlField)
+		g.p("m.%v.Inherit(m, from)", g.ctrlField)
+		g.out()
+		g.p("}")
+	}
+
+	if g.methodConstants && len(intf.Methods) > 0 {
+		g.GenerateMethodConstants(mockType, intf)
+	}
+
 	g.GenerateMockMethods(mockType, intf, outputPackagePath, longTp, shortTp, *typed)
 
 	return nil
 }
 
+// GenerateMethodConstants generates the constants naming the methods of a
+// mock.
+func (g *generator) GenerateMethodConstants(mockType string, intf *model.Interface) {
+	sort.Sort(byMethodName(intf.Methods))
+	g.p("")
+	g.p("// Names of the methods of %v.", mockType)
+	g.p("const (")
+	g.in()
+	for _, m := range intf.Methods {
+		g.p("%s = %q", g.methodName(mockType, m), m.Name)
+	}
+	g.out()
+	g.p(")")
+}
+
+// methodName returns the expression naming the method m of a mock in the
+// generated code: a constant with -method_constants, and a string otherwise.
+// The recorders generated into a package of their own cannot refer to the
+// constants of the mocks.
+func (g *generator) methodName(mockType string, m *model.Method) string {
+	if g.methodConstants && !g.recordersOnly {
+		return mockType + m.Name + "Method"
+	}
+	return strconv.Quote(m.Name)
+}
+
+// GenerateMockRecorder generates the recorder of a mock into a package of its
+// own, where it refers to the mock and its controller by field.
+func (g *generator) GenerateMockRecorder(intf *model.Interface, mockType string) {
+	g.p("")
+	g.p("// %vMockRecorder is the mock recorder for %v.", mockType, mockType)
+	g.p("type %vMockRecorder struct {", mockType)
+	g.in()
+	g.p("%v %v", g.mockField, g.anyType())
+	g.p("%v *gomock.Controller", g.ctrlField)
+	g.out()
+	g.p("}")
+	g.p("")
+
+	g.p("// New%vMockRecorder creates the recorder of mock, for use by New%v.", mockType, mockType)
+	g.p("func New%vMockRecorder(mock %v, ctrl *gomock.Controller) *%vMockRecorder {", mockType, g.anyType(), mockType)
+	g.in()
+	g.p("return &%vMockRecorder{%v: mock, %v: ctrl}", mockType, g.mockField, g.ctrlField)
+	g.out()
+	g.p("}")
+
+	g.GenerateMockMethods(mockType, intf, "", "", "", *typed)
+}
+
+// hasRPCMethod returns whether intf has a method taking a request and
+// returning a response and an error, as expected by gomock.Stub, and no
+// method the generated Stub method would collide with.
+func hasRPCMethod(intf *model.Interface) bool {
+	var found bool
+	for _, m := range intf.Methods {
+		if m.Name == "Stub" {
+			return false
+		}
+		if len(m.In) > 0 && m.Variadic == nil && len(m.Out) == 2 && m.Out[1].Type == model.PredeclaredType("error") {
+			found = true
+		}
+	}
+	return found
+}
+
+// hasMethod returns whether intf has a method named name.
+func hasMethod(intf *model.Interface, name string) bool {
+	for _, m := range intf.Methods {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 type byMethodName []*model.Method
 
 func (b byMethodName) Len() int           { return len(b) }
@@ -484,14 +1091,36 @@ func (b byMethodName) Less(i, j int) bool { return b[i].Name < b[j].Name }
 
 func (g *generator) GenerateMockMethods(mockType string, intf *model.Interface, pkgOverride, longTp, shortTp string, typed bool) {
 	sort.Sort(byMethodName(intf.Methods))
+	methods := make(map[string]bool, len(intf.Methods))
+	g.closeGuard = false
 	for _, m := range intf.Methods {
-		g.p("")
-		_ = g.GenerateMockMethod(mockType, m, pkgOverride, shortTp)
+		methods[m.Name] = true
+		g.closeGuard = g.closeGuard || g.closeMethods[m.Name]
+	}
+	for _, m := range intf.Methods {
+		if g.usedMethods != nil && !g.usedMethods[m.Name] {
+			if !g.recordersOnly {
+				g.p("")
+				g.GenerateUnusedMethod(mockType, m, pkgOverride, shortTp)
+			}
+			continue
+		}
+		if !g.recordersOnly {
+			g.p("")
+			_ = g.GenerateMockMethod(mockType, m, pkgOverride, shortTp)
+		}
+		if g.recorderPkg != "" {
+			continue
+		}
 		g.p("")
 		_ = g.GenerateMockRecorderMethod(intf, mockType, m, shortTp, typed)
 		if typed {
 			g.p("")
 			_ = g.GenerateMockReturnCallMethod(intf, m, pkgOverride, longTp, shortTp)
+			if !g.recordersOnly && !methods["Stub"+m.Name] {
+				g.p("")
+				_ = g.GenerateMockStubMethod(intf, mockType, m, pkgOverride, shortTp)
+			}
 		}
 	}
 }
@@ -509,24 +1138,46 @@ func makeArgString(argNames, argTypes []string) string {
 	return strings.Join(args, ", ")
 }
 
-// GenerateMockMethod generates a mock method implementation.
-// If non-empty, pkgOverride is the package in which unqualified types reside.
-func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOverride, shortTp string) error {
-	argNames := g.getArgNames(m, true /* in */)
+// signature returns the names of the parameters of the method m, and the
+// parameters and results of its declaration.
+func (g *generator) signature(m *model.Method, pkgOverride string) (argNames, rets []string, argString, retString string) {
+	argNames = g.getArgNames(m, true /* in */)
 	argTypes := g.getArgTypes(m, pkgOverride, true /* in */)
-	argString := makeArgString(argNames, argTypes)
+	argString = makeArgString(argNames, argTypes)
 
-	rets := make([]string, len(m.Out))
+	rets = make([]string, len(m.Out))
 	for i, p := range m.Out {
 		rets[i] = p.Type.String(g.packageMap, pkgOverride)
 	}
-	retString := strings.Join(rets, ", ")
+	retString = strings.Join(rets, ", ")
 	if len(rets) > 1 {
 		retString = "(" + retString + ")"
 	}
 	if retString != "" {
 		retString = " " + retString
 	}
+	return argNames, rets, argString, retString
+}
+
+// GenerateUnusedMethod generates a method of a mock that the packages given
+// to -methods_from_usage do not use, which panics rather than going through
+// the controller.
+func (g *generator) GenerateUnusedMethod(mockType string, m *model.Method, pkgOverride, shortTp string) {
+	argNames, _, argString, retString := g.signature(m, pkgOverride)
+	idRecv := newIdentifierAllocator(argNames).allocateIdentifier("m")
+
+	g.p("// %v is not mocked, as the packages given to -methods_from_usage do not use it.", m.Name)
+	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
+	g.in()
+	g.p("panic(%q)", fmt.Sprintf("mockgen: %s.%s is not mocked, as the packages given to -methods_from_usage do not use it", mockType, m.Name))
+	g.out()
+	g.p("}")
+}
+
+// GenerateMockMethod generates a mock method implementation.
+// If non-empty, pkgOverride is the package in which unqualified types reside.
+func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOverride, shortTp string) error {
+	argNames, rets, argString, retString := g.signature(m, pkgOverride)
 
 	ia := newIdentifierAllocator(argNames)
 	idRecv := ia.allocateIdentifier("m")
@@ -534,7 +1185,20 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 	g.p("// %v mocks base method.", m.Name)
 	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
 	g.in()
-	g.p("%s.ctrl.T.Helper()", idRecv)
+	g.p("%s.%s.T.Helper()", idRecv, g.ctrlField)
+	if g.closeGuard {
+		g.p("%s.%s.CheckNotClosed(%s, %s)", idRecv, g.ctrlField, idRecv, g.methodName(mockType, m))
+		if g.closeMethods[m.Name] {
+			g.p("defer %s.%s.MarkClosed(%s, %s)", idRecv, g.ctrlField, idRecv, g.methodName(mockType, m))
+		}
+	}
+	if g.nilErrorDefaults && len(m.Out) == 1 && m.Out[0].Type == model.PredeclaredType("error") {
+		g.p("if !%s.%s.Expects(%s, %s) {", idRecv, g.ctrlField, idRecv, g.methodName(mockType, m))
+		g.in()
+		g.p("return nil")
+		g.out()
+		g.p("}")
+	}
 
 	var callArgs string
 	if m.Variadic == nil {
@@ -546,7 +1210,7 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 		// but the variadic argument may be any type.
 		idVarArgs := ia.allocateIdentifier("varargs")
 		idVArg := ia.allocateIdentifier("a")
-		g.p("%s := []any{%s}", idVarArgs, strings.Join(argNames[:len(argNames)-1], ", "))
+		g.p("%s := []%s{%s}", idVarArgs, g.anyType(), strings.Join(argNames[:len(argNames)-1], ", "))
 		g.p("for _, %s := range %s {", idVArg, argNames[len(argNames)-1])
 		g.in()
 		g.p("%s = append(%s, %s)", idVarArgs, idVarArgs, idVArg)
@@ -555,10 +1219,16 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 		callArgs = ", " + idVarArgs + "..."
 	}
 	if len(m.Out) == 0 {
-		g.p(`%v.ctrl.Call(%v, %q%v)`, idRecv, idRecv, m.Name, callArgs)
+		g.p(`%v.%v.Call(%v, %v%v)`, idRecv, g.ctrlField, idRecv, g.methodName(mockType, m), callArgs)
 	} else {
 		idRet := ia.allocateIdentifier("ret")
-		g.p(`%v := %v.ctrl.Call(%v, %q%v)`, idRet, idRecv, idRecv, m.Name, callArgs)
+		g.p(`%v := %v.%v.Call(%v, %v%v)`, idRet, idRecv, g.ctrlField, idRecv, g.methodName(mockType, m), callArgs)
+		if helper := g.foldedResults(m, pkgOverride, shortTp); helper != "" {
+			g.p("return %s(%s)", helper, idRet)
+			g.out()
+			g.p("}")
+			return nil
+		}
 
 		// Go does not allow "naked" type assertions on nil values, so we use the two-value form here.
 		// The value of that is either (x.(T), true) or (Z, false), where Z is the zero value for T.
@@ -586,14 +1256,14 @@ func (g *generator) GenerateMockRecorderMethod(intf *model.Interface, mockType s
 		argString = strings.Join(argNames[:len(argNames)-1], ", ")
 	}
 	if argString != "" {
-		argString += " any"
+		argString += " " + g.anyType()
 	}
 
 	if m.Variadic != nil {
 		if argString != "" {
 			argString += ", "
 		}
-		argString += fmt.Sprintf("%s ...any", argNames[len(argNames)-1])
+		argString += fmt.Sprintf("%s ...%s", argNames[len(argNames)-1], g.anyType())
 	}
 
 	ia := newIdentifierAllocator(argNames)
@@ -607,7 +1277,15 @@ func (g *generator) GenerateMockRecorderMethod(intf *model.Interface, mockType s
 	}
 
 	g.in()
-	g.p("%s.mock.ctrl.T.Helper()", idRecv)
+	// The recorders generated into a package of their own cannot refer to the
+	// type of the mock.
+	ctrl := fmt.Sprintf("%s.%s.%s", idRecv, g.mockField, g.ctrlField)
+	methodType := fmt.Sprintf("reflect.TypeOf((*%s%s)(nil).%s)", mockType, shortTp, m.Name)
+	if g.recordersOnly {
+		ctrl = idRecv + "." + g.ctrlField
+		methodType = fmt.Sprintf("reflect.ValueOf(%s.%s).MethodByName(%q).Type()", idRecv, g.mockField, m.Name)
+	}
+	g.p("%s.T.Helper()", ctrl)
 
 	var callArgs string
 	if m.Variadic == nil {
@@ -621,18 +1299,21 @@ func (g *generator) GenerateMockRecorderMethod(intf *model.Interface, mockType s
 		} else {
 			// Hard: create a temporary slice.
 			idVarArgs := ia.allocateIdentifier("varargs")
-			g.p("%s := append([]any{%s}, %s...)",
-				idVarArgs,
+			g.p("%s := append([]%s{%s}, %s...)",
+				idVarArgs, g.anyType(),
 				strings.Join(argNames[:len(argNames)-1], ", "),
 				argNames[len(argNames)-1])
 			callArgs = ", " + idVarArgs + "..."
 		}
 	}
+	recordCall := fmt.Sprintf(`%s.RecordCallWithMethodType(%s.%s, %s, %s%s)`,
+		ctrl, idRecv, g.mockField, g.methodName(mockType, m), methodType, callArgs)
 	if typed {
-		g.p(`call := %s.mock.ctrl.RecordCallWithMethodType(%s.mock, "%s", reflect.TypeOf((*%s%s)(nil).%s)%s)`, idRecv, idRecv, m.Name, mockType, shortTp, m.Name, callArgs)
-		g.p(`return &%s%sCall%s{Call: call}`, intf.Name, m.Name, shortTp)
+		idCall := ia.allocateIdentifier("call")
+		g.p("%s := %s", idCall, recordCall)
+		g.p(`return &%s%sCall%s{Call: %s}`, intf.Name, m.Name, shortTp, idCall)
 	} else {
-		g.p(`return %s.mock.ctrl.RecordCallWithMethodType(%s.mock, "%s", reflect.TypeOf((*%s%s)(nil).%s)%s)`, idRecv, idRecv, m.Name, mockType, shortTp, m.Name, callArgs)
+		g.p("return %s", recordCall)
 	}
 
 	g.out()
@@ -641,6 +1322,18 @@ func (g *generator) GenerateMockRecorderMethod(intf *model.Interface, mockType s
 }
 
 func (g *generator) GenerateMockReturnCallMethod(intf *model.Interface, m *model.Method, pkgOverride, longTp, shortTp string) error {
+	callType := intf.Name + m.Name + "Call"
+	if shared := g.foldedCall(m, pkgOverride, shortTp); shared != "" {
+		g.p("// %s wrap *gomock.Call", callType)
+		g.p("type %s = %s", callType, shared)
+		return nil
+	}
+	return g.generateCallType(callType, m, pkgOverride, longTp, shortTp)
+}
+
+// generateCallType generates the typed wrapper of *gomock.Call named
+// callType for the calls to m.
+func (g *generator) generateCallType(callType string, m *model.Method, pkgOverride, longTp, shortTp string) error {
 	argNames := g.getArgNames(m, true /* in */)
 	retNames := g.getArgNames(m, false /* out */)
 	argTypes := g.getArgTypes(m, pkgOverride, true /* in */)
@@ -660,20 +1353,18 @@ func (g *generator) GenerateMockReturnCallMethod(intf *model.Interface, m *model
 		retString = " (" + strings.Join(rets, ", ") + ")"
 	}
 
-	ia := newIdentifierAllocator(argNames)
+	ia := newIdentifierAllocator(append(argNames, retNames...))
 	idRecv := ia.allocateIdentifier("c")
 
-	recvStructName := intf.Name + m.Name
-
-	g.p("// %s%sCall wrap *gomock.Call", intf.Name, m.Name)
-	g.p("type %s%sCall%s struct{", intf.Name, m.Name, longTp)
+	g.p("// %s wrap *gomock.Call", callType)
+	g.p("type %s%s struct{", callType, longTp)
 	g.in()
 	g.p("*gomock.Call")
 	g.out()
 	g.p("}")
 
 	g.p("// Return rewrite *gomock.Call.Return")
-	g.p("func (%s *%sCall%s) Return(%v) *%sCall%s {", idRecv, recvStructName, shortTp, makeArgString(retNames, retTypes), recvStructName, shortTp)
+	g.p("func (%s *%s%s) Return(%v) *%s%s {", idRecv, callType, shortTp, makeArgString(retNames, retTypes), callType, shortTp)
 	g.in()
 	var retArgs string
 	if len(retNames) > 0 {
@@ -684,8 +1375,17 @@ func (g *generator) GenerateMockReturnCallMethod(intf *model.Interface, m *model
 	g.out()
 	g.p("}")
 
+	idSeq := ia.allocateIdentifier("valuesPerCall")
+	g.p("// ReturnSeq rewrite *gomock.Call.ReturnSeq")
+	g.p("func (%s *%s%s) ReturnSeq(%s ...[]any) *%s%s {", idRecv, callType, shortTp, idSeq, callType, shortTp)
+	g.in()
+	g.p("%s.Call = %s.Call.ReturnSeq(%s...)", idRecv, idRecv, idSeq)
+	g.p("return %s", idRecv)
+	g.out()
+	g.p("}")
+
 	g.p("// Do rewrite *gomock.Call.Do")
-	g.p("func (%s *%sCall%s) Do(f func(%v)%v) *%sCall%s {", idRecv, recvStructName, shortTp, argString, retString, recvStructName, shortTp)
+	g.p("func (%s *%s%s) Do(f func(%v)%v) *%s%s {", idRecv, callType, shortTp, argString, retString, callType, shortTp)
 	g.in()
 	g.p(`%s.Call = %v.Call.Do(f)`, idRecv, idRecv)
 	g.p("return %s", idRecv)
@@ -693,12 +1393,95 @@ func (g *generator) GenerateMockReturnCallMethod(intf *model.Interface, m *model
 	g.p("}")
 
 	g.p("// DoAndReturn rewrite *gomock.Call.DoAndReturn")
-	g.p("func (%s *%sCall%s) DoAndReturn(f func(%v)%v) *%sCall%s {", idRecv, recvStructName, shortTp, argString, retString, recvStructName, shortTp)
+	g.p("func (%s *%s%s) DoAndReturn(f func(%v)%v) *%s%s {", idRecv, callType, shortTp, argString, retString, callType, shortTp)
 	g.in()
 	g.p(`%s.Call = %v.Call.DoAndReturn(f)`, idRecv, idRecv)
 	g.p("return %s", idRecv)
 	g.out()
 	g.p("}")
+
+	g.generateEachReceivedArgs(ia, idRecv, callType, shortTp, argNames, argTypes, argString, m.Variadic != nil)
+	return nil
+}
+
+// generateEachReceivedArgs generates the method of a typed wrapper of
+// *gomock.Call passing the arguments of the calls it matched, as returned by
+// gomock.Call.ReceivedArgs, to a function of the parameters of the method.
+func (g *generator) generateEachReceivedArgs(ia identifierAllocator, idRecv, callType, shortTp string, argNames, argTypes []string, argString string, variadic bool) {
+	idF := ia.allocateIdentifier("f")
+	idArgs := ia.allocateIdentifier("args")
+
+	g.p("// EachReceivedArgs calls f with the arguments of each call matched by %s, in order", idRecv)
+	g.p("func (%s *%s%s) EachReceivedArgs(%s func(%v)) {", idRecv, callType, shortTp, idF, argString)
+	g.in()
+	if len(argNames) == 0 {
+		g.p("for range %s.Call.ReceivedArgs() {", idRecv)
+	} else {
+		g.p("for _, %s := range %s.Call.ReceivedArgs() {", idArgs, idRecv)
+	}
+	g.in()
+	callArgs := make([]string, len(argNames))
+	copy(callArgs, argNames)
+	n := len(argNames)
+	if variadic {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		g.p("%s, _ := %s[%d].(%s)", argNames[i], idArgs, i, argTypes[i])
+	}
+	if variadic {
+		idV := ia.allocateIdentifier("v")
+		idA := ia.allocateIdentifier("a")
+		vararg := argNames[n]
+		g.p("var %s []%s", vararg, strings.TrimPrefix(argTypes[n], "..."))
+		g.p("for _, %s := range %s[%d:] {", idA, idArgs, n)
+		g.in()
+		g.p("%s, _ := %s.(%s)", idV, idA, strings.TrimPrefix(argTypes[n], "..."))
+		g.p("%s = append(%s, %s)", vararg, vararg, idV)
+		g.out()
+		g.p("}")
+		callArgs[n] += "..."
+	}
+	g.p("%s(%s)", idF, strings.Join(callArgs, ", "))
+	g.out()
+	g.p("}")
+	g.out()
+	g.p("}")
+}
+
+// GenerateMockStubMethod generates, in typed mode, a method of the mock that
+// makes the method m behave like a function of its signature for the rest of
+// the test, whatever its arguments.
+func (g *generator) GenerateMockStubMethod(intf *model.Interface, mockType string, m *model.Method, pkgOverride, shortTp string) error {
+	argTypes := g.getArgTypes(m, pkgOverride, true /* in */)
+
+	rets := make([]string, len(m.Out))
+	for i, p := range m.Out {
+		rets[i] = p.Type.String(g.packageMap, pkgOverride)
+	}
+	var retString string
+	switch {
+	case len(rets) == 1:
+		retString = " " + rets[0]
+	case len(rets) > 1:
+		retString = " (" + strings.Join(rets, ", ") + ")"
+	}
+
+	matchers := make([]string, len(argTypes))
+	for i := range matchers {
+		matchers[i] = "gomock.Any()"
+	}
+
+	g.p("// Stub%s makes %s behave like fn for the rest of the test, whatever its", m.Name, m.Name)
+	g.p("// arguments.")
+	g.p("func (m *%v%v) Stub%v(fn func(%v)%v) *%s%sCall%s {", mockType, shortTp, m.Name, strings.Join(argTypes, ", "), retString, intf.Name, m.Name, shortTp)
+	g.in()
+	g.p("m.%s.T.Helper()", g.ctrlField)
+	g.p("call := m.EXPECT().%s(%s)", m.Name, strings.Join(matchers, ", "))
+	g.p("call.AnyTimes()")
+	g.p("return call.DoAndReturn(fn)")
+	g.out()
+	g.p("}")
 	return nil
 }
 
@@ -724,6 +1507,14 @@ func (g *generator) getArgNames(m *model.Method, in bool) []string {
 		}
 		argNames = append(argNames, name)
 	}
+	// Rename parameters that would shadow identifiers the generated code
+	// refers to, such as imported package names.
+	ia := newIdentifierAllocator(argNames)
+	for i, name := range argNames {
+		if g.reservedNames[name] {
+			argNames[i] = ia.allocateIdentifier(name)
+		}
+	}
 	return argNames
 }
 
@@ -777,25 +1568,29 @@ func (g *generator) Output() []byte {
 // createPackageMap returns a map of import path to package name
 // for specified importPaths.
 func createPackageMap(importPaths []string) map[string]string {
-	var pkg struct {
-		Name       string
-		ImportPath string
-	}
 	pkgMap := make(map[string]string)
 	b := bytes.NewBuffer(nil)
-	args := []string{"list", "-json"}
+	// -e reports the packages that cannot be loaded, such as the external
+	// test package of a -source _test.go file, without failing the others.
+	args := []string{"list", "-e", "-json"}
 	args = append(args, importPaths...)
 	cmd := exec.Command("go", args...)
 	cmd.Stdout = b
 	cmd.Run()
 	dec := json.NewDecoder(b)
 	for dec.More() {
+		var pkg struct {
+			Name       string
+			ImportPath string
+		}
 		err := dec.Decode(&pkg)
 		if err != nil {
 			log.Printf("failed to decode 'go list' output: %v", err)
 			continue
 		}
-		pkgMap[pkg.ImportPath] = pkg.Name
+		if pkg.Name != "" {
+			pkgMap[pkg.ImportPath] = pkg.Name
+		}
 	}
 	return pkgMap
 }
@@ -808,6 +1603,62 @@ func printVersion() {
 	}
 }
 
+// platformConstraint returns the build constraint of the platform given by
+// -goos and -goarch, if any.
+func platformConstraint() string {
+	var tags []string
+	for _, tag := range []string{*goos, *goarch} {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return strings.Join(tags, " && ")
+}
+
+// checkDestinationPackage returns an error if the Go files already in the
+// directory of the destination file, other than the file itself, belong to
+// a package other than pkgName, which the generated code would not build
+// with. The external test package of the directory is accepted for test
+// files.
+func checkDestinationPackage(destination, pkgName string) error {
+	dir := filepath.Dir(destination)
+	ctxt := build.Default
+	ctxt.ReadDir = func(dir string) ([]fs.FileInfo, error) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		var infos []fs.FileInfo
+		for _, entry := range entries {
+			if entry.Name() == filepath.Base(destination) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, info)
+		}
+		return infos, nil
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	p, err := ctxt.ImportDir(dir, 0)
+	var noGo *build.NoGoError
+	switch {
+	case errors.As(err, &noGo):
+		return nil
+	case err != nil:
+		return err
+	case p.Name == "" || pkgName == p.Name:
+		return nil
+	case isTestFile(destination) && pkgName == p.Name+"_test":
+		return nil
+	}
+	return fmt.Errorf("%s is in package %s, not %s", destination, p.Name, pkgName)
+}
+
 // parseImportPackage get package import path via source file
 // an alternative implementation is to use:
 // cfg := &packages.Config{Mode: packages.NeedName, Tests: true, Dir: srcDir}
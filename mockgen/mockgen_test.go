@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -260,6 +262,86 @@ func TestGenerateMockInterface_Helper(t *testing.T) {
 	}
 }
 
+func TestGenerateMockInterface_ExpectCollision(t *testing.T) {
+	g := generator{}
+	intf := &model.Interface{Name: "Somename"}
+	intf.AddMethod(&model.Method{Name: "EXPECT"})
+
+	err := g.GenerateMockInterface(intf, "somepackage")
+	if err == nil || !strings.Contains(err.Error(), "collides with the EXPECT method") {
+		t.Fatalf("GenerateMockInterface() error = %v, want EXPECT collision", err)
+	}
+}
+
+func TestGenerateMockInterface_TypedStub(t *testing.T) {
+	defer func(old bool) { *typed = old }(*typed)
+	*typed = true
+
+	g := generator{}
+	intf := &model.Interface{Name: "Somename"}
+	intf.AddMethod(&model.Method{Name: "Get"})
+	intf.AddMethod(&model.Method{Name: "Put"})
+	intf.AddMethod(&model.Method{Name: "StubPut"})
+
+	if err := g.GenerateMockInterface(intf, "somepackage"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(g.buf.String(), "\n")
+	findMethod(t, "MockSomename", "StubGet", lines)
+	findMethod(t, "MockSomename", "StubStubPut", lines)
+	// StubPut is a method of the interface, so Put gets no Stub method.
+	if n := strings.Count(g.buf.String(), "*MockSomename) StubPut("); n != 1 {
+		t.Errorf("StubPut declared %d times, want 1", n)
+	}
+}
+
+func TestGenerateFoldedSignatures(t *testing.T) {
+	defer func(old bool) { *typed = old }(*typed)
+	*typed = true
+
+	str := model.PredeclaredType("string")
+	errType := &model.NamedType{Type: "error"}
+	method := func(name string, out ...model.Type) *model.Method {
+		m := &model.Method{Name: name, In: []*model.Parameter{{Name: "key", Type: str}}}
+		for _, t := range out {
+			m.Out = append(m.Out, &model.Parameter{Type: t})
+		}
+		return m
+	}
+	intf := &model.Interface{Name: "Store"}
+	intf.AddMethod(method("Get", str, errType))
+	intf.AddMethod(method("Lookup", str, errType))
+	intf.AddMethod(method("Delete", errType))
+	pkg := &model.Package{Name: "store", PkgPath: "example.com/store", Interfaces: []*model.Interface{intf}}
+
+	g := generator{foldSignatures: true}
+	if err := g.Generate(pkg, "store", "example.com/store"); err != nil {
+		t.Fatal(err)
+	}
+	out := g.buf.String()
+	if _, err := parser.ParseFile(token.NewFileSet(), "", out, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"type StoreGetCall = mockStoreCall0",
+		"type StoreLookupCall = mockStoreCall0",
+		"type StoreDeleteCall struct",
+		"return mockStoreResults0(ret)",
+		"func mockStoreResults0(ret []any) (string, error) {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "type mockStoreCall0 struct"); n != 1 {
+		t.Errorf("mockStoreCall0 declared %d times, want 1", n)
+	}
+	// Delete is the only method returning only an error.
+	if strings.Contains(out, "mockStoreResults1") {
+		t.Errorf("generated code has a helper used by a single method:\n%s", out)
+	}
+}
+
 func findMethod(t *testing.T, identifier, methodName string, lines []string) int {
 	t.Helper()
 	r := regexp.MustCompile(fmt.Sprintf(`func\s+\(.+%s\)\s*%s`, identifier, methodName))
@@ -273,6 +355,152 @@ func findMethod(t *testing.T, identifier, methodName string, lines []string) int
 	panic("unreachable")
 }
 
+func TestGenerateBuildConstraint(t *testing.T) {
+	intf := &model.Interface{Name: "Conn"}
+	pkg := &model.Package{Name: "winio", PkgPath: "example.com/winio", Interfaces: []*model.Interface{intf}}
+
+	g := generator{copyrightHeader: "Copyright", buildConstraint: "windows && arm64"}
+	if err := g.Generate(pkg, "mock_winio", ""); err != nil {
+		t.Fatal(err)
+	}
+	out := g.buf.String()
+	if want := "// Copyright\n\n//go:build windows && arm64\n\n// Code generated by MockGen."; !strings.HasPrefix(out, want) {
+		t.Errorf("generated code starts with %q, want %q", out[:len(want)], want)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "", out, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, out)
+	}
+}
+
+func TestAddCombinedInterfaces(t *testing.T) {
+	str := model.PredeclaredType("string")
+	item := &model.NamedType{Package: "example.com/v1", Type: "Item"}
+	newItem := &model.NamedType{Package: "example.com/v2", Type: "Item"}
+	method := func(name string, out model.Type) *model.Method {
+		return &model.Method{
+			Name: name,
+			In:   []*model.Parameter{{Name: "key", Type: str}},
+			Out:  []*model.Parameter{{Type: out}},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		old, new    []*model.Method
+		wantMethods []string
+		wantErr     string
+	}{
+		{
+			name:        "union",
+			old:         []*model.Method{method("Get", item), method("Delete", str)},
+			new:         []*model.Method{method("Get", item), method("Put", str)},
+			wantMethods: []string{"Get", "Put", "Delete"},
+		},
+		{
+			name:    "conflicting signatures",
+			old:     []*model.Method{method("Get", item)},
+			new:     []*model.Method{method("Get", newItem)},
+			wantErr: "method Get has different signatures in V1 and V2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := &model.Package{Interfaces: []*model.Interface{
+				{Name: "V1", Methods: tt.old},
+				{Name: "V2", Methods: tt.new},
+			}}
+			err := addCombinedInterfaces(pkg, [][2]string{{"V1", "V2"}})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("addCombinedInterfaces() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("addCombinedInterfaces() error = %v", err)
+			}
+			combined := pkg.Interfaces[len(pkg.Interfaces)-1]
+			if combined.Name != "V1AndV2" {
+				t.Errorf("combined interface name = %q, want V1AndV2", combined.Name)
+			}
+			var names []string
+			for _, m := range combined.Methods {
+				names = append(names, m.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantMethods) {
+				t.Errorf("combined methods = %v, want %v", names, tt.wantMethods)
+			}
+		})
+	}
+}
+
+func TestGenerateAdapter(t *testing.T) {
+	str := model.PredeclaredType("string")
+	logMethod := &model.Method{
+		Name:     "Log",
+		In:       []*model.Parameter{{Name: "format", Type: str}},
+		Variadic: &model.Parameter{Name: "args", Type: model.PredeclaredType("any")},
+	}
+	closeMethod := &model.Method{Name: "Close", Out: []*model.Parameter{{Type: model.PredeclaredType("error")}}}
+	pkg := &model.Package{Name: "store", PkgPath: "example.com/store", Interfaces: []*model.Interface{
+		{Name: "V1", Methods: []*model.Method{closeMethod, logMethod}},
+		{Name: "V2", Methods: []*model.Method{closeMethod}},
+	}}
+
+	g := generator{packageMap: map[string]string{"example.com/store": "store"}}
+	if err := g.GenerateAdapter(pkg, "V1", "V2", "example.com/mocks"); err != nil {
+		t.Fatal(err)
+	}
+	out := g.buf.String()
+	for _, want := range []string{
+		"type V1FromV2 struct {\n\tV2 store.V2\n\tLogFunc func(format string, args ...any)\n}",
+		"func (a *V1FromV2) Close() error {\n\treturn a.V2.Close()\n}",
+		"func (a *V1FromV2) Log(format string, args ...any) {\n" +
+			"\tif a.LogFunc == nil {\n\t\tpanic(\"V1FromV2.LogFunc is not set\")\n\t}\n" +
+			"\ta.LogFunc(format, args...)\n}",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestParseLang(t *testing.T) {
+	tests := []struct {
+		lang    string
+		want    int
+		wantErr bool
+	}{
+		{lang: "go1.17", want: 17},
+		{lang: "go1.20.3", want: 20},
+		{lang: "1.20", wantErr: true},
+		{lang: "go2", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseLang(tt.lang)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLang(%q) error = %v, wantErr %v", tt.lang, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseLang(%q) = %d, want %d", tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateMockInterface_GenericBeforeGo118(t *testing.T) {
+	g := generator{goMinor: 17}
+	intf := &model.Interface{
+		Name:       "Somename",
+		TypeParams: []*model.Parameter{{Name: "T", Type: model.PredeclaredType("any")}},
+	}
+
+	err := g.GenerateMockInterface(intf, "somepackage")
+	if err == nil || !strings.Contains(err.Error(), "cannot be mocked before go1.18") {
+		t.Fatalf("GenerateMockInterface() error = %v, want go1.18 error", err)
+	}
+}
+
 func TestGetArgNames(t *testing.T) {
 	for _, testCase := range []struct {
 		name     string
@@ -424,3 +652,88 @@ func TestParsePackageImport_FallbackMultiGoPath(t *testing.T) {
 		t.Errorf("expect %s, got %s", expected, pkgPath)
 	}
 }
+
+func TestCheckDestinationPackage(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"store.go":      "package store\n",
+		"store_test.go": "package store_test\n",
+		"gen.go":        "//go:build ignore\n\npackage main\n",
+		// The mocks generated before with another package name.
+		"mocks/mock_store.go": "package mocks\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		destination, pkgName string
+		wantErr              bool
+	}{
+		{filepath.Join(dir, "mock_store.go"), "store", false},
+		{filepath.Join(dir, "mock_store.go"), "mock_store", true},
+		{filepath.Join(dir, "mock_store_test.go"), "store_test", false},
+		{filepath.Join(dir, "mock_store_test.go"), "mock_store", true},
+		{filepath.Join(dir, "mocks", "mock_store.go"), "mock_store", false},
+		{filepath.Join(dir, "mocks", "mock_cache.go"), "mock_store", true},
+		{filepath.Join(dir, "testdata", "mock_store.go"), "mock_store", false},
+	} {
+		err := checkDestinationPackage(tc.destination, tc.pkgName)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("checkDestinationPackage(%q, %q) = %v, want error: %v", tc.destination, tc.pkgName, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMockModuleDirectives(t *testing.T) {
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := mockModuleDirectives()
+	if err != nil {
+		t.Fatalf("mockModuleDirectives: %v", err)
+	}
+	want := fmt.Sprintf("replace go.uber.org/mock => %q\n", root)
+	if !strings.Contains(got, want) {
+		t.Errorf("mockModuleDirectives() == %q, want it to contain %q", got, want)
+	}
+}
+
+func TestParseInterfaces(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod": "module example.com/broken\n",
+		// The package does not build, and uses cgo.
+		"store.go":  "package broken\n\nimport \"C\"\n\ntype Store interface {\n\tGet(key string) (string, error)\n}\n",
+		"bad.go":    "package broken\n\nfunc helper() int { return \"x\" }\n",
+		"syntax.go": "package broken\n\ntype Cache interface {\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pkg, err := parseInterfaces(dir, "example.com/broken", []string{"Store"})
+	if err != nil {
+		t.Fatalf("parseInterfaces: %v", err)
+	}
+	if pkg.Name != "broken" || pkg.PkgPath != "example.com/broken" {
+		t.Errorf("package is %s %q", pkg.Name, pkg.PkgPath)
+	}
+	if len(pkg.Interfaces) != 1 || pkg.Interfaces[0].Name != "Store" || len(pkg.Interfaces[0].Methods) != 1 {
+		t.Errorf("interfaces are %v", pkg.Interfaces)
+	}
+
+	_, err = parseInterfaces(dir, "example.com/broken", []string{"Store", "Cache"})
+	if err == nil || !strings.Contains(err.Error(), "declaring interface Cache") {
+		t.Errorf("parseInterfaces() error = %v, want Cache to be reported", err)
+	}
+	if want := filepath.Join(dir, "syntax.go") + ":3:24: "; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("parseInterfaces() error = %v, want the error at %s to be reported", err, want)
+	}
+}
@@ -25,10 +25,12 @@ import (
 	"go/parser"
 	"go/token"
 	"go/types"
+	"io/fs"
 	"log"
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 
@@ -81,6 +83,13 @@ func sourceMode(source string) (*model.Package, error) {
 	}
 	p.addAuxInterfacesFromFile(packageImport, file) // this file
 
+	// Interfaces declared in an external test package, package foo_test,
+	// belong to the package of that name rather than to the package in the
+	// directory.
+	if isTestFile(source) && strings.HasSuffix(file.Name.Name, "_test") {
+		packageImport += "_test"
+	}
+
 	pkg, err := p.parseFile(packageImport, file)
 	if err != nil {
 		return nil, err
@@ -91,6 +100,12 @@ func sourceMode(source string) (*model.Package, error) {
 	return pkg, nil
 }
 
+// isTestFile reports whether the source file is a test file, whose
+// declarations are only visible to the tests of its package.
+func isTestFile(source string) bool {
+	return strings.HasSuffix(source, "_test.go")
+}
+
 type importedPackage interface {
 	Path() string
 	Parser() *fileParser
@@ -248,10 +263,22 @@ func (p *fileParser) parsePackage(path string) (*fileParser, error) {
 		srcDir:             p.srcDir,
 	}
 
-	var pkgs map[string]*ast.Package
-	if imp, err := build.Import(path, newP.srcDir, build.FindOnly); err != nil {
+	ctx := buildContext()
+	imp, err := ctx.Import(path, newP.srcDir, build.FindOnly)
+	if err != nil {
 		return nil, err
-	} else if pkgs, err = parser.ParseDir(newP.fileSet, imp.Dir, nil, 0); err != nil {
+	}
+	var filter func(fs.FileInfo) bool
+	if *goos != "" || *goarch != "" {
+		// Only the files of the platform are parsed, as the others may
+		// declare the same interfaces differently.
+		filter = func(fi fs.FileInfo) bool {
+			match, err := ctx.MatchFile(imp.Dir, fi.Name())
+			return err == nil && match
+		}
+	}
+	pkgs, err := parser.ParseDir(newP.fileSet, imp.Dir, filter, 0)
+	if err != nil {
 		return nil, err
 	}
 
@@ -766,6 +793,25 @@ func isVariadic(f *ast.FuncType) bool {
 	return ok
 }
 
+// buildContext returns the context to load packages with, for the platform
+// given by -goos and -goarch.
+func buildContext() build.Context {
+	ctx := build.Default
+	if *goos != "" {
+		ctx.GOOS = *goos
+	}
+	if *goarch != "" {
+		ctx.GOARCH = *goarch
+	}
+	return ctx
+}
+
+// crossPlatform returns whether -goos or -goarch name another platform than
+// the one running mockgen.
+func crossPlatform() bool {
+	return *goos != "" && *goos != runtime.GOOS || *goarch != "" && *goarch != runtime.GOARCH
+}
+
 // packageNameOfDir get package import path via dir
 func packageNameOfDir(srcDir string) (string, error) {
 	files, err := os.ReadDir(srcDir)
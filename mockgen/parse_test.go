@@ -1,6 +1,7 @@
 package main
 
 import (
+	"go/build"
 	"go/parser"
 	"go/token"
 	"testing"
@@ -143,3 +144,35 @@ func TestParseArrayWithConstLength(t *testing.T) {
 		}
 	}
 }
+
+func TestFileParser_ParsePackage_GOOS(t *testing.T) {
+	for _, tt := range []struct {
+		goos, method string
+	}{
+		{"windows", "Handle"},
+		{"linux", "Fd"},
+	} {
+		t.Run(tt.goos, func(t *testing.T) {
+			defer func(old string) { *goos = old }(*goos)
+			*goos = tt.goos
+			// Other tests of the package point GOPATH elsewhere, where go
+			// list would download the modules anew.
+			t.Setenv("GOPATH", build.Default.GOPATH)
+
+			p := fileParser{
+				fileSet:            token.NewFileSet(),
+				imports:            make(map[string]importedPackage),
+				importedInterfaces: newInterfaceCache(),
+			}
+			path := "go.uber.org/mock/mockgen/internal/tests/goos/platform"
+			newP, err := p.parsePackage(path)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			it := newP.importedInterfaces.GetASTIface(path, "Handle")
+			if it == nil || len(it.Methods.List) != 1 || it.Methods.List[0].Names[0].Name != tt.method {
+				t.Errorf("Handle of %s does not declare only %s", tt.goos, tt.method)
+			}
+		})
+	}
+}
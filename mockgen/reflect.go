@@ -19,15 +19,18 @@ package main
 import (
 	"bytes"
 	"encoding/gob"
+	"errors"
 	"flag"
 	"fmt"
 	"go/build"
+	"go/scanner"
 	"io"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"runtime/debug"
 	"strings"
 	"text/template"
 
@@ -40,12 +43,25 @@ var (
 	buildFlags = flag.String("build_flags", "", "(reflect mode) Additional flags for go build.")
 )
 
-// reflectMode generates mocks via reflection on an interface.
+// reflectMode generates mocks via reflection on an interface. The import path
+// may be followed by @version to load that version of its module, rather than
+// the one required by the current module.
 func reflectMode(importPath string, symbols []string) (*model.Package, error) {
 	if *execOnly != "" {
 		return run(*execOnly)
 	}
 
+	importPath, version, versioned := strings.Cut(importPath, "@")
+	if crossPlatform() {
+		// The reflection program cannot run on another platform.
+		wd, _ := os.Getwd()
+		ctx := buildContext()
+		bp, err := ctx.Import(importPath, wd, build.FindOnly)
+		if err != nil {
+			return nil, err
+		}
+		return parseInterfaces(bp.Dir, importPath, symbols)
+	}
 	program, err := writeProgram(importPath, symbols)
 	if err != nil {
 		return nil, err
@@ -58,6 +74,10 @@ func reflectMode(importPath string, symbols []string) (*model.Package, error) {
 		os.Exit(0)
 	}
 
+	if versioned {
+		return runInModule(program, importPath, version)
+	}
+
 	wd, _ := os.Getwd()
 
 	// Try to run the reflection program  in the current working directory.
@@ -74,7 +94,87 @@ func reflectMode(importPath string, symbols []string) (*model.Package, error) {
 	}
 
 	// Try to run it in a standard temp directory.
-	return runInDir(program, "")
+	p, err := runInDir(program, "")
+	if err == nil {
+		return p, nil
+	}
+
+	// The package may not build, because of errors in files that do not
+	// declare the interfaces or because it uses cgo: fall back to parsing the
+	// files declaring them, as in source mode.
+	bp, ierr := build.Import(importPath, wd, build.FindOnly)
+	if ierr != nil {
+		return nil, err
+	}
+	p, serr := parseInterfaces(bp.Dir, importPath, symbols)
+	if serr != nil {
+		return nil, fmt.Errorf("%v; the interfaces cannot be parsed from the source either: %v", err, serr)
+	}
+	log.Printf("Warning: %s does not build, see the errors above; the mocks of %s are generated from their source", importPath, strings.Join(symbols, ", "))
+	return p, nil
+}
+
+// parseInterfaces parses the interfaces named by symbols from the Go files of
+// dir, the directory of the package importPath, as in source mode. Only the
+// files declaring the interfaces are parsed, so that errors in the other
+// files of the package do not matter.
+func parseInterfaces(dir, importPath string, symbols []string) (*model.Package, error) {
+	ctx := buildContext()
+	ctx.CgoEnabled = true // Parse the files using cgo too.
+	bp, err := ctx.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*model.Interface)
+	pkg := &model.Package{Name: bp.Name, PkgPath: importPath}
+	// The errors of the files that cannot be parsed, with their positions.
+	var parseErrs []string
+	for _, name := range append(bp.GoFiles, bp.CgoFiles...) {
+		src := filepath.Join(dir, name)
+		declared, err := sourceInterfaces(src)
+		if err != nil {
+			// A file with syntax errors cannot be parsed: the interfaces
+			// it may declare are reported as missing, with the errors.
+			var list scanner.ErrorList
+			if errors.As(err, &list) {
+				for _, e := range list {
+					parseErrs = append(parseErrs, e.Error())
+				}
+			} else {
+				parseErrs = append(parseErrs, err.Error())
+			}
+			continue
+		}
+		var wanted bool
+		for _, sym := range symbols {
+			wanted = wanted || declared[sym]
+		}
+		if !wanted {
+			continue
+		}
+		p, err := sourceMode(src)
+		if err != nil {
+			return nil, err
+		}
+		for _, intf := range p.Interfaces {
+			found[intf.Name] = intf
+		}
+		pkg.DotImports = append(pkg.DotImports, p.DotImports...)
+	}
+
+	for _, sym := range symbols {
+		intf, ok := found[sym]
+		if !ok {
+			if len(parseErrs) == 0 {
+				return nil, fmt.Errorf("no file of %s declares interface %s", dir, sym)
+			}
+			return nil, fmt.Errorf("no file of %s declaring interface %s can be parsed, these files have errors:\n\t%s",
+				dir, sym, strings.Join(parseErrs, "\n\t"))
+		}
+		pkg.Interfaces = append(pkg.Interfaces, intf)
+	}
+	return pkg, nil
 }
 
 func writeProgram(importPath string, symbols []string) ([]byte, error) {
@@ -128,6 +228,59 @@ func run(program string) (*model.Package, error) {
 	return &pkg, nil
 }
 
+// runInModule runs the given program in a temporary module requiring the
+// given version of the module providing importPath, and parses the output as
+// a model.Package.
+func runInModule(program []byte, importPath, version string) (*model.Package, error) {
+	mockModule, err := mockModuleDirectives()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gomock_reflect_")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			log.Printf("failed to remove temp directory: %s", err)
+		}
+	}()
+
+	goMod := "module gomock_reflect\n\n" + mockModule
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0600); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("go", "get", importPath+"@"+version, "go.uber.org/mock/mockgen/model")
+	cmd.Dir = tmpDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("loading %s@%s: %v", importPath, version, err)
+	}
+	return buildAndRun(program, tmpDir)
+}
+
+// mockModuleDirectives returns the go.mod directives making a module use the
+// go.uber.org/mock module of the current module, or else the one mockgen was
+// built from, for the reflection program to encode the model with.
+func mockModuleDirectives() (string, error) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Version}}\t{{.Dir}}", "go.uber.org/mock").Output()
+	if err == nil {
+		version, dir, _ := strings.Cut(strings.TrimRight(string(out), "\n"), "\t")
+		if dir != "" {
+			return fmt.Sprintf("require go.uber.org/mock v0.0.0\n\nreplace go.uber.org/mock => %q\n", dir), nil
+		}
+		if version != "" {
+			return fmt.Sprintf("require go.uber.org/mock %s\n", version), nil
+		}
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Path == "go.uber.org/mock" && bi.Main.Sum != "" {
+		return fmt.Sprintf("require go.uber.org/mock %s\n", bi.Main.Version), nil
+	}
+	return "", errors.New("unable to determine the version of go.uber.org/mock to load an import path at a version with; run mockgen in a module requiring go.uber.org/mock")
+}
+
 // runInDir writes the given program into the given dir, runs it there, and
 // parses the output as a model.Package.
 func runInDir(program []byte, dir string) (*model.Package, error) {
@@ -141,6 +294,12 @@ func runInDir(program []byte, dir string) (*model.Package, error) {
 			log.Printf("failed to remove temp directory: %s", err)
 		}
 	}()
+	return buildAndRun(program, tmpDir)
+}
+
+// buildAndRun writes the given program into tmpDir, builds and runs it there,
+// and parses the output as a model.Package.
+func buildAndRun(program []byte, tmpDir string) (*model.Package, error) {
 	const progSource = "prog.go"
 	var progBinary = "prog.bin"
 	if runtime.GOOS == "windows" {
@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// usedNames returns the names selected in the non-test Go files of the
+// packages, directories or directory trees ending with /..., given by
+// patterns, such as Get in c.Get(key). A method of a mocked interface whose
+// name is not among them is not used by the packages. Mocks generated by
+// mockgen are skipped, as they select the methods they mock.
+func usedNames(patterns []string) (map[string]bool, error) {
+	names := make(map[string]bool)
+	fset := token.NewFileSet()
+	scanDir := func(dir string) error {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if isTestFile(file) {
+				continue
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if bytes.Contains(data, []byte("// Code generated by MockGen. DO NOT EDIT.\n")) {
+				continue
+			}
+			f, err := parser.ParseFile(fset, file, data, 0)
+			if err != nil {
+				return err
+			}
+			ast.Inspect(f, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					names[sel.Sel.Name] = true
+				}
+				return true
+			})
+		}
+		return nil
+	}
+
+	for _, pattern := range patterns {
+		root, recursive := strings.CutSuffix(pattern, "/...")
+		if !recursive {
+			if err := scanDir(pattern); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return err
+			}
+			if name := d.Name(); path != root && (name == "testdata" || name == "vendor" ||
+				strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return scanDir(path)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
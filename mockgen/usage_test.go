@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUsedNames(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("a.go", "package a\n\nfunc f(c Client) { c.Get() }\n")
+	write("a_test.go", "package a\n\nfunc g(c Client) { c.Put() }\n")
+	write("mock.go", "// Code generated by MockGen. DO NOT EDIT.\npackage a\n\nfunc h(c Client) { c.Delete() }\n")
+	write("sub/b.go", "package b\n\nvar f = c.List\n")
+	write("testdata/c.go", "package c\n\nfunc f(c Client) { c.Watch() }\n")
+
+	for _, tt := range []struct {
+		patterns []string
+		want     []string
+	}{
+		{[]string{dir}, []string{"Get"}},
+		{[]string{dir + "/..."}, []string{"Get", "List"}},
+		{[]string{filepath.Join(dir, "sub"), filepath.Join(dir, "testdata")}, []string{"List", "Watch"}},
+	} {
+		names, err := usedNames(tt.patterns)
+		if err != nil {
+			t.Fatalf("usedNames(%q): %v", tt.patterns, err)
+		}
+		want := make(map[string]bool)
+		for _, name := range tt.want {
+			want[name] = true
+		}
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("usedNames(%q) = %v, want %v", tt.patterns, names, want)
+		}
+	}
+}
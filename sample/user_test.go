@@ -2,6 +2,8 @@
 package user_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"go.uber.org/mock/gomock"
@@ -159,37 +161,45 @@ func TestExpectTrueNil(t *testing.T) {
 	mockIndex.Ptr(nil)          // this nil is a nil *int
 }
 
+// fatalRecorder is a gomock.TestReporter recording fatal failures instead of
+// stopping the test.
+type fatalRecorder struct {
+	fatals []string
+}
+
+func (r *fatalRecorder) Errorf(format string, args ...any) {}
+
+func (r *fatalRecorder) Fatalf(format string, args ...any) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
 func TestDoAndReturnSignature(t *testing.T) {
+	// The signature of the function is checked when the expectation is
+	// declared, even if the call never happens.
 	t.Run("wrong number of return args", func(t *testing.T) {
-		ctrl := gomock.NewController(t)
-		defer ctrl.Finish()
-
-		mockIndex := NewMockIndex(ctrl)
+		r := new(fatalRecorder)
+		mockIndex := NewMockIndex(gomock.NewController(r))
 
 		mockIndex.EXPECT().Slice(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(_ []int, _ []byte) {},
 		)
 
-		defer func() {
-			if r := recover(); r == nil {
-				t.Error("expected panic")
-			}
-		}()
-
-		mockIndex.Slice([]int{0}, []byte("meow"))
+		if len(r.fatals) != 1 || !strings.Contains(r.fatals[0], "wrong number of results") {
+			t.Errorf("got failures %q, want a wrong number of results", r.fatals)
+		}
 	})
 
 	t.Run("wrong type of return arg", func(t *testing.T) {
-		ctrl := gomock.NewController(t)
-		defer ctrl.Finish()
-
-		mockIndex := NewMockIndex(ctrl)
+		r := new(fatalRecorder)
+		mockIndex := NewMockIndex(gomock.NewController(r))
 
 		mockIndex.EXPECT().Slice(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(_ []int, _ []byte) bool {
 				return true
 			})
 
-		mockIndex.Slice([]int{0}, []byte("meow"))
+		if len(r.fatals) != 1 || !strings.Contains(r.fatals[0], "wrong type of result 0") {
+			t.Errorf("got failures %q, want a wrong type of result", r.fatals)
+		}
 	})
 }